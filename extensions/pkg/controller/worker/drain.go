@@ -0,0 +1,101 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DrainingSucceededCondition is the type of the Condition the reconciler maintains on a Worker's status while an
+// Actuator that also implements Drainer is cordoning and evicting the pods of the nodes scheduled for removal.
+const DrainingSucceededCondition gardencorev1beta1.ConditionType = "DrainingSucceeded"
+
+// defaultDrainTimeout is used for a worker pool that does not configure its own
+// MachineControllerManagerSettings.MachineDrainTimeout.
+const defaultDrainTimeout = 10 * time.Minute
+
+// Drainer is an optional capability an Actuator may implement in addition to Actuator itself. If it does, the
+// reconciler drains the nodes belonging to a Worker (cordon, then evict pods honoring PodDisruptionBudgets) before
+// calling Actuator.Delete, instead of deleting the underlying machines out from under still-running workloads.
+type Drainer interface {
+	// Drain cordons and evicts the pods of the nodes scheduled for removal as part of deleting worker. A non-zero
+	// reconcile.Result (typically RequeueAfter) means draining is still in progress; the reconciler returns it as-is
+	// so the queue backs off and calls Drain again later, rather than blocking inside a single Reconcile call.
+	Drain(ctx context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (reconcile.Result, error)
+}
+
+// drain invokes drainer.Drain, records its outcome as the DrainingSucceededCondition on worker.Status, and emits an
+// event if draining fails. If draining has been in progress for longer than the worker pools' configured (or
+// default) drain timeout, drain is force-completed so that deletion is not blocked indefinitely by pods that cannot
+// be evicted.
+func (r *reconciler) drain(ctx context.Context, logger logr.Logger, drainer Drainer, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (reconcile.Result, error) {
+	condition := gardencorev1beta1helper.GetOrInitCondition(worker.Status.Conditions, DrainingSucceededCondition)
+
+	result, err := drainer.Drain(ctx, worker, cluster)
+	switch {
+	case err != nil:
+		condition = gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionFalse, "DrainFailed", err.Error())
+		r.recorder.Event(worker, corev1.EventTypeWarning, "DrainFailed", err.Error())
+
+	case result.Requeue || result.RequeueAfter > 0:
+		if condition.Status == gardencorev1beta1.ConditionFalse && time.Since(condition.LastTransitionTime.Time) > drainTimeout(worker) {
+			logger.Info("Drain timeout exceeded, proceeding with deletion", "timeout", drainTimeout(worker))
+			r.recorder.Eventf(worker, corev1.EventTypeWarning, "DrainTimeoutExceeded", "Drain timeout of %s exceeded, proceeding with deletion", drainTimeout(worker))
+			condition = gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "DrainTimeoutExceeded", "Drain timeout exceeded, proceeding with deletion")
+			result, err = reconcile.Result{}, nil
+		} else {
+			condition = gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionFalse, "Draining", "Nodes scheduled for removal are still draining")
+		}
+
+	default:
+		condition = gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "DrainSucceeded", "All nodes scheduled for removal have been drained")
+	}
+
+	if updateErr := newStatusWriter(worker).SetCondition(condition).Flush(ctx, r.client); updateErr != nil {
+		logger.Error(updateErr, "Failed to update DrainingSucceededCondition")
+	}
+
+	return result, err
+}
+
+// drainTimeout returns the longest drain timeout among worker.Spec.Pools, where a pool without a configured
+// MachineDrainTimeout counts with defaultDrainTimeout. A Worker without pools also gets defaultDrainTimeout.
+func drainTimeout(worker *extensionsv1alpha1.Worker) time.Duration {
+	if len(worker.Spec.Pools) == 0 {
+		return defaultDrainTimeout
+	}
+
+	var timeout time.Duration
+	for _, pool := range worker.Spec.Pools {
+		d := defaultDrainTimeout
+		if pool.MachineControllerManagerSettings != nil && pool.MachineControllerManagerSettings.MachineDrainTimeout != nil {
+			d = pool.MachineControllerManagerSettings.MachineDrainTimeout.Duration
+		}
+		if d > timeout {
+			timeout = d
+		}
+	}
+	return timeout
+}
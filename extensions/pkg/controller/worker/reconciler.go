@@ -22,10 +22,11 @@ import (
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 
@@ -42,6 +43,7 @@ const backoffOnInfrastructureRequestThrottling = 10 * time.Minute
 type reconciler struct {
 	logger   logr.Logger
 	actuator Actuator
+	recorder record.EventRecorder
 
 	client client.Client
 	reader client.Reader
@@ -49,12 +51,13 @@ type reconciler struct {
 
 // NewReconciler creates a new reconcile.Reconciler that reconciles
 // Worker resources of Gardener's `extensions.gardener.cloud` API group.
-func NewReconciler(actuator Actuator) reconcile.Reconciler {
+func NewReconciler(mgr manager.Manager, actuator Actuator) reconcile.Reconciler {
 	return extensionscontroller.OperationAnnotationWrapper(
 		func() client.Object { return &extensionsv1alpha1.Worker{} },
 		&reconciler{
 			logger:   log.Log.WithName(ControllerName),
 			actuator: actuator,
+			recorder: mgr.GetEventRecorderFor(ControllerName),
 		},
 	)
 }
@@ -121,30 +124,31 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	return result, err
 }
 
+// updateStatusProcessing is a thin, backward-compatible shim over StatusWriter for callers that only need to record
+// a single LastOperation transition rather than batching several mutations into one Flush.
 func (r *reconciler) updateStatusProcessing(ctx context.Context, logger logr.Logger, worker *extensionsv1alpha1.Worker, lastOperationType gardencorev1beta1.LastOperationType, description string) error {
 	logger.Info(description)
-	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, worker, func() error {
-		worker.Status.LastOperation = extensionscontroller.LastOperation(lastOperationType, gardencorev1beta1.LastOperationStateProcessing, 1, description)
-		return nil
-	})
+	return newStatusWriter(worker).
+		SetLastOperation(lastOperationType, gardencorev1beta1.LastOperationStateProcessing, 1, description).
+		Flush(ctx, r.client)
 }
 
+// updateStatusError is a thin, backward-compatible shim over StatusWriter; see updateStatusProcessing.
 func (r *reconciler) updateStatusError(ctx context.Context, err error, worker *extensionsv1alpha1.Worker, lastOperationType gardencorev1beta1.LastOperationType, description string) {
-	updateErr := extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, worker, func() error {
-		worker.Status.ObservedGeneration = worker.Generation
-		worker.Status.LastOperation, worker.Status.LastError = extensionscontroller.ReconcileError(lastOperationType, gardencorev1beta1helper.FormatLastErrDescription(fmt.Errorf("%s: %v", description, extensionscontroller.ReconcileErrCauseOrErr(err))), 50, gardencorev1beta1helper.ExtractErrorCodes(gardencorev1beta1helper.DetermineError(err, err.Error()))...)
-		return nil
-	})
+	updateErr := newStatusWriter(worker).
+		SetObservedGeneration().
+		SetLastError(lastOperationType, gardencorev1beta1helper.FormatLastErrDescription(fmt.Errorf("%s: %v", description, extensionscontroller.ReconcileErrCauseOrErr(err))), gardencorev1beta1helper.ExtractErrorCodes(gardencorev1beta1helper.DetermineError(err, err.Error()))...).
+		Flush(ctx, r.client)
 	utilruntime.HandleError(updateErr)
 }
 
+// updateStatusSuccess is a thin, backward-compatible shim over StatusWriter; see updateStatusProcessing.
 func (r *reconciler) updateStatusSuccess(ctx context.Context, logger logr.Logger, worker *extensionsv1alpha1.Worker, lastOperationType gardencorev1beta1.LastOperationType, description string) error {
 	logger.Info(description)
-	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, r.client, worker, func() error {
-		worker.Status.ObservedGeneration = worker.Generation
-		worker.Status.LastOperation, worker.Status.LastError = extensionscontroller.ReconcileSucceeded(lastOperationType, description)
-		return nil
-	})
+	return newStatusWriter(worker).
+		SetObservedGeneration().
+		SetLastOperation(lastOperationType, gardencorev1beta1.LastOperationStateSucceeded, 100, description).
+		Flush(ctx, r.client)
 }
 
 func (r *reconciler) removeFinalizerFromWorker(ctx context.Context, logger logr.Logger, worker *extensionsv1alpha1.Worker) error {
@@ -196,6 +200,12 @@ func (r *reconciler) delete(ctx context.Context, logger logr.Logger, worker *ext
 		return reconcile.Result{}, err
 	}
 
+	if drainer, ok := r.actuator.(Drainer); ok {
+		if result, err := r.drain(ctx, logger, drainer, worker, cluster); err != nil || !result.IsZero() {
+			return result, err
+		}
+	}
+
 	if err := r.actuator.Delete(ctx, worker, cluster); err != nil {
 		r.updateStatusError(ctx, err, worker, gardencorev1beta1.LastOperationTypeDelete, "Error deleting worker")
 		return extensionscontroller.ReconcileErr(err)
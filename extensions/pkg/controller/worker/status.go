@@ -0,0 +1,116 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"net/http"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Additional WorkerStatus.Conditions types this reconciler maintains, alongside DrainingSucceededCondition (see
+// drain.go). Nothing in this package populates MachineDeploymentsAvailableCondition or NodesReadyCondition yet -
+// that requires watching the MachineDeployments/Nodes belonging to a Worker, which lives in the (provider-specific)
+// Actuator, not here - but StatusWriter.SetCondition works with either once an Actuator starts reporting them.
+const (
+	MachineDeploymentsAvailableCondition gardencorev1beta1.ConditionType = "MachineDeploymentsAvailable"
+	NodesReadyCondition                  gardencorev1beta1.ConditionType = "NodesReady"
+)
+
+// statusFieldManager is the field manager used for the server-side apply patches StatusWriter issues.
+const statusFieldManager = "gardener-extension-worker"
+
+// StatusWriter accumulates Worker status mutations (LastOperation/LastError transitions, ObservedGeneration,
+// Conditions, Progress) in-memory and applies them with a single patch on Flush, instead of each caller issuing its
+// own get-and-update round trip against the status subresource.
+type StatusWriter struct {
+	worker    *extensionsv1alpha1.Worker
+	mutations []func(*extensionsv1alpha1.WorkerStatus)
+}
+
+// newStatusWriter creates a StatusWriter accumulating mutations to worker's status.
+func newStatusWriter(worker *extensionsv1alpha1.Worker) *StatusWriter {
+	return &StatusWriter{worker: worker}
+}
+
+// SetLastOperation records a LastOperation transition.
+func (w *StatusWriter) SetLastOperation(lastOperationType gardencorev1beta1.LastOperationType, state gardencorev1beta1.LastOperationState, progress int32, description string) *StatusWriter {
+	w.mutations = append(w.mutations, func(status *extensionsv1alpha1.WorkerStatus) {
+		status.LastOperation = extensionscontroller.LastOperation(lastOperationType, state, progress, description)
+	})
+	return w
+}
+
+// SetLastError records lastOperation/lastError for a failed operation, as extensionscontroller.ReconcileError would.
+func (w *StatusWriter) SetLastError(lastOperationType gardencorev1beta1.LastOperationType, description string, errorCodes ...gardencorev1beta1.ErrorCode) *StatusWriter {
+	w.mutations = append(w.mutations, func(status *extensionsv1alpha1.WorkerStatus) {
+		status.LastOperation, status.LastError = extensionscontroller.ReconcileError(lastOperationType, description, 50, errorCodes...)
+	})
+	return w
+}
+
+// SetObservedGeneration sets status.ObservedGeneration to the Worker's current Generation.
+func (w *StatusWriter) SetObservedGeneration() *StatusWriter {
+	w.mutations = append(w.mutations, func(status *extensionsv1alpha1.WorkerStatus) {
+		status.ObservedGeneration = w.worker.Generation
+	})
+	return w
+}
+
+// SetCondition merges condition into status.Conditions (see gardencorev1beta1helper.MergeConditions).
+func (w *StatusWriter) SetCondition(condition gardencorev1beta1.Condition) *StatusWriter {
+	w.mutations = append(w.mutations, func(status *extensionsv1alpha1.WorkerStatus) {
+		status.Conditions = gardencorev1beta1helper.MergeConditions(status.Conditions, condition)
+	})
+	return w
+}
+
+// SetProgress sets status.Progress (0-100), letting gardenctl and dashboards show migration/reconcile progress
+// without tailing logs.
+func (w *StatusWriter) SetProgress(progress int32) *StatusWriter {
+	w.mutations = append(w.mutations, func(status *extensionsv1alpha1.WorkerStatus) {
+		status.Progress = &progress
+	})
+	return w
+}
+
+// Flush applies every accumulated mutation to the Worker's in-memory status and persists it with a single
+// server-side apply patch, falling back to a strategic-merge patch if the API server rejects the apply content type
+// (HTTP 415, e.g. because the CustomResourceDefinition does not have structural schemas / SSA enabled).
+func (w *StatusWriter) Flush(ctx context.Context, c client.Client) error {
+	mergePatch := client.MergeFrom(w.worker.DeepCopy())
+
+	for _, mutate := range w.mutations {
+		mutate(&w.worker.Status)
+	}
+	w.mutations = nil
+
+	err := c.Status().Patch(ctx, w.worker, client.Apply, client.FieldOwner(statusFieldManager), client.ForceOwnership)
+	if err == nil {
+		return nil
+	}
+
+	if statusErr, ok := err.(*apierrors.StatusError); !ok || statusErr.Status().Code != http.StatusUnsupportedMediaType {
+		return err
+	}
+	return c.Status().Patch(ctx, w.worker, mergePatch)
+}
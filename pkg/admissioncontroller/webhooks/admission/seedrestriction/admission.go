@@ -0,0 +1,615 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package seedrestriction contains a webhook handler that restricts requests from gardenlets (running on Seeds) to
+// only those objects that actually belong to the Seed that is making the request, preventing one Seed from reading
+// or writing another Seed's state.
+package seedrestriction
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gardener/gardener/pkg/admissioncontroller/webhooks/admission/seedrestriction/admissioncsr"
+	"github.com/gardener/gardener/pkg/admissioncontroller/webhooks/auth/seed/seedauthorizer"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenoperationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Name is the name of this admission webhook handler.
+const Name = "seedrestriction"
+
+// gardenSeedManagementNamespace is the namespace in which ManagedSeed objects live.
+const gardenSeedManagementNamespace = "garden"
+
+// leaderElectionLeaseName is the name of the Lease object used by gardenlets for leader election, which is not
+// owned by any particular seed and must always be allowed.
+const leaderElectionLeaseName = "gardenlet-leader-election"
+
+var (
+	shootStateResource                = schema.GroupResource{Group: gardencorev1alpha1.SchemeGroupVersion.Group, Resource: "shootstates"}
+	shootExtensionStatusResource      = schema.GroupResource{Group: gardencorev1alpha1.SchemeGroupVersion.Group, Resource: "shootextensionstatuses"}
+	backupBucketResource              = schema.GroupResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupbuckets"}
+	backupEntryResource               = schema.GroupResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupentries"}
+	seedResource                      = schema.GroupResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "seeds"}
+	bastionResource                   = schema.GroupResource{Group: gardenoperationsv1alpha1.SchemeGroupVersion.Group, Resource: "bastions"}
+	leaseResource                     = schema.GroupResource{Group: coordinationv1.SchemeGroupVersion.Group, Resource: "leases"}
+	certificateSigningRequestResource = schema.GroupResource{Group: certificatesv1beta1.SchemeGroupVersion.Group, Resource: "certificatesigningrequests"}
+
+	shootStateKind           = gardencorev1alpha1.SchemeGroupVersion.WithKind("ShootState")
+	shootExtensionStatusKind = gardencorev1alpha1.SchemeGroupVersion.WithKind("ShootExtensionStatus")
+	backupBucketKind         = gardencorev1beta1.SchemeGroupVersion.WithKind("BackupBucket")
+	backupEntryKind          = gardencorev1beta1.SchemeGroupVersion.WithKind("BackupEntry")
+	bastionKind              = gardenoperationsv1alpha1.SchemeGroupVersion.WithKind("Bastion")
+	leaseKind                = coordinationv1.SchemeGroupVersion.WithKind("Lease")
+)
+
+// handler restricts requests from gardenlets (acting on behalf of a Seed) to objects related to their own Seed.
+type handler struct {
+	logger      logr.Logger
+	cacheReader client.Reader
+	decoder     *admission.Decoder
+	config      Config
+
+	// verifier, if set, authenticates the calling seed's identity via a stronger mechanism (e.g. SPIFFE/SVID) than
+	// the conventional gardener.cloud:system:seed:<name> username prefix. See WithVerifier.
+	verifier Verifier
+
+	// sinks receive a structured AuditRecord for every admission decision. See WithSinks.
+	sinks []SinkInterface
+
+	// bucketSeedIndex resolves a BackupBucket's owning seed without a synchronous cacheReader.Get; see
+	// bucket_seed_index.go.
+	bucketSeedIndex *bucketSeedIndex
+
+	// csrAPIVersions is the set of certificates.k8s.io API versions ("v1", "v1beta1") this cluster is known to
+	// serve CertificateSigningRequests through. A request made via any other version is rejected outright, so a
+	// cluster that has dropped v1beta1 does not silently keep accepting it. See WithCertificateSigningRequestAPIVersions.
+	csrAPIVersions map[string]bool
+}
+
+// defaultCSRAPIVersions is used unless WithCertificateSigningRequestAPIVersions overrides it.
+var defaultCSRAPIVersions = map[string]bool{
+	certificatesv1.SchemeGroupVersion.Version:      true,
+	certificatesv1beta1.SchemeGroupVersion.Version: true,
+}
+
+// Option customizes a handler created by New.
+type Option func(*handler)
+
+// WithVerifier configures the handler to resolve the calling seed's identity via verifier instead of the
+// conventional username-prefix convention. See Verifier and seedIdentityFromRequest.
+func WithVerifier(verifier Verifier) Option {
+	return func(h *handler) { h.verifier = verifier }
+}
+
+// WithConfig configures the per-GroupVersionKind UPDATE field allow-list enforced by the handler. Without this
+// option, every guarded resource defaults to defaultFieldAllowList.
+func WithConfig(config Config) Option {
+	return func(h *handler) { h.config = config }
+}
+
+// WithSinks registers one or more SinkInterface implementations that receive a structured AuditRecord for every
+// admission decision (see audit.go). Sinks are invoked in the order given, best effort: a failing sink is logged but
+// never turns an already-decided admission.Response into an error.
+func WithSinks(sinks ...SinkInterface) Option {
+	return func(h *handler) { h.sinks = append(h.sinks, sinks...) }
+}
+
+// WithCertificateSigningRequestAPIVersions restricts the certificates.k8s.io API versions the handler accepts
+// CertificateSigningRequests through to versions, overriding defaultCSRAPIVersions (both "v1" and "v1beta1").
+func WithCertificateSigningRequestAPIVersions(versions ...string) Option {
+	return func(h *handler) {
+		h.csrAPIVersions = make(map[string]bool, len(versions))
+		for _, version := range versions {
+			h.csrAPIVersions[version] = true
+		}
+	}
+}
+
+// New creates a new handler for the seedrestriction webhook that authenticates callers via the conventional
+// gardener.cloud:system:seed:<name> username prefix and gardener.cloud:system:seeds group membership, unless
+// overridden via WithVerifier.
+func New(ctx context.Context, logger logr.Logger, c cache.Cache, opts ...Option) (admission.Handler, error) {
+	backupBucketInformer, err := c.GetInformer(ctx, &gardencorev1beta1.BackupBucket{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get informer for %T: %w", &gardencorev1beta1.BackupBucket{}, err)
+	}
+
+	// Warm the informers for the remaining object kinds this handler reads synchronously out-of-band below, so
+	// that the first admission requests do not have to pay for an uncached List/Get against the API server.
+	for _, obj := range []client.Object{
+		&seedmanagementv1alpha1.ManagedSeed{},
+		&gardencorev1beta1.Shoot{},
+	} {
+		if _, err := c.GetInformer(ctx, obj); err != nil {
+			return nil, fmt.Errorf("failed to get informer for %T: %w", obj, err)
+		}
+	}
+
+	bucketSeedIndex := newBucketSeedIndex()
+	bucketSeedIndex.registerWith(backupBucketInformer)
+
+	h := &handler{
+		logger:          logger,
+		cacheReader:     c,
+		bucketSeedIndex: bucketSeedIndex,
+		csrAPIVersions:  defaultCSRAPIVersions,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// InjectDecoder injects the given decoder into the handler.
+func (h *handler) InjectDecoder(d *admission.Decoder) error {
+	h.decoder = d
+	return nil
+}
+
+// Handle implements the admission.Handler interface. It wraps admit with structured audit logging and metrics, so
+// every decision is recorded regardless of which code path produced it.
+func (h *handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	start := time.Now()
+	seedName, ambiguous, response := h.admit(ctx, req)
+	h.audit(ctx, req, seedName, ambiguous, response, time.Since(start))
+	return response
+}
+
+// audit records the outcome of an admission decision to every configured sink and to the Prometheus counters, best
+// effort: a sink failure is logged but never turns an already-decided admission.Response into an error.
+func (h *handler) audit(ctx context.Context, req admission.Request, seedName string, ambiguous bool, response admission.Response, latency time.Duration) {
+	resource := req.Resource.Resource
+
+	decision := DecisionAllowed
+	reason := ""
+	if !response.Allowed {
+		decision = DecisionDenied
+		if response.Result != nil {
+			reason = response.Result.Message
+		}
+	}
+
+	metricDecisionsTotal.WithLabelValues(resource, string(decision), reason).Inc()
+	if ambiguous {
+		metricAmbiguousOverridesTotal.WithLabelValues(resource).Inc()
+	}
+
+	if len(h.sinks) == 0 {
+		return
+	}
+
+	record := AuditRecord{
+		RequestUID: req.UID,
+		UserInfo:   req.UserInfo,
+		Resource:   schema.GroupVersionResource{Group: req.Resource.Group, Version: req.Resource.Version, Resource: req.Resource.Resource},
+		Namespace:  req.Namespace,
+		Name:       req.Name,
+		SeedName:   seedName,
+		Decision:   decision,
+		Reason:     reason,
+		Latency:    latency,
+	}
+
+	for _, sink := range h.sinks {
+		if err := sink.Record(ctx, record); err != nil {
+			h.logger.Error(err, "Failed to record seedrestriction audit event", "sink", fmt.Sprintf("%T", sink))
+		}
+	}
+}
+
+// admit contains the actual admission decision logic formerly exposed directly as Handle; seedName/ambiguous are
+// also returned so Handle can attach them to the audit record even when admit itself returns an early Allowed/Denied
+// response (e.g. because the resource is unhandled).
+func (h *handler) admit(ctx context.Context, req admission.Request) (seedName string, ambiguous bool, response admission.Response) {
+	var isSeed bool
+
+	seedName, isSeed, ambiguous, err := h.seedIdentityFromRequest(req.UserInfo)
+	if err != nil {
+		return seedName, ambiguous, admission.Errored(http.StatusForbidden, err)
+	}
+	if !isSeed {
+		return seedName, ambiguous, admission.Allowed("")
+	}
+
+	gr := schema.GroupResource{Group: req.Resource.Group, Resource: req.Resource.Resource}
+
+	switch gr {
+	case shootStateResource:
+		return seedName, ambiguous, h.admitUsingSeedNameOfShoot(ctx, seedName, ambiguous, shootStateKind, req)
+
+	case shootExtensionStatusResource:
+		return seedName, ambiguous, h.admitUsingSeedNameOfShoot(ctx, seedName, ambiguous, shootExtensionStatusKind, req)
+
+	case backupBucketResource:
+		return seedName, ambiguous, h.admitBackupBucket(ctx, seedName, ambiguous, req)
+
+	case backupEntryResource:
+		return seedName, ambiguous, h.admitBackupEntry(ctx, seedName, ambiguous, req)
+
+	case bastionResource:
+		return seedName, ambiguous, h.admitBastion(ctx, seedName, ambiguous, req)
+
+	case leaseResource:
+		return seedName, ambiguous, h.admitLease(seedName, ambiguous, req)
+
+	case seedResource:
+		if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update && req.Operation != admissionv1.Delete {
+			return seedName, ambiguous, admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+		}
+		return seedName, ambiguous, h.admitSeed(ctx, seedName, ambiguous, req)
+
+	case certificateSigningRequestResource:
+		if req.Operation != admissionv1.Create {
+			return seedName, ambiguous, admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+		}
+		return seedName, ambiguous, h.admitCertificateSigningRequest(seedName, ambiguous, req)
+	}
+
+	return seedName, ambiguous, admission.Allowed("")
+}
+
+// seedIdentityFromRequest resolves the name of the seed a request is acting on behalf of. If verifier is configured
+// it is the only source of a seed identity: a verifier that errors fails the request outright, and a verifier that
+// finds no verifiable identity at all (ok == false, err == nil) leaves a request whose username claims a seed
+// (including the ambiguous one) unverified, which fails it as well. Only a request whose username does not match the
+// seed prefix is then recognized as non-seed traffic and allowed. Without a verifier, the conventional
+// username-prefix check decides.
+func (h *handler) seedIdentityFromRequest(userInfo authenticationv1.UserInfo) (seedName string, isSeed, ambiguous bool, err error) {
+	if h.verifier != nil {
+		name, ok, err := h.verifier.VerifySeedName(userInfo)
+		if err != nil {
+			return "", false, false, err
+		}
+		if ok {
+			return name, true, false, nil
+		}
+		if claimedName, claimsSeed, _ := seedauthorizer.SeedIdentityFromUserInfo(userInfo); claimsSeed {
+			return claimedName, false, false, fmt.Errorf("seed identity %q could not be verified", claimedName)
+		}
+		return "", false, false, nil
+	}
+
+	name, isSeed, ambiguous := seedauthorizer.SeedIdentityFromUserInfo(userInfo)
+	return name, isSeed, ambiguous, nil
+}
+
+func seedMismatchErr(seedName string) error {
+	return fmt.Errorf("object does not belong to seed %q", seedName)
+}
+
+func seedNameMatches(ambiguous bool, seedName string, candidate *string) bool {
+	return seedauthorizer.SeedNameMatches(ambiguous, seedName, candidate)
+}
+
+// checkUpdateDiff enforces the configured field allow-list for gvk on an UPDATE request. ok is false if the caller
+// should return resp immediately (either a decode failure or a disallowed field change); ambiguous callers skip the
+// check entirely, matching the escape hatch CREATE already grants them.
+func (h *handler) checkUpdateDiff(req admission.Request, gvk schema.GroupVersionKind, ambiguous bool) (resp admission.Response, ok bool) {
+	if ambiguous {
+		return admission.Response{}, true
+	}
+
+	newObj := &unstructured.Unstructured{}
+	if err := h.decoder.DecodeRaw(req.Object, newObj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err), false
+	}
+
+	oldObj := &unstructured.Unstructured{}
+	if err := h.decoder.DecodeRaw(req.OldObject, oldObj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err), false
+	}
+
+	if err := checkUpdateAllowed(h.config, gvk, oldObj, newObj); err != nil {
+		return admission.Errored(http.StatusForbidden, err), false
+	}
+
+	return admission.Response{}, true
+}
+
+func (h *handler) admitUsingSeedNameOfShoot(ctx context.Context, seedName string, ambiguous bool, gvk schema.GroupVersionKind, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update, admissionv1.Delete:
+	default:
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+	}
+
+	if req.Operation == admissionv1.Update {
+		if resp, ok := h.checkUpdateDiff(req, gvk, ambiguous); !ok {
+			return resp
+		}
+	}
+
+	// ShootStates and ShootExtensionStatuses carry no seed identity of their own; ownership is always derived from
+	// the Shoot sharing their name and namespace.
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := h.cacheReader.Get(ctx, kutil.Key(req.Namespace, req.Name), shoot); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !seedNameMatches(ambiguous, seedName, shoot.Spec.SeedName) {
+		return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+	}
+
+	return admission.Allowed("")
+}
+
+func (h *handler) admitBackupBucket(ctx context.Context, seedName string, ambiguous bool, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create:
+		backupBucket := &gardencorev1beta1.BackupBucket{}
+		if err := h.decoder.DecodeRaw(req.Object, backupBucket); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, backupBucket.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Update:
+		if resp, ok := h.checkUpdateDiff(req, backupBucketKind, ambiguous); !ok {
+			return resp
+		}
+		oldBackupBucket := &gardencorev1beta1.BackupBucket{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldBackupBucket); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, oldBackupBucket.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Delete:
+		if ambiguous {
+			return admission.Allowed("")
+		}
+		existing := &gardencorev1beta1.BackupBucket{}
+		if err := h.cacheReader.Get(ctx, kutil.Key(req.Name), existing); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !seedNameMatches(false, seedName, existing.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+	}
+
+	return admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+}
+
+// seedNameOfBackupBucket resolves the seed name a BackupBucket belongs to, preferring the in-memory
+// bucketSeedIndex over a cacheReader.Get so a burst of BackupEntry admission requests does not serialize on cache
+// reads for their (shared) parent bucket. It falls back to a direct Get on an index miss, e.g. because the
+// BackupBucket informer has not synced yet.
+func (h *handler) seedNameOfBackupBucket(ctx context.Context, bucketName string) (*string, error) {
+	if seedName, ok := h.bucketSeedIndex.lookup(bucketName); ok {
+		return seedName, nil
+	}
+
+	backupBucket := &gardencorev1beta1.BackupBucket{}
+	if err := h.cacheReader.Get(ctx, kutil.Key(bucketName), backupBucket); err != nil {
+		return nil, err
+	}
+	return backupBucket.Spec.SeedName, nil
+}
+
+func (h *handler) admitBackupEntry(ctx context.Context, seedName string, ambiguous bool, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create:
+		backupEntry := &gardencorev1beta1.BackupEntry{}
+		if err := h.decoder.DecodeRaw(req.Object, backupEntry); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, backupEntry.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+
+		bucketSeedName, err := h.seedNameOfBackupBucket(ctx, backupEntry.Spec.BucketName)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, bucketSeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Update:
+		if resp, ok := h.checkUpdateDiff(req, backupEntryKind, ambiguous); !ok {
+			return resp
+		}
+		// The bucket a BackupEntry points at is not expected to change after creation, so only the entry's own
+		// seed ownership is re-checked here; a bucket-reassignment would already be rejected by checkUpdateDiff
+		// since spec.bucketName is not in the allow-list.
+		oldBackupEntry := &gardencorev1beta1.BackupEntry{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldBackupEntry); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, oldBackupEntry.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Delete:
+		if ambiguous {
+			return admission.Allowed("")
+		}
+		existing := &gardencorev1beta1.BackupEntry{}
+		if err := h.cacheReader.Get(ctx, kutil.Key(req.Namespace, req.Name), existing); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !seedNameMatches(false, seedName, existing.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+	}
+
+	return admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+}
+
+func (h *handler) admitBastion(ctx context.Context, seedName string, ambiguous bool, req admission.Request) admission.Response {
+	switch req.Operation {
+	case admissionv1.Create:
+		bastion := &gardenoperationsv1alpha1.Bastion{}
+		if err := h.decoder.DecodeRaw(req.Object, bastion); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, bastion.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Update:
+		if resp, ok := h.checkUpdateDiff(req, bastionKind, ambiguous); !ok {
+			return resp
+		}
+		oldBastion := &gardenoperationsv1alpha1.Bastion{}
+		if err := h.decoder.DecodeRaw(req.OldObject, oldBastion); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		if !seedNameMatches(ambiguous, seedName, oldBastion.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Delete:
+		if ambiguous {
+			return admission.Allowed("")
+		}
+		existing := &gardenoperationsv1alpha1.Bastion{}
+		if err := h.cacheReader.Get(ctx, kutil.Key(req.Namespace, req.Name), existing); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		if !seedNameMatches(false, seedName, existing.Spec.SeedName) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+	}
+
+	return admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+}
+
+func (h *handler) admitLease(seedName string, ambiguous bool, req admission.Request) admission.Response {
+	// The leader-election lease is not owned by any particular seed; every gardenlet instance needs to be able to
+	// acquire it.
+	if req.Name == leaderElectionLeaseName {
+		return admission.Allowed("")
+	}
+
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Delete:
+		if !ambiguous && req.Name != seedName {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+
+	case admissionv1.Update:
+		if resp, ok := h.checkUpdateDiff(req, leaseKind, ambiguous); !ok {
+			return resp
+		}
+		if !ambiguous && req.Name != seedName {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Allowed("")
+	}
+
+	return admission.Errored(http.StatusBadRequest, fmt.Errorf("unexpected operation: %q", req.Operation))
+}
+
+func (h *handler) admitSeed(ctx context.Context, seedName string, ambiguous bool, req admission.Request) admission.Response {
+	if ambiguous || req.Name == seedName {
+		return admission.Allowed("")
+	}
+
+	managedSeed := &seedmanagementv1alpha1.ManagedSeed{}
+	if err := h.cacheReader.Get(ctx, kutil.Key(gardenSeedManagementNamespace, req.Name), managedSeed); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+		}
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if req.Operation == admissionv1.Delete {
+		if managedSeed.DeletionTimestamp == nil {
+			return admission.Errored(http.StatusForbidden, fmt.Errorf("object can only be deleted if corresponding ManagedSeed has a deletion timestamp"))
+		}
+	} else if managedSeed.Spec.SeedTemplate == nil {
+		return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+	}
+
+	if managedSeed.Spec.Shoot == nil {
+		return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+	}
+
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := h.cacheReader.Get(ctx, kutil.Key(managedSeed.Namespace, managedSeed.Spec.Shoot.Name), shoot); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if shoot.Spec.SeedName == nil || *shoot.Spec.SeedName != seedName {
+		return admission.Errored(http.StatusForbidden, seedMismatchErr(seedName))
+	}
+
+	return admission.Allowed("")
+}
+
+// admitCertificateSigningRequest decodes req.Object as whichever of the certificates.k8s.io API versions this
+// cluster is known to serve (see WithCertificateSigningRequestAPIVersions) matches req.Resource.Version, and
+// delegates the actual CSR validation to admissioncsr.ValidateSeedCSR so the PEM-parsing logic has exactly one
+// implementation shared with other callers.
+func (h *handler) admitCertificateSigningRequest(seedName string, ambiguous bool, req admission.Request) admission.Response {
+	version := req.Resource.Version
+	if version == "" {
+		version = certificatesv1beta1.SchemeGroupVersion.Version
+	}
+	if !h.csrAPIVersions[version] {
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("certificatesigningrequests API version %q is not served by this cluster", version))
+	}
+
+	var csr runtime.Object
+	if version == certificatesv1.SchemeGroupVersion.Version {
+		csr = &certificatesv1.CertificateSigningRequest{}
+	} else {
+		csr = &certificatesv1beta1.CertificateSigningRequest{}
+	}
+
+	if err := h.decoder.DecodeRaw(req.Object, csr); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := admissioncsr.ValidateSeedCSR(csr, seedName, ambiguous); err != nil {
+		return admission.Errored(http.StatusForbidden, err)
+	}
+
+	return admission.Allowed("")
+}
@@ -36,6 +36,7 @@ import (
 	. "github.com/onsi/gomega"
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -128,6 +129,69 @@ var _ = Describe("handler", func() {
 			})
 		})
 
+		Context("when a Verifier is configured", func() {
+			var verifierHandler admission.Handler
+
+			BeforeEach(func() {
+				mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.BackupBucket{}))
+				mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&seedmanagementv1alpha1.ManagedSeed{}))
+				mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{}))
+
+				verifierHandler, err = New(ctx, logger, mockCache, WithVerifier(&SPIFFEVerifier{TrustDomain: "gardener.cloud"}))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admission.InjectDecoderInto(decoder, verifierHandler)).To(BeTrue())
+
+				request.Operation = admissionv1.Create
+				request.Name = seedName
+				request.Resource = metav1.GroupVersionResource{
+					Group:    coordinationv1.SchemeGroupVersion.Group,
+					Resource: "leases",
+				}
+			})
+
+			It("should allow the request via the verified SPIFFE identity", func() {
+				request.UserInfo = authenticationv1.UserInfo{
+					Extra: map[string]authenticationv1.ExtraValue{
+						"authentication.kubernetes.io/spiffe-id": {fmt.Sprintf("spiffe://gardener.cloud/seed/%s", seedName)},
+					},
+				}
+
+				Expect(verifierHandler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+
+			It("should deny the request when the SPIFFE ID does not belong to the trusted domain", func() {
+				request.UserInfo = authenticationv1.UserInfo{
+					Extra: map[string]authenticationv1.ExtraValue{
+						"authentication.kubernetes.io/spiffe-id": {fmt.Sprintf("spiffe://evil.example/seed/%s", seedName)},
+					},
+				}
+
+				resp := verifierHandler.Handle(ctx, request)
+				Expect(resp.Allowed).To(BeFalse())
+				Expect(resp.Result.Code).To(Equal(int32(http.StatusForbidden)))
+			})
+
+			It("should deny a request whose username claims a seed but which carries no verifiable identity, rather than falling back to the username-prefix convention", func() {
+				request.UserInfo = seedUser
+
+				Expect(verifierHandler.Handle(ctx, request)).To(Equal(admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:    int32(http.StatusForbidden),
+							Message: fmt.Sprintf("seed identity %q could not be verified", seedName),
+						},
+					},
+				}))
+			})
+
+			It("should still allow genuinely non-seed traffic via the username-prefix fallback", func() {
+				request.UserInfo = authenticationv1.UserInfo{Username: "foo"}
+
+				Expect(verifierHandler.Handle(ctx, request)).To(Equal(responseAllowed))
+			})
+		})
+
 		Context("when requested for ShootStates", func() {
 			var name, namespace string
 
@@ -158,8 +222,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -224,6 +286,85 @@ var _ = Describe("handler", func() {
 					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
 				})
 			})
+
+			Context("when operation is update", func() {
+				BeforeEach(func() {
+					request.Operation = admissionv1.Update
+					request.Object.Raw = []byte(`{"status":{"gardener":{}}}`)
+					request.OldObject.Raw = []byte(`{"status":{}}`)
+				})
+
+				It("should return an error because decoding the new object failed", func() {
+					request.Object.Raw = []byte(`{]`)
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Code).To(Equal(int32(http.StatusBadRequest)))
+				})
+
+				It("should forbid the request because the update changes a field outside of the allow-list", func() {
+					request.Object.Raw = []byte(`{"status":{},"spec":{"gardener":{}}}`)
+					request.OldObject.Raw = []byte(`{"status":{}}`)
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Code).To(Equal(int32(http.StatusForbidden)))
+				})
+
+				It("should allow the request because the update only changes status and the seed name of the related shoot matches", func() {
+					mockCache.EXPECT().Get(ctx, kutil.Key(namespace, name), gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *gardencorev1beta1.Shoot) error {
+						(&gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{SeedName: &seedName}}).DeepCopyInto(obj)
+						return nil
+					})
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should allow the request because the ambiguous user is exempt from the field allow-list", func() {
+					request.UserInfo = ambiguousUser
+					request.Object.Raw = []byte(`{"status":{},"spec":{"gardener":{}}}`)
+					request.OldObject.Raw = []byte(`{"status":{}}`)
+
+					mockCache.EXPECT().Get(ctx, kutil.Key(namespace, name), gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *gardencorev1beta1.Shoot) error {
+						(&gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{SeedName: pointer.StringPtr("some-different-seed")}}).DeepCopyInto(obj)
+						return nil
+					})
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+			})
+
+			Context("when operation is delete", func() {
+				BeforeEach(func() {
+					request.Operation = admissionv1.Delete
+				})
+
+				It("should forbid the request because the seed name of the related shoot does not match", func() {
+					mockCache.EXPECT().Get(ctx, kutil.Key(namespace, name), gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *gardencorev1beta1.Shoot) error {
+						(&gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{SeedName: pointer.StringPtr("some-different-seed")}}).DeepCopyInto(obj)
+						return nil
+					})
+
+					Expect(handler.Handle(ctx, request)).To(Equal(admission.Response{
+						AdmissionResponse: admissionv1.AdmissionResponse{
+							Allowed: false,
+							Result: &metav1.Status{
+								Code:    int32(http.StatusForbidden),
+								Message: fmt.Sprintf("object does not belong to seed %q", seedName),
+							},
+						},
+					}))
+				})
+
+				It("should allow the request because the seed name of the related shoot matches", func() {
+					mockCache.EXPECT().Get(ctx, kutil.Key(namespace, name), gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *gardencorev1beta1.Shoot) error {
+						(&gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{SeedName: &seedName}}).DeepCopyInto(obj)
+						return nil
+					})
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+			})
 		})
 
 		Context("when requested for ShootExtensionStatuses", func() {
@@ -256,8 +397,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -353,8 +492,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -462,8 +599,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -597,8 +732,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -679,8 +812,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -726,6 +857,28 @@ var _ = Describe("handler", func() {
 					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
 				})
 			})
+
+			Context("when operation is update", func() {
+				BeforeEach(func() {
+					request.Operation = admissionv1.Update
+					request.Name = seedName
+					request.Object.Raw = []byte(`{"spec":{"holderIdentity":"new-holder","renewTime":"2021-01-01T00:00:01Z"}}`)
+					request.OldObject.Raw = []byte(`{"spec":{"holderIdentity":"old-holder","renewTime":"2021-01-01T00:00:00Z"}}`)
+				})
+
+				It("should allow the request because the update only renews the lease's spec", func() {
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should forbid the request because the update changes a field outside of the allow-list", func() {
+					request.Object.Raw = []byte(`{"spec":{"holderIdentity":"new-holder"},"status":{"gardener":{}}}`)
+					request.OldObject.Raw = []byte(`{"spec":{"holderIdentity":"old-holder"}}`)
+
+					response := handler.Handle(ctx, request)
+					Expect(response.Allowed).To(BeFalse())
+					Expect(response.Result.Code).To(Equal(int32(http.StatusForbidden)))
+				})
+			})
 		})
 
 		Context("when requested for Seeds", func() {
@@ -1007,8 +1160,6 @@ var _ = Describe("handler", func() {
 					}))
 				},
 
-				Entry("update", admissionv1.Update),
-				Entry("delete", admissionv1.Delete),
 				Entry("connect", admissionv1.Connect),
 			)
 
@@ -1171,6 +1322,62 @@ yO57qEcJqG1cB7iSchFuCSTuDBbZlN0fXgn4YjiWZyb4l3BDp3rm4iJImA==
 
 					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
 				})
+
+				It("should allow the request for a certificates.k8s.io/v1 CSR", func() {
+					request.Resource.Version = certificatesv1.SchemeGroupVersion.Version
+
+					objData, err := runtime.Encode(encoder, &certificatesv1.CertificateSigningRequest{
+						Spec: certificatesv1.CertificateSigningRequestSpec{
+							Request: []byte(`-----BEGIN CERTIFICATE REQUEST-----
+MIIClTCCAX0CAQAwUDEkMCIGA1UEChMbZ2FyZGVuZXIuY2xvdWQ6c3lzdGVtOnNl
+ZWRzMSgwJgYDVQQDEx9nYXJkZW5lci5jbG91ZDpzeXN0ZW06c2VlZDpzZWVkMIIB
+IjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAsDqibMtE5PXULTT12u0TYW1U
+EI2f2MFImNPdEdmyTO8kjy61JzBQxUz6NLLmZWks7dnhZOrhfXqJjVzLWi7gAAIH
+hkoxnu8spKTV53l6eY5RrivVsNFRuPF763bKd6JvsF1p9QD9y8uk6bY4NbLAjgMJ
+MH64Sj398AnvLlIL+8XIFKtT/SjvOp99oGkKxWHBvokcz9MLUJc/2/JcOdsZ62ue
+ZAsqimh0F085+BoG2YtLa4kLNAAiNsijgJ5QCXc7/F8uqkj4uy436LGgGmDfcQxC
+9W2snEqriv1dsjF5R/kjh+UbTd+ZdHoAaNaiE7lfZcwe/ap6SNeZaszcDoR//wID
+AQABoAAwDQYJKoZIhvcNAQELBQADggEBAKGWWWDHGHdUkOvE1L+tR/v3sDvLfmO7
+jWtF/Sq7kRCrr6xEHLKmVA4wRovpzOML0ntrDCu3npKAWqN+U56L1ZeZSsxyOhvN
+dXjk2wPg0+IXPscd33hq0wGZRtBc5MHNWwYLv3ERKnHNbPE2ifkYy6FQ/h/2Kx55
+tHu5PlIwWS6CP+03s3/gjbHX7VL+V3RF5BIHDWcp9QfjN0zEx0R2WVXKIbhC8RTR
+BkEao/FEz4eQuV5atSD0S78+aF4BriEtWKKjXECTCxMuqcA24vGOgHIrEbKd7zSC
+2L4LgmHdCmMFOtPkykwLK6wV1YW7Ce8AxU3j+q4kgZQ+51HJDQDdB74=
+-----END CERTIFICATE REQUEST-----`),
+							Usages: []certificatesv1.KeyUsage{
+								certificatesv1.UsageKeyEncipherment,
+								certificatesv1.UsageDigitalSignature,
+								certificatesv1.UsageClientAuth,
+							},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					request.Object.Raw = objData
+
+					Expect(handler.Handle(ctx, request)).To(Equal(responseAllowed))
+				})
+
+				It("should reject the request because the certificates.k8s.io/v1beta1 GVR is not served by this cluster", func() {
+					mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.BackupBucket{}))
+					mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&seedmanagementv1alpha1.ManagedSeed{}))
+					mockCache.EXPECT().GetInformer(ctx, gomock.AssignableToTypeOf(&gardencorev1beta1.Shoot{}))
+
+					v1OnlyHandler, err := New(ctx, logger, mockCache, WithCertificateSigningRequestAPIVersions(certificatesv1.SchemeGroupVersion.Version))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(admission.InjectDecoderInto(decoder, v1OnlyHandler)).To(BeTrue())
+
+					request.Resource.Version = certificatesv1beta1.SchemeGroupVersion.Version
+
+					Expect(v1OnlyHandler.Handle(ctx, request)).To(Equal(admission.Response{
+						AdmissionResponse: admissionv1.AdmissionResponse{
+							Allowed: false,
+							Result: &metav1.Status{
+								Code:    int32(http.StatusBadRequest),
+								Message: `certificatesigningrequests API version "v1beta1" is not served by this cluster`,
+							},
+						},
+					}))
+				})
 			})
 		})
 	})
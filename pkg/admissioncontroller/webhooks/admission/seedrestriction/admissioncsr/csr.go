@@ -0,0 +1,138 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admissioncsr validates that a CertificateSigningRequest is a well-formed request for a gardenlet seed
+// client certificate, independent of whether it was submitted via the certificates.k8s.io/v1beta1 or
+// certificates.k8s.io/v1 API. It is shared by the seedrestriction admission webhook and, potentially, other callers
+// that need to validate the same kind of CSR (e.g. a CSR-approving controller) without duplicating the PEM parsing.
+package admissioncsr
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// seedClientCertUsages is the exact set of key usages a seed's client certificate CSR must request, independent of
+// which certificates.k8s.io API version the request was made through.
+var seedClientCertUsages = []string{
+	string(certificatesv1.UsageKeyEncipherment),
+	string(certificatesv1.UsageDigitalSignature),
+	string(certificatesv1.UsageClientAuth),
+}
+
+// ValidateSeedCSR validates that csr (either a *certificatesv1.CertificateSigningRequest or a
+// *certificatesv1beta1.CertificateSigningRequest) is a well-formed request for a seed client certificate, and that
+// its requested identity matches expectedSeedName. ambiguous callers (see seedauthorizer.SeedIdentityFromUserInfo)
+// are exempt from the name match, mirroring the escape hatch seedrestriction grants them everywhere else.
+func ValidateSeedCSR(csr runtime.Object, expectedSeedName string, ambiguous bool) error {
+	pemData, usages, err := requestAndUsages(csr)
+	if err != nil {
+		return err
+	}
+
+	seedName, err := seedNameFromPEM(pemData, usages)
+	if err != nil {
+		return err
+	}
+
+	if !ambiguous && seedName != expectedSeedName {
+		return fmt.Errorf("object does not belong to seed %q", expectedSeedName)
+	}
+	return nil
+}
+
+func requestAndUsages(csr runtime.Object) ([]byte, []string, error) {
+	switch o := csr.(type) {
+	case *certificatesv1.CertificateSigningRequest:
+		usages := make([]string, 0, len(o.Spec.Usages))
+		for _, usage := range o.Spec.Usages {
+			usages = append(usages, string(usage))
+		}
+		return o.Spec.Request, usages, nil
+
+	case *certificatesv1beta1.CertificateSigningRequest:
+		usages := make([]string, 0, len(o.Spec.Usages))
+		for _, usage := range o.Spec.Usages {
+			usages = append(usages, string(usage))
+		}
+		return o.Spec.Request, usages, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported certificate signing request type %T", csr)
+	}
+}
+
+// seedNameFromPEM parses a PEM-encoded PKCS#10 certificate signing request and extracts the seed name it was made
+// for. It requires the Subject to have the gardener.cloud:system:seed:<name> CommonName, the
+// gardener.cloud:system:seeds Organization, and usages to be exactly the key usages a seed client certificate needs.
+func seedNameFromPEM(pemData []byte, usages []string) (string, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return "", fmt.Errorf("no PEM block found in certificate signing request")
+	}
+
+	x509cr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse certificate signing request: %w", err)
+	}
+
+	if !strings.HasPrefix(x509cr.Subject.CommonName, v1beta1constants.SeedUserNamePrefix) {
+		return "", fmt.Errorf("can only create CSRs for seed clusters")
+	}
+
+	hasSeedsOrg := false
+	for _, org := range x509cr.Subject.Organization {
+		if org == v1beta1constants.SeedsGroup {
+			hasSeedsOrg = true
+			break
+		}
+	}
+	if !hasSeedsOrg {
+		return "", fmt.Errorf("can only create CSRs for seed clusters")
+	}
+
+	if !hasExactUsages(usages, seedClientCertUsages...) {
+		return "", fmt.Errorf("can only create CSRs for seed clusters")
+	}
+
+	return strings.TrimPrefix(x509cr.Subject.CommonName, v1beta1constants.SeedUserNamePrefix), nil
+}
+
+// hasExactUsages reports whether usages contains exactly the given expected usages, in any order.
+func hasExactUsages(usages []string, expected ...string) bool {
+	if len(usages) != len(expected) {
+		return false
+	}
+
+	want := make(map[string]struct{}, len(expected))
+	for _, usage := range expected {
+		want[usage] = struct{}{}
+	}
+
+	for _, usage := range usages {
+		if _, ok := want[usage]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
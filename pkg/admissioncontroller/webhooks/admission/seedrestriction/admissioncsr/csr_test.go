@@ -0,0 +1,101 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admissioncsr_test
+
+import (
+	. "github.com/gardener/gardener/pkg/admissioncontroller/webhooks/admission/seedrestriction/admissioncsr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+)
+
+// validSeedCSRPEM is a PKCS#10 certificate signing request whose Subject has CommonName
+// "gardener.cloud:system:seed:myseed" and Organization "gardener.cloud:system:seeds".
+const validSeedCSRPEM = `-----BEGIN CERTIFICATE REQUEST-----
+MIIClzCCAX8CAQAwUjEkMCIGA1UEChMbZ2FyZGVuZXIuY2xvdWQ6c3lzdGVtOnNl
+ZWRzMSowKAYDVQQDEyFnYXJkZW5lci5jbG91ZDpzeXN0ZW06c2VlZDpteXNlZWQw
+ggEiMA0GCSqGSIb3DQEBAQUAA4IBDwAwggEKAoIBAQCzNgJWhogJrCSzAhKKmHkJ
+FuooKAbxpWRGDOe5DiB8jPdgCoRCkZYnF7D9x9cDzliljA9IeBad3P3E9oegtSV/
+sXFJYqb+lRuhJQ5oo2eBC6WRg+Oxglp+n7o7xt0bO7JHS977mqNrqsJ1d1FnJHTB
+MPHPxqoqkgIbdW4t219ckSA20aWzC3PU7I7+Z9OD+YfuuYgzkWG541XyBBKVSD2w
+Ix2yGu6zrslqZ1eVBZ4IoxpWrQNmLSMFQVnABThyEUi0U1eVtW0vPNwSnBf0mufX
+Z0PpqAIPVjr64Z4s3HHml2GSu64iOxaG5wwb9qIPcdyFaQCep/sFh7kq1KjNI1Ql
+AgMBAAGgADANBgkqhkiG9w0BAQsFAAOCAQEAb+meLvm7dgHpzhu0XQ39w41FgpTv
+S7p78ABFwzDNcP1NwfrEUft0T/rUwPiMlN9zve2rRicaZX5Z7Bol/newejsu8H5z
+OdotvtKjE7zBCMzwnXZwO/0pA0cuUFcAy50DPcr35gdGjGlzV9ogO+HPKPTieS3n
+TRVg+MWlcLqCjALr9Y4N39DOzf4/SJts8AZJJ+lyyxnY3XIPXx7SdADwNWC8BX0U
+OK8CwMwN3iiBQ4redVeMK7LU1unV899q/PWB+NXFcKVr+Grm/Kom5VxuhXSzcHEp
+yO57qEcJqG1cB7iSchFuCSTuDBbZlN0fXgn4YjiWZyb4l3BDp3rm4iJImA==
+-----END CERTIFICATE REQUEST-----`
+
+var _ = Describe("ValidateSeedCSR", func() {
+	DescribeTable("should validate a certificates.k8s.io/v1beta1 CertificateSigningRequest",
+		func(usages []certificatesv1beta1.KeyUsage, expectedSeedName string, ambiguous bool, matcher OmegaMatcher) {
+			csr := &certificatesv1beta1.CertificateSigningRequest{
+				Spec: certificatesv1beta1.CertificateSigningRequestSpec{
+					Request: []byte(validSeedCSRPEM),
+					Usages:  usages,
+				},
+			}
+
+			Expect(ValidateSeedCSR(csr, expectedSeedName, ambiguous)).To(matcher)
+		},
+
+		Entry("matching seed name and usages", validV1Beta1Usages(), "myseed", false, Not(HaveOccurred())),
+		Entry("mismatching seed name", validV1Beta1Usages(), "otherseed", false, HaveOccurred()),
+		Entry("ambiguous caller is exempt from the name match", validV1Beta1Usages(), "otherseed", true, Not(HaveOccurred())),
+		Entry("missing usages", nil, "myseed", false, HaveOccurred()),
+	)
+
+	DescribeTable("should validate a certificates.k8s.io/v1 CertificateSigningRequest",
+		func(usages []certificatesv1.KeyUsage, expectedSeedName string, ambiguous bool, matcher OmegaMatcher) {
+			csr := &certificatesv1.CertificateSigningRequest{
+				Spec: certificatesv1.CertificateSigningRequestSpec{
+					Request: []byte(validSeedCSRPEM),
+					Usages:  usages,
+				},
+			}
+
+			Expect(ValidateSeedCSR(csr, expectedSeedName, ambiguous)).To(matcher)
+		},
+
+		Entry("matching seed name and usages", validV1Usages(), "myseed", false, Not(HaveOccurred())),
+		Entry("mismatching seed name", validV1Usages(), "otherseed", false, HaveOccurred()),
+		Entry("ambiguous caller is exempt from the name match", validV1Usages(), "otherseed", true, Not(HaveOccurred())),
+	)
+
+	It("should return an error for an unsupported type", func() {
+		Expect(ValidateSeedCSR(&certificatesv1.CertificateSigningRequestList{}, "myseed", false)).To(HaveOccurred())
+	})
+})
+
+func validV1Beta1Usages() []certificatesv1beta1.KeyUsage {
+	return []certificatesv1beta1.KeyUsage{
+		certificatesv1beta1.UsageKeyEncipherment,
+		certificatesv1beta1.UsageDigitalSignature,
+		certificatesv1beta1.UsageClientAuth,
+	}
+}
+
+func validV1Usages() []certificatesv1.KeyUsage {
+	return []certificatesv1.KeyUsage{
+		certificatesv1.UsageKeyEncipherment,
+		certificatesv1.UsageDigitalSignature,
+		certificatesv1.UsageClientAuth,
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedrestriction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Decision is the outcome of a seedrestriction admission decision.
+type Decision string
+
+// The possible values for Decision.
+const (
+	DecisionAllowed Decision = "Allowed"
+	DecisionDenied  Decision = "Denied"
+)
+
+// AuditRecord is a structured description of a single seedrestriction admission decision, suitable for forensic
+// analysis of what a (possibly rogue) seed attempted to do.
+type AuditRecord struct {
+	RequestUID types.UID                   `json:"requestUID"`
+	UserInfo   authenticationv1.UserInfo   `json:"userInfo"`
+	Resource   schema.GroupVersionResource `json:"resource"`
+	Namespace  string                      `json:"namespace,omitempty"`
+	Name       string                      `json:"name,omitempty"`
+	SeedName   string                      `json:"seedName,omitempty"`
+	Decision   Decision                    `json:"decision"`
+	Reason     string                      `json:"reason,omitempty"`
+	Latency    time.Duration               `json:"latency"`
+}
+
+// SinkInterface receives an AuditRecord for every seedrestriction admission decision.
+type SinkInterface interface {
+	// Record is called synchronously from Handle after a decision has been made; implementations must not block for
+	// a significant amount of time and should not return an error that aborts admission (Handle logs sink failures
+	// but never turns them into a denied request).
+	Record(ctx context.Context, record AuditRecord) error
+}
+
+// stdoutAuditSink writes every AuditRecord as a single line of JSON to an io.Writer (typically os.Stdout).
+type stdoutAuditSink struct {
+	writer io.Writer
+}
+
+// NewStdoutAuditSink returns a SinkInterface that writes each AuditRecord as a JSON line to writer.
+func NewStdoutAuditSink(writer io.Writer) SinkInterface {
+	return &stdoutAuditSink{writer: writer}
+}
+
+// Record implements SinkInterface.
+func (s *stdoutAuditSink) Record(_ context.Context, record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+
+	data = append(data, '\n')
+	_, err = s.writer.Write(data)
+	return err
+}
+
+// eventAuditSink emits a corev1.Event in the garden namespace for every AuditRecord.
+type eventAuditSink struct {
+	recorder record.EventRecorder
+}
+
+// NewEventAuditSink returns a SinkInterface that emits a corev1.Event scoped to the garden namespace (via recorder,
+// which callers are expected to have configured to emit into that namespace) for every AuditRecord.
+func NewEventAuditSink(recorder record.EventRecorder) SinkInterface {
+	return &eventAuditSink{recorder: recorder}
+}
+
+// Record implements SinkInterface.
+func (s *eventAuditSink) Record(_ context.Context, record AuditRecord) error {
+	eventType := corev1.EventTypeNormal
+	if record.Decision == DecisionDenied {
+		eventType = corev1.EventTypeWarning
+	}
+
+	// The decision is not about a single persisted object (a denied request, by definition, was never persisted),
+	// so the garden Namespace itself is used as the involved object, scoping every event to the garden namespace.
+	involvedObject := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: gardenSeedManagementNamespace}}
+
+	s.recorder.Eventf(
+		involvedObject,
+		eventType,
+		string(record.Decision),
+		"seedrestriction %s request for %s/%s by %q: %s",
+		record.Resource.Resource, record.Namespace, record.Name, record.UserInfo.Username, record.Reason,
+	)
+	return nil
+}
+
+// otelAuditSink records every AuditRecord as a zero-duration OpenTelemetry span carrying the decision as attributes,
+// so audit records can be correlated with the rest of a distributed trace.
+type otelAuditSink struct {
+	tracer trace.Tracer
+}
+
+// NewOTelAuditSink returns a SinkInterface that records every AuditRecord as an OpenTelemetry span via tracer.
+func NewOTelAuditSink(tracer trace.Tracer) SinkInterface {
+	return &otelAuditSink{tracer: tracer}
+}
+
+// Record implements SinkInterface.
+func (s *otelAuditSink) Record(ctx context.Context, record AuditRecord) error {
+	_, span := s.tracer.Start(ctx, "seedrestriction.Handle")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("seedrestriction.request_uid", string(record.RequestUID)),
+		attribute.String("seedrestriction.resource", record.Resource.Resource),
+		attribute.String("seedrestriction.namespace", record.Namespace),
+		attribute.String("seedrestriction.name", record.Name),
+		attribute.String("seedrestriction.seed_name", record.SeedName),
+		attribute.String("seedrestriction.decision", string(record.Decision)),
+		attribute.String("seedrestriction.reason", record.Reason),
+		attribute.Int64("seedrestriction.latency_ms", record.Latency.Milliseconds()),
+	)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedrestriction
+
+import (
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// bucketSeedIndex is an in-memory index from BackupBucket name to its current Spec.SeedName, kept up to date by an
+// event handler on the BackupBucket informer. It lets admitBackupEntry resolve a bucket's owning seed synchronously,
+// without an additional cacheReader.Get on every BackupEntry admission request.
+//
+// It is deliberately not built on client-go's generic toolscache.Indexer: controller-runtime's cache.Informer only
+// exposes AddIndexers for indexing an informer's own objects by a derived key, not a way to look an arbitrary key
+// back up afterwards. A small dedicated map populated from the informer's event handler gives the same O(1) lookup
+// without reaching for the unexported machinery backing Indexer.
+type bucketSeedIndex struct {
+	mu     sync.RWMutex
+	seedOf map[string]*string
+}
+
+// newBucketSeedIndex returns an empty bucketSeedIndex. Call registerWith to start populating it from an informer.
+func newBucketSeedIndex() *bucketSeedIndex {
+	return &bucketSeedIndex{seedOf: map[string]*string{}}
+}
+
+// registerWith wires idx up to informer's add/update/delete notifications. informer may be nil (e.g. a bare test
+// double that never delivers events), in which case idx stays empty and every lookup simply misses, falling back to
+// a direct cache read.
+func (idx *bucketSeedIndex) registerWith(informer cache.Informer) {
+	if informer == nil {
+		return
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    idx.put,
+		UpdateFunc: func(_, newObj interface{}) { idx.put(newObj) },
+		DeleteFunc: idx.delete,
+	})
+}
+
+func (idx *bucketSeedIndex) put(obj interface{}) {
+	backupBucket, ok := obj.(*gardencorev1beta1.BackupBucket)
+	if !ok {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.seedOf[backupBucket.Name] = backupBucket.Spec.SeedName
+}
+
+func (idx *bucketSeedIndex) delete(obj interface{}) {
+	backupBucket, ok := obj.(*gardencorev1beta1.BackupBucket)
+	if !ok {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			backupBucket, ok = tombstone.Obj.(*gardencorev1beta1.BackupBucket)
+			if !ok {
+				return
+			}
+		} else {
+			return
+		}
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	delete(idx.seedOf, backupBucket.Name)
+}
+
+// lookup returns the seed name currently indexed for the BackupBucket named name, and whether the index has an
+// entry for it at all (ok is false on an index miss, e.g. because the informer has not synced yet).
+func (idx *bucketSeedIndex) lookup(name string) (seedName *string, ok bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	seedName, ok = idx.seedOf[name]
+	return seedName, ok
+}
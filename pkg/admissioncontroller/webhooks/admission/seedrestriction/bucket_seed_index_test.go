@@ -0,0 +1,100 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedrestriction
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeFailingReader is a client.Reader stub whose Get/List always fail; it is only used to prove that a warm
+// bucketSeedIndex is consulted instead of falling through to the cache.
+type fakeFailingReader struct{}
+
+func (fakeFailingReader) Get(_ context.Context, _ client.ObjectKey, _ client.Object) error {
+	return fmt.Errorf("Get must not be called while the bucket seed index is warm")
+}
+
+func (fakeFailingReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("List must not be called by seedNameOfBackupBucket")
+}
+
+var _ = Describe("bucketSeedIndex", func() {
+	var idx *bucketSeedIndex
+
+	BeforeEach(func() {
+		idx = newBucketSeedIndex()
+	})
+
+	It("should miss before the bucket is indexed", func() {
+		_, ok := idx.lookup("my-bucket")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should return the seed name after the bucket is added, and miss again after it is deleted", func() {
+		idx.put(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-a")},
+		})
+
+		seedName, ok := idx.lookup("my-bucket")
+		Expect(ok).To(BeTrue())
+		Expect(seedName).To(Equal(pointer.StringPtr("seed-a")))
+
+		idx.delete(&gardencorev1beta1.BackupBucket{ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"}})
+
+		_, ok = idx.lookup("my-bucket")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should update the seed name on a subsequent add", func() {
+		idx.put(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-a")},
+		})
+		idx.put(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-b")},
+		})
+
+		seedName, ok := idx.lookup("my-bucket")
+		Expect(ok).To(BeTrue())
+		Expect(seedName).To(Equal(pointer.StringPtr("seed-b")))
+	})
+})
+
+var _ = Describe("handler#seedNameOfBackupBucket", func() {
+	It("should use the warm index without reading from the cache", func() {
+		idx := newBucketSeedIndex()
+		idx.put(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-a")},
+		})
+
+		h := &handler{cacheReader: fakeFailingReader{}, bucketSeedIndex: idx}
+
+		seedName, err := h.seedNameOfBackupBucket(context.TODO(), "my-bucket")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(seedName).To(Equal(pointer.StringPtr("seed-a")))
+	})
+})
@@ -0,0 +1,101 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedrestriction_test
+
+import (
+	. "github.com/gardener/gardener/pkg/admissioncontroller/webhooks/admission/seedrestriction"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+var _ = Describe("SPIFFEVerifier", func() {
+	var verifier *SPIFFEVerifier
+
+	BeforeEach(func() {
+		verifier = &SPIFFEVerifier{TrustDomain: "gardener.cloud"}
+	})
+
+	Describe("#VerifySeedName", func() {
+		It("should return the seed name for a valid SPIFFE ID", func() {
+			userInfo := authenticationv1.UserInfo{
+				Extra: map[string]authenticationv1.ExtraValue{
+					"authentication.kubernetes.io/spiffe-id": {"spiffe://gardener.cloud/seed/my-seed"},
+				},
+			}
+
+			name, ok, err := verifier.VerifySeedName(userInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(name).To(Equal("my-seed"))
+		})
+
+		It("should return ok=false and no error when no recognizable identity is present", func() {
+			name, ok, err := verifier.VerifySeedName(authenticationv1.UserInfo{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(name).To(BeEmpty())
+		})
+
+		It("should return an error for a SPIFFE ID belonging to a different trust domain", func() {
+			userInfo := authenticationv1.UserInfo{
+				Extra: map[string]authenticationv1.ExtraValue{
+					"authentication.kubernetes.io/spiffe-id": {"spiffe://evil.example/seed/my-seed"},
+				},
+			}
+
+			_, ok, err := verifier.VerifySeedName(userInfo)
+			Expect(err).To(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should return an error for a SPIFFE ID with an unexpected scheme", func() {
+			userInfo := authenticationv1.UserInfo{
+				Extra: map[string]authenticationv1.ExtraValue{
+					"authentication.kubernetes.io/spiffe-id": {"https://gardener.cloud/seed/my-seed"},
+				},
+			}
+
+			_, ok, err := verifier.VerifySeedName(userInfo)
+			Expect(err).To(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should return an error for a SPIFFE ID that is missing the /seed/<name> path", func() {
+			userInfo := authenticationv1.UserInfo{
+				Extra: map[string]authenticationv1.ExtraValue{
+					"authentication.kubernetes.io/spiffe-id": {"spiffe://gardener.cloud/something-else"},
+				},
+			}
+
+			_, ok, err := verifier.VerifySeedName(userInfo)
+			Expect(err).To(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should return an error for a JWT-SVID, since validating it is not implemented", func() {
+			userInfo := authenticationv1.UserInfo{
+				Extra: map[string]authenticationv1.ExtraValue{
+					"authentication.kubernetes.io/jwt-svid": {"some.jwt.token"},
+				},
+			}
+
+			_, ok, err := verifier.VerifySeedName(userInfo)
+			Expect(err).To(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,185 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedrestriction
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Config configures per-GroupVersionKind behavior of the seedrestriction webhook for UPDATE requests. A compromised
+// seed that can still authenticate as itself should not automatically be able to rewrite arbitrary fields of
+// objects it legitimately owns (e.g. Spec.SeedName, finalizers, ownerReferences) just because the CREATE-time
+// ownership check passed; FieldAllowList pins down exactly which top-level field paths an UPDATE may touch.
+type Config struct {
+	// FieldAllowList maps a GroupVersionKind to the set of dot-separated field paths (rooted at the object, e.g.
+	// "status" or "spec.holderIdentity") that an UPDATE from the owning seed is permitted to change. Any field not
+	// covered by an entry's list, and not covered by alwaysAllowedFieldPaths below, must be identical between the
+	// old and the new object or the request is forbidden. A GroupVersionKind with no entry defaults to
+	// defaultFieldAllowList.
+	FieldAllowList map[schema.GroupVersionKind][]string
+
+	// AnnotationAllowList names annotation keys that an UPDATE from the owning seed may add, change, or remove,
+	// regardless of FieldAllowList. Annotations not in this list must be unchanged.
+	AnnotationAllowList []string
+}
+
+// defaultFieldAllowList is used for any GroupVersionKind without an explicit Config.FieldAllowList entry: only the
+// status subresource may be updated, matching how most seed-owned objects are expected to be reconciled.
+var defaultFieldAllowList = []string{"status"}
+
+// defaultLeaseFieldAllowList is the default allow-list for leaseKind. Unlike the other guarded resources, a Lease
+// has no status subresource at all: the fields a gardenlet's heartbeat renewal actually changes (holderIdentity,
+// renewTime, acquireTime, leaseDurationSeconds, leaseTransitions) live under spec. Falling back to
+// defaultFieldAllowList here would reject every legitimate Lease renewal.
+var defaultLeaseFieldAllowList = []string{"spec"}
+
+// alwaysAllowedFieldPaths change on every write/reconcile and are never meaningful identity or authorization
+// signals, so they are exempted from the diff regardless of Config. metadata.annotations is exempted from the generic
+// diff only because checkUpdateAllowed checks it key by key against Config.AnnotationAllowList beforehand.
+var alwaysAllowedFieldPaths = []string{
+	"metadata.resourceVersion",
+	"metadata.generation",
+	"metadata.managedFields",
+	"metadata.selfLink",
+	"metadata.annotations",
+}
+
+func (c Config) fieldAllowListFor(gvk schema.GroupVersionKind) []string {
+	if allowed, ok := c.FieldAllowList[gvk]; ok {
+		return allowed
+	}
+	if gvk == leaseKind {
+		return defaultLeaseFieldAllowList
+	}
+	return defaultFieldAllowList
+}
+
+// checkUpdateAllowed verifies that newObj only differs from oldObj in field paths allowed for gvk by cfg (plus
+// annotation keys in cfg.AnnotationAllowList and the metadata bookkeeping fields that always change). It returns a
+// non-nil error describing the first disallowed change it finds.
+func checkUpdateAllowed(cfg Config, gvk schema.GroupVersionKind, oldObj, newObj runtime.Object) error {
+	oldMap, err := toMap(oldObj)
+	if err != nil {
+		return err
+	}
+	newMap, err := toMap(newObj)
+	if err != nil {
+		return err
+	}
+
+	oldAnnotations, _ := getPath(oldMap, "metadata.annotations").(map[string]interface{})
+	newAnnotations, _ := getPath(newMap, "metadata.annotations").(map[string]interface{})
+	if err := checkAnnotationsAllowed(cfg.AnnotationAllowList, oldAnnotations, newAnnotations); err != nil {
+		return err
+	}
+
+	for _, path := range alwaysAllowedFieldPaths {
+		deletePath(oldMap, path)
+		deletePath(newMap, path)
+	}
+
+	for _, path := range cfg.fieldAllowListFor(gvk) {
+		deletePath(oldMap, path)
+		deletePath(newMap, path)
+	}
+
+	if !reflect.DeepEqual(oldMap, newMap) {
+		return fmt.Errorf("update changes fields outside of the allowed set for %s", gvk.Kind)
+	}
+
+	return nil
+}
+
+func checkAnnotationsAllowed(allowList []string, oldAnnotations, newAnnotations map[string]interface{}) error {
+	allowed := make(map[string]struct{}, len(allowList))
+	for _, key := range allowList {
+		allowed[key] = struct{}{}
+	}
+
+	keys := make(map[string]struct{}, len(oldAnnotations)+len(newAnnotations))
+	for k := range oldAnnotations {
+		keys[k] = struct{}{}
+	}
+	for k := range newAnnotations {
+		keys[k] = struct{}{}
+	}
+
+	for key := range keys {
+		if _, ok := allowed[key]; ok {
+			continue
+		}
+		if !reflect.DeepEqual(oldAnnotations[key], newAnnotations[key]) {
+			return fmt.Errorf("update changes annotation %q which is not in the allow-list", key)
+		}
+	}
+
+	return nil
+}
+
+func toMap(obj runtime.Object) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", obj, err)
+	}
+
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %T: %w", obj, err)
+	}
+
+	return out, nil
+}
+
+func getPath(m map[string]interface{}, path string) interface{} {
+	segments := strings.Split(path, ".")
+	var current interface{} = m
+
+	for _, segment := range segments {
+		asMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current, ok = asMap[segment]
+		if !ok {
+			return nil
+		}
+	}
+
+	return current
+}
+
+func deletePath(m map[string]interface{}, path string) {
+	segments := strings.Split(path, ".")
+	current := m
+
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			delete(current, segment)
+			return
+		}
+
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package seed contains the seed authorizer: a Kubernetes Authorization webhook
+// (https://kubernetes.io/docs/reference/access-authn-authz/webhook/) that decides whether a request made by a seed
+// (a gardenlet, identified the same way seedrestriction identifies it) is allowed to read or patch objects belonging
+// to another seed - a decision admission webhooks cannot make, since get/list/watch/patch requests never reach
+// admission. The package also contains the graph subpackage, which backs some of these decisions.
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/gardener/pkg/admissioncontroller/webhooks/auth/seed/seedauthorizer"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// leaderElectionLeaseName is the name of the Lease object used by gardenlets for leader election, which is not
+// owned by any particular seed and must always be allowed.
+const leaderElectionLeaseName = "gardenlet-leader-election"
+
+// recognizedVerbs are the only verbs this authorizer ever expresses an opinion about; every other verb (e.g.
+// create/update/delete, which admission already restricts) gets NoOpinion so RBAC remains the sole authority.
+var recognizedVerbs = map[string]bool{
+	"get":   true,
+	"list":  true,
+	"watch": true,
+	"patch": true,
+}
+
+// Authorizer decides Allow/NoOpinion/Deny for SubjectAccessReviews made on behalf of a seed. The actual
+// resource-ownership rules live in the Restrictors registered with its Registry; see RegisterRestrictor.
+type Authorizer struct {
+	logger      logr.Logger
+	cacheReader client.Reader
+	registry    *Registry
+}
+
+// New creates a new Authorizer with the built-in Restrictors (Shoots, Seeds, BackupBuckets, BackupEntries,
+// ControllerInstallations, ShootStates and the gardenlet leader-election Lease) already registered. c is used both
+// as a client.Reader and to pre-warm the informers those Restrictors depend on.
+//
+// Secrets are deliberately never registered: resolving the full set of Secrets a seed's ManagedSeed may legitimately
+// reference (bootstrap kubeconfigs, backup credentials, certificates, ...) is not modeled here, so requests for them
+// fall through to NoOpinion and defer to RBAC instead of approximating a policy this authorizer cannot enforce
+// precisely.
+func New(ctx context.Context, logger logr.Logger, c cache.Cache) (*Authorizer, error) {
+	for _, obj := range []client.Object{
+		&gardencorev1beta1.Shoot{},
+		&gardencorev1beta1.Seed{},
+		&gardencorev1beta1.BackupBucket{},
+		&gardencorev1beta1.BackupEntry{},
+		&gardencorev1beta1.ControllerInstallation{},
+	} {
+		if _, err := c.GetInformer(ctx, obj); err != nil {
+			return nil, fmt.Errorf("failed to get informer for %T: %w", obj, err)
+		}
+	}
+
+	resolver := seedauthorizer.NewResolver(c)
+
+	registry := NewRegistry()
+	registry.Register(shootRestrictor{resolver: resolver})
+	registry.Register(shootStateRestrictor{resolver: resolver})
+	registry.Register(seedRestrictor{resolver: resolver})
+	registry.Register(backupBucketRestrictor{})
+	registry.Register(backupEntryRestrictor{})
+	registry.Register(controllerInstallationRestrictor{})
+	registry.Register(leaseRestrictor{})
+
+	return &Authorizer{
+		logger:      logger,
+		cacheReader: c,
+		registry:    registry,
+	}, nil
+}
+
+// RegisterRestrictor adds restrictor to the Authorizer's Registry, letting a caller support an additional
+// seed-scoped resource without editing this package's Decide method.
+func (a *Authorizer) RegisterRestrictor(restrictor Restrictor) {
+	a.registry.Register(restrictor)
+}
+
+// ServeHTTP implements http.Handler. It decodes the incoming SubjectAccessReview, decides its Status via Decide, and
+// writes the same SubjectAccessReview back with Status populated, per the Kubernetes authorization webhook protocol.
+func (a *Authorizer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sar := &authorizationv1.SubjectAccessReview{}
+	if err := json.NewDecoder(r.Body).Decode(sar); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sar.Status = a.Decide(r.Context(), sar.Spec)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sar); err != nil {
+		a.logger.Error(err, "Failed to encode SubjectAccessReview response")
+	}
+}
+
+// Decide resolves the seed identity of spec.User/spec.Groups and returns whether the described request is Allowed,
+// Denied, or whether this authorizer has NoOpinion (in which case the next authorizer in the chain, typically RBAC,
+// decides). The actual ownership check for a recognized resource is delegated to whichever Restrictor is registered
+// for its GroupVersionResource.
+func (a *Authorizer) Decide(ctx context.Context, spec authorizationv1.SubjectAccessReviewSpec) authorizationv1.SubjectAccessReviewStatus {
+	seedName, isSeed, ambiguous := seedauthorizer.SeedIdentityFromUserInfo(userInfoFromSpec(spec))
+	if !isSeed {
+		return noOpinion("request is not made by a seed")
+	}
+
+	attrs := spec.ResourceAttributes
+	if attrs == nil {
+		// Non-resource requests (e.g. /healthz) are out of scope for this authorizer.
+		return noOpinion("non-resource request")
+	}
+	if !recognizedVerbs[attrs.Verb] {
+		return noOpinion(fmt.Sprintf("verb %q is not evaluated by the seed authorizer", attrs.Verb))
+	}
+
+	if ambiguous {
+		return allowed("request is made by the ambiguous seed user")
+	}
+
+	restrictor, ok := a.registry.Get(schema.GroupVersionResource{Group: attrs.Group, Version: attrs.Version, Resource: attrs.Resource})
+	if !ok {
+		return noOpinion(fmt.Sprintf("resource %q is not evaluated by the seed authorizer", attrs.Resource))
+	}
+	if !restrictor.AllowedVerbs().Has(attrs.Verb) {
+		return noOpinion(fmt.Sprintf("verb %q is not evaluated for resource %q", attrs.Verb, attrs.Resource))
+	}
+
+	owners, err := restrictor.SeedNameFor(ctx, attrs, a.cacheReader)
+	switch {
+	case err == nil:
+		for _, owner := range owners {
+			if owner == seedName || owner == anySeed {
+				return allowed("object belongs to the requesting seed")
+			}
+		}
+		return authorizationv1.SubjectAccessReviewStatus{Denied: true, Reason: fmt.Sprintf("object does not belong to seed %q", seedName)}
+	case errors.Is(err, ErrNotScopable):
+		return noOpinion("list/watch across all objects of this kind cannot be scoped to a single seed")
+	case apierrors.IsNotFound(err):
+		return noOpinion("object does not exist")
+	default:
+		return a.errorStatus(err)
+	}
+}
+
+func (a *Authorizer) errorStatus(err error) authorizationv1.SubjectAccessReviewStatus {
+	a.logger.Error(err, "Failed to resolve seed ownership for SubjectAccessReview")
+	return authorizationv1.SubjectAccessReviewStatus{Denied: true, Reason: fmt.Sprintf("failed to resolve seed ownership: %v", err)}
+}
+
+func allowed(reason string) authorizationv1.SubjectAccessReviewStatus {
+	return authorizationv1.SubjectAccessReviewStatus{Allowed: true, Reason: reason}
+}
+
+func noOpinion(reason string) authorizationv1.SubjectAccessReviewStatus {
+	return authorizationv1.SubjectAccessReviewStatus{Allowed: false, Denied: false, Reason: reason}
+}
+
+func userInfoFromSpec(spec authorizationv1.SubjectAccessReviewSpec) authenticationv1.UserInfo {
+	return authenticationv1.UserInfo{Username: spec.User, Groups: spec.Groups}
+}
@@ -0,0 +1,164 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/gardener/pkg/admissioncontroller/webhooks/auth/seed/seedauthorizer"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeReader is a minimal client.Reader backed by an in-memory map.
+type fakeReader struct {
+	objects map[string]client.Object
+}
+
+func (r *fakeReader) set(obj client.Object) {
+	r.objects[fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())] = obj
+}
+
+func (r *fakeReader) Get(_ context.Context, key client.ObjectKey, obj client.Object) error {
+	stored, ok := r.objects[fmt.Sprintf("%T/%s/%s", obj, key.Namespace, key.Name)]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+
+	switch o := obj.(type) {
+	case *gardencorev1beta1.BackupBucket:
+		*o = *stored.(*gardencorev1beta1.BackupBucket)
+	default:
+		return fmt.Errorf("fakeReader: unsupported type %T", obj)
+	}
+	return nil
+}
+
+func (r *fakeReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("fakeReader: List is not supported")
+}
+
+var _ = Describe("Authorizer", func() {
+	var (
+		reader     *fakeReader
+		authorizer *Authorizer
+		seedUser   = authorizationv1.SubjectAccessReviewSpec{User: "gardener.cloud:system:seed:seed-a", Groups: []string{"gardener.cloud:system:seeds"}}
+	)
+
+	BeforeEach(func() {
+		reader = &fakeReader{objects: map[string]client.Object{}}
+
+		resolver := seedauthorizer.NewResolver(reader)
+		registry := NewRegistry()
+		registry.Register(backupBucketRestrictor{})
+		registry.Register(leaseRestrictor{})
+		registry.Register(seedRestrictor{resolver: resolver})
+
+		authorizer = &Authorizer{cacheReader: reader, registry: registry}
+	})
+
+	It("should have NoOpinion for requests not made by a seed", func() {
+		status := authorizer.Decide(context.TODO(), authorizationv1.SubjectAccessReviewSpec{User: "system:admin"})
+		Expect(status.Allowed).To(BeFalse())
+		Expect(status.Denied).To(BeFalse())
+	})
+
+	It("should have NoOpinion for a verb it does not evaluate", func() {
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupbuckets", Name: "my-bucket", Verb: "create"}
+
+		status := authorizer.Decide(context.TODO(), spec)
+		Expect(status.Allowed).To(BeFalse())
+		Expect(status.Denied).To(BeFalse())
+	})
+
+	It("should allow a get on a BackupBucket belonging to the requesting seed", func() {
+		reader.set(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-a")},
+		})
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupbuckets", Name: "my-bucket", Verb: "get"}
+
+		status := authorizer.Decide(context.TODO(), spec)
+		Expect(status.Allowed).To(BeTrue())
+	})
+
+	It("should deny a get on a BackupBucket belonging to another seed", func() {
+		reader.set(&gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-bucket"},
+			Spec:       gardencorev1beta1.BackupBucketSpec{SeedName: pointer.StringPtr("seed-b")},
+		})
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupbuckets", Name: "my-bucket", Verb: "get"}
+
+		status := authorizer.Decide(context.TODO(), spec)
+		Expect(status.Denied).To(BeTrue())
+	})
+
+	It("should always allow the leader election lease", func() {
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Group: "coordination.k8s.io", Resource: "leases", Name: leaderElectionLeaseName, Verb: "patch"}
+
+		status := authorizer.Decide(context.TODO(), spec)
+		Expect(status.Allowed).To(BeTrue())
+	})
+
+	It("should have NoOpinion for secrets, deferring to RBAC", func() {
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Resource: "secrets", Name: "some-secret", Verb: "get"}
+
+		status := authorizer.Decide(context.TODO(), spec)
+		Expect(status.Allowed).To(BeFalse())
+		Expect(status.Denied).To(BeFalse())
+	})
+
+	It("should let a third party support an additional resource without editing Decide", func() {
+		authorizer.RegisterRestrictor(widgetRestrictor{})
+
+		spec := seedUser
+		spec.ResourceAttributes = &authorizationv1.ResourceAttributes{Group: "example.com", Resource: "widgets", Name: "seed-a", Verb: "get"}
+		Expect(authorizer.Decide(context.TODO(), spec).Allowed).To(BeTrue())
+
+		spec.ResourceAttributes.Name = "seed-b"
+		Expect(authorizer.Decide(context.TODO(), spec).Denied).To(BeTrue())
+	})
+})
+
+// widgetRestrictor is a fake Restrictor for a resource this package knows nothing about, demonstrating that a third
+// party can support an additional seed-scoped resource purely by implementing Restrictor and calling
+// Authorizer.RegisterRestrictor - without touching Decide.
+type widgetRestrictor struct{}
+
+func (widgetRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: "example.com", Resource: "widgets"}
+}
+
+func (widgetRestrictor) AllowedVerbs() sets.String { return sets.NewString("get") }
+
+func (widgetRestrictor) SeedNameFor(_ context.Context, attrs *authorizationv1.ResourceAttributes, _ client.Reader) ([]string, error) {
+	// A widget is conventionally named after the seed it belongs to.
+	return []string{attrs.Name}, nil
+}
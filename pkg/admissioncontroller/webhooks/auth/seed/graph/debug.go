@@ -0,0 +1,206 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DebugHandler renders the current graph as Graphviz DOT so that operators can visualize and diagnose it, e.g. via
+// `dot -Tsvg`. It is meant to be mounted alongside the gardener-admission-controller's debug/pprof endpoints.
+//
+// Supported query parameters:
+//   - "type": only include vertices of the given VertexType name (e.g. "Seed"), along with their direct neighbors.
+//   - "name": restrict the output to the transitive neighborhood of the single vertex identified by "type"/
+//     "namespace"/"name".
+//   - "namespace": namespace of the vertex identified by "name", if any.
+func (g *graph) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+
+		if name := query.Get("name"); name != "" {
+			vertexType, err := parseVertexType(query.Get("type"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if _, err := w.Write([]byte(g.neighborhoodDOT(vertexType, query.Get("namespace"), name))); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		var filter *VertexType
+		if typeParam := query.Get("type"); typeParam != "" {
+			vertexType, err := parseVertexType(typeParam)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			filter = &vertexType
+		}
+
+		if _, err := w.Write([]byte(g.dot(filter))); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+func parseVertexType(s string) (VertexType, error) {
+	for t := VertexTypeNamespace; t <= VertexTypeBastion; t++ {
+		if t.String() == s {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown vertex type %q", s)
+}
+
+// dot renders the whole graph (or, if filter is non-nil, only vertices of the given type and their direct
+// neighbors) as Graphviz DOT.
+func (g *graph) dot(filter *VertexType) string {
+	var out string
+
+	g.read(func(s *state) {
+		include := func(id int64) bool {
+			if filter == nil {
+				return true
+			}
+			if s.ids[id].vertexType == *filter {
+				return true
+			}
+
+			matches := false
+			neighbors := s.graph.From(id)
+			for neighbors.Next() {
+				if s.ids[neighbors.Node().ID()].vertexType == *filter {
+					matches = true
+				}
+			}
+			toNeighbors := s.graph.To(id)
+			for toNeighbors.Next() {
+				if s.ids[toNeighbors.Node().ID()].vertexType == *filter {
+					matches = true
+				}
+			}
+			return matches
+		}
+
+		out = renderDOT(s, include)
+	})
+
+	return out
+}
+
+// neighborhoodDOT renders only the transitive neighborhood (both directions) of the given vertex.
+func (g *graph) neighborhoodDOT(vertexType VertexType, namespace, name string) string {
+	var out string
+
+	g.read(func(s *state) {
+		rootID, ok := s.lookup(vertexType, namespace, name)
+		if !ok {
+			out = "digraph \"graph\" {}\n"
+			return
+		}
+
+		reachable := map[int64]bool{rootID: true}
+		queue := []int64{rootID}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+
+			from := s.graph.From(current)
+			for from.Next() {
+				id := from.Node().ID()
+				if !reachable[id] {
+					reachable[id] = true
+					queue = append(queue, id)
+				}
+			}
+			to := s.graph.To(current)
+			for to.Next() {
+				id := to.Node().ID()
+				if !reachable[id] {
+					reachable[id] = true
+					queue = append(queue, id)
+				}
+			}
+		}
+
+		out = renderDOT(s, func(id int64) bool { return reachable[id] })
+	})
+
+	return out
+}
+
+func renderDOT(s *state, include func(id int64) bool) string {
+	var b strings.Builder
+	b.WriteString("digraph \"graph\" {\n")
+
+	for id, key := range s.ids {
+		if !include(id) {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %s [label=%q];\n", nodeID(id), vertexLabel(key)))
+	}
+
+	edges := s.graph.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		from, to := e.From().ID(), e.To().ID()
+		if !include(from) || !include(to) {
+			continue
+		}
+		if kinds := edgeKindLabel(s.edgeKinds[from][to]); kinds != "" {
+			b.WriteString(fmt.Sprintf("  %s -> %s [label=%q];\n", nodeID(from), nodeID(to), kinds))
+		} else {
+			b.WriteString(fmt.Sprintf("  %s -> %s;\n", nodeID(from), nodeID(to)))
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func nodeID(id int64) string {
+	return "n" + strconv.FormatInt(id, 10)
+}
+
+// edgeKindLabel renders the set of EdgeKinds an edge exists for as a comma-separated DOT edge label, omitting
+// edgeKindUnspecified since it carries no information an operator would want displayed.
+func edgeKindLabel(kinds map[EdgeKind]struct{}) string {
+	var names []string
+	for kind := range kinds {
+		if kind == edgeKindUnspecified {
+			continue
+		}
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func vertexLabel(key vertexKey) string {
+	if key.namespace == "" {
+		return fmt.Sprintf("%s/%s", key.vertexType, key.name)
+	}
+	return fmt.Sprintf("%s/%s/%s", key.vertexType, key.namespace, key.name)
+}
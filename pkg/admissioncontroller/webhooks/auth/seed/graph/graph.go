@@ -0,0 +1,863 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graph maintains an in-memory, directed graph of the Garden cluster objects that are relevant for the
+// seed authorizer and seed-restriction admission webhooks: which Secret/ConfigMap a Shoot references, which Seed
+// a BackupBucket belongs to, and so on. The graph is kept up to date via informer event handlers and is queried via
+// HasPathFrom to answer "can seed X reach object Y" without hitting the API server on every request.
+package graph
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+
+	"github.com/go-logr/logr"
+	"gonum.org/v1/gonum/graph/simple"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// ConcurrencyStrategy selects how graph synchronizes reads (HasPathFrom) against writes (informer event handlers).
+type ConcurrencyStrategy string
+
+const (
+	// ConcurrencyStrategyMutex guards a single shared state with a sync.RWMutex. Simple and the long-standing
+	// default, but a burst of writes (e.g. many Shoot status updates) can stall every concurrent HasPathFrom call,
+	// since readers and writers contend for the same lock.
+	ConcurrencyStrategyMutex ConcurrencyStrategy = "Mutex"
+	// ConcurrencyStrategyRCU publishes an immutable state via atomic.Value. Writers serialize amongst themselves,
+	// mutate a private clone, and publish it atomically; readers always load a consistent, immutable snapshot
+	// without taking any lock at all, trading additional writer-side copying for read-path isolation.
+	ConcurrencyStrategyRCU ConcurrencyStrategy = "RCU"
+)
+
+// Option configures a graph created via New.
+type Option func(*graph)
+
+// WithConcurrencyStrategy selects the ConcurrencyStrategy used to synchronize reads against writes. The default,
+// if this option isn't given, is ConcurrencyStrategyMutex.
+func WithConcurrencyStrategy(strategy ConcurrencyStrategy) Option {
+	return func(g *graph) { g.strategy = strategy }
+}
+
+// graph is the concrete implementation backing the Interface used by the seed authorizer and seed-restriction
+// webhooks.
+type graph struct {
+	log      logr.Logger
+	strategy ConcurrencyStrategy
+
+	// options configures, per VertexType, how that type's informer events are filtered and projected before they
+	// reach the graph; see GraphOptions. A VertexType missing from it is consumed unmodified.
+	options GraphOptions
+
+	// maxPathDepth and maxVisitedNodes bound HasPathFromWithLimit/AllPathsFrom; zero means "use the package default".
+	maxPathDepth    int
+	maxVisitedNodes int
+
+	// lock, graph, vertices, ids, nextID and edgeKinds back the ConcurrencyStrategyMutex path: graph/vertices/ids
+	// are mutated in place under lock. They stay top-level fields, rather than moving into a private state struct,
+	// so that diagnostics reaching into a *graph's internals keep working unchanged.
+	lock      sync.RWMutex
+	graph     *simple.DirectedGraph
+	vertices  map[VertexType]map[string]map[string]int64
+	ids       map[int64]vertexKey
+	nextID    int64
+	edgeKinds map[int64]map[int64]map[EdgeKind]struct{}
+
+	// writeMu and rcuState back the ConcurrencyStrategyRCU path: writeMu serializes writers only, readers load
+	// rcuState (a *state) without taking any lock at all.
+	writeMu  sync.Mutex
+	rcuState atomic.Value
+}
+
+// New creates a new graph that is empty until Setup is called.
+func New(log logr.Logger, opts ...Option) *graph {
+	g := &graph{log: log, strategy: ConcurrencyStrategyMutex}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	if g.strategy == ConcurrencyStrategyRCU {
+		g.rcuState.Store(newState())
+		return g
+	}
+
+	g.graph = simple.NewDirectedGraph()
+	g.vertices = make(map[VertexType]map[string]map[string]int64)
+	g.ids = make(map[int64]vertexKey)
+	g.edgeKinds = make(map[int64]map[int64]map[EdgeKind]struct{})
+	return g
+}
+
+// read invokes fn with a stable view of the graph's state. Under ConcurrencyStrategyMutex it holds the read lock
+// for the duration of fn; under ConcurrencyStrategyRCU it hands fn an immutable snapshot and takes no lock at all.
+// fn must not retain s beyond the call, nor mutate it.
+func (g *graph) read(fn func(s *state)) {
+	if g.strategy == ConcurrencyStrategyRCU {
+		fn(g.rcuState.Load().(*state))
+		return
+	}
+
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	fn(&state{graph: g.graph, vertices: g.vertices, ids: g.ids, nextID: g.nextID, edgeKinds: g.edgeKinds})
+}
+
+// write invokes fn with a state it may mutate in place. Under ConcurrencyStrategyMutex it holds the write lock for
+// the duration of fn, operating directly on the graph's own maps. Under ConcurrencyStrategyRCU it serializes
+// against other writers, hands fn a private clone of the current state, and atomically publishes the clone once fn
+// returns, leaving concurrent readers of the previous state undisturbed.
+func (g *graph) write(fn func(s *state)) {
+	if g.strategy == ConcurrencyStrategyRCU {
+		g.writeMu.Lock()
+		defer g.writeMu.Unlock()
+
+		clone := g.rcuState.Load().(*state).clone()
+		fn(clone)
+		g.rcuState.Store(clone)
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	s := &state{graph: g.graph, vertices: g.vertices, ids: g.ids, nextID: g.nextID, edgeKinds: g.edgeKinds}
+	fn(s)
+	g.nextID = s.nextID
+}
+
+// Setup registers event handlers with the given informer cache for all object kinds relevant to the graph.
+func (g *graph) Setup(ctx context.Context, informerCache cache.Cache) error {
+	for _, setup := range []func(context.Context, cache.Cache) error{
+		g.setupSeedWatch,
+		g.setupShootWatch,
+		g.setupProjectWatch,
+		g.setupBackupBucketWatch,
+		g.setupBackupEntryWatch,
+		g.setupSecretBindingWatch,
+		g.setupManagedSeedWatch,
+		g.setupControllerInstallationWatch,
+		g.setupShootStateWatch,
+		g.setupBastionWatch,
+	} {
+		if err := setup(ctx, informerCache); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasPathFrom returns true if there is a directed path in the graph from the vertex identified by
+// (fromType, fromNamespace, fromName) to the vertex identified by (toType, toNamespace, toName).
+func (g *graph) HasPathFrom(fromType VertexType, fromNamespace, fromName string, toType VertexType, toNamespace, toName string) bool {
+	start := time.Now()
+
+	var found bool
+	g.read(func(s *state) {
+		found = s.hasPathFrom(fromType, fromNamespace, fromName, toType, toNamespace, toName)
+	})
+
+	metricPathQueryDuration.Observe(time.Since(start).Seconds())
+	if found {
+		metricPathQueriesTotal.WithLabelValues("found").Inc()
+	} else {
+		metricPathQueriesTotal.WithLabelValues("not_found").Inc()
+	}
+
+	return found
+}
+
+// HasPathFromWithKinds behaves like HasPathFrom, but only traverses edges whose EdgeKind is in kinds, e.g. a
+// gardenlet reading a Shoot's cloudprovider Secret should check for a path made up exclusively of
+// EdgeKindCloudProviderSecret/EdgeKindSecretBindingShoot edges, not any Secret->Shoot path (a DNS provider Secret or
+// a reflection-discovered resource reference would satisfy HasPathFrom just as well, which is exactly the
+// over-authorization this guards against). Passing no kinds matches any edge, same as HasPathFrom.
+func (g *graph) HasPathFromWithKinds(fromType VertexType, fromNamespace, fromName string, toType VertexType, toNamespace, toName string, kinds ...EdgeKind) bool {
+	start := time.Now()
+
+	kindSet := make(map[EdgeKind]struct{}, len(kinds))
+	for _, kind := range kinds {
+		kindSet[kind] = struct{}{}
+	}
+
+	var found bool
+	g.read(func(s *state) {
+		found = s.hasPathFromWithKinds(fromType, fromNamespace, fromName, toType, toNamespace, toName, kindSet)
+	})
+
+	metricPathQueryDuration.Observe(time.Since(start).Seconds())
+	if found {
+		metricPathQueriesTotal.WithLabelValues("found").Inc()
+	} else {
+		metricPathQueriesTotal.WithLabelValues("not_found").Inc()
+	}
+
+	return found
+}
+
+// setVertexUID records the UID of the object that the vertex identified by (vertexType, namespace, name) was last
+// observed for. It is a no-op if the vertex doesn't exist yet, which is the case for vertices that are only
+// referenced by other objects (e.g. a Secret) but never watched directly.
+func (g *graph) setVertexUID(vertexType VertexType, namespace, name string, uid types.UID) {
+	g.write(func(s *state) {
+		s.setVertexUID(vertexType, namespace, name, uid)
+	})
+}
+
+func (g *graph) addEdgesLocked(edges []edgeSpec) {
+	observeMutation(func() {
+		g.write(func(s *state) {
+			s.addEdges(edges)
+			updateNodeAndEdgeGauges(s)
+		})
+	})
+}
+
+func (g *graph) removeEdgesLocked(edges []edgeSpec) {
+	observeMutation(func() {
+		g.write(func(s *state) {
+			s.removeEdges(edges)
+			updateNodeAndEdgeGauges(s)
+		})
+	})
+}
+
+func handleDelete(obj interface{}) interface{} {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		return tombstone.Obj
+	}
+	return obj
+}
+
+func newVertex(vertexType VertexType, namespace, name string) vertexKey {
+	return vertexKey{vertexType: vertexType, namespace: namespace, name: name}
+}
+
+func newEdge(fromType VertexType, fromNamespace, fromName string, toType VertexType, toNamespace, toName string, kind EdgeKind) edgeSpec {
+	return edgeSpec{from: newVertex(fromType, fromNamespace, fromName), to: newVertex(toType, toNamespace, toName), kind: kind}
+}
+
+func secretRefEdge(ref corev1.SecretReference, toType VertexType, toNamespace, toName string, kind EdgeKind) edgeSpec {
+	return newEdge(VertexTypeSecret, ref.Namespace, ref.Name, toType, toNamespace, toName, kind)
+}
+
+func (g *graph) setupSeedWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.Seed{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			seed, ok := obj.(*gardencorev1beta1.Seed)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeSeed, seed, "", seed.Name, seed.UID, seedEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSeed, ok := oldObj.(*gardencorev1beta1.Seed)
+			if !ok {
+				return
+			}
+			newSeed, ok := newObj.(*gardencorev1beta1.Seed)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeSeed, oldSeed, newSeed, "", newSeed.Name, newSeed.UID, seedEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			seed, ok := handleDelete(obj).(*gardencorev1beta1.Seed)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeSeed, seed, seedEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func seedEdges(seed *gardencorev1beta1.Seed) []edgeSpec {
+	var edges []edgeSpec
+
+	if seed.Spec.SecretRef != nil {
+		edges = append(edges, secretRefEdge(*seed.Spec.SecretRef, VertexTypeSeed, "", seed.Name, EdgeKindSeedSecret))
+	}
+	if seed.Spec.Backup != nil {
+		edges = append(edges, secretRefEdge(seed.Spec.Backup.SecretRef, VertexTypeSeed, "", seed.Name, EdgeKindBackupSecret))
+	}
+
+	return edges
+}
+
+// seedEdgesUntyped adapts seedEdges to the func(interface{}) []edgeSpec shape observeAdd/observeUpdate/observeDelete
+// expect, since GraphOptions.Transform may hand them back an object that's still a *gardencorev1beta1.Seed but
+// isn't the same pointer the type switch in setupSeedWatch's handlers already unwrapped.
+func seedEdgesUntyped(obj interface{}) []edgeSpec {
+	seed, ok := obj.(*gardencorev1beta1.Seed)
+	if !ok {
+		return nil
+	}
+	return seedEdges(seed)
+}
+
+func (g *graph) setupShootWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.Shoot{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			shoot, ok := obj.(*gardencorev1beta1.Shoot)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeShoot, shoot, shoot.Namespace, shoot.Name, shoot.UID, shootEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldShoot, ok := oldObj.(*gardencorev1beta1.Shoot)
+			if !ok {
+				return
+			}
+			newShoot, ok := newObj.(*gardencorev1beta1.Shoot)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeShoot, oldShoot, newShoot, newShoot.Namespace, newShoot.Name, newShoot.UID, shootEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			shoot, ok := handleDelete(obj).(*gardencorev1beta1.Shoot)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeShoot, shoot, shootEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func shootEdges(shoot *gardencorev1beta1.Shoot) []edgeSpec {
+	edges := []edgeSpec{
+		newEdge(VertexTypeNamespace, "", shoot.Namespace, VertexTypeShoot, shoot.Namespace, shoot.Name, EdgeKindNamespaceShoot),
+		newEdge(VertexTypeCloudProfile, "", shoot.Spec.CloudProfileName, VertexTypeShoot, shoot.Namespace, shoot.Name, EdgeKindCloudProfileShoot),
+	}
+
+	if shoot.Spec.SecretBindingName != "" {
+		edges = append(edges, newEdge(VertexTypeSecretBinding, shoot.Namespace, shoot.Spec.SecretBindingName, VertexTypeShoot, shoot.Namespace, shoot.Name, EdgeKindSecretBindingShoot))
+	}
+
+	if shoot.Spec.DNS != nil {
+		for _, provider := range shoot.Spec.DNS.Providers {
+			if provider.SecretName != nil {
+				edges = append(edges, newEdge(VertexTypeSecret, shoot.Namespace, *provider.SecretName, VertexTypeShoot, shoot.Namespace, shoot.Name, EdgeKindDNSProviderSecret))
+			}
+		}
+	}
+
+	// Rather than hand-maintaining a list of every Secret/ConfigMap-reference-shaped field in ShootSpec (which tends
+	// to silently miss newly added fields, e.g. in a new provider config or extension ProviderConfig), discover them
+	// by walking the spec via reflection. DNS provider secrets aren't covered by this, since they're plain strings
+	// with no type to recognize.
+	edges = append(edges, secretAndConfigMapEdges(VertexTypeShoot, shoot.Namespace, shoot.Name, &shoot.Spec)...)
+
+	if shoot.Spec.SeedName != nil {
+		edges = append(edges, newEdge(VertexTypeShoot, shoot.Namespace, shoot.Name, VertexTypeSeed, "", *shoot.Spec.SeedName, EdgeKindSpecSeed))
+	}
+	if shoot.Status.SeedName != nil {
+		edges = append(edges, newEdge(VertexTypeShoot, shoot.Namespace, shoot.Name, VertexTypeSeed, "", *shoot.Status.SeedName, EdgeKindStatusSeed))
+	}
+
+	if shoot.Spec.ExposureClassName != nil {
+		edges = append(edges, newEdge(VertexTypeExposureClass, "", *shoot.Spec.ExposureClassName, VertexTypeShoot, shoot.Namespace, shoot.Name, EdgeKindExposureClassShoot))
+	}
+
+	return edges
+}
+
+// shootEdgesUntyped adapts shootEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func shootEdgesUntyped(obj interface{}) []edgeSpec {
+	shoot, ok := obj.(*gardencorev1beta1.Shoot)
+	if !ok {
+		return nil
+	}
+	return shootEdges(shoot)
+}
+
+func (g *graph) setupProjectWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.Project{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			project, ok := obj.(*gardencorev1beta1.Project)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeProject, project, "", project.Name, project.UID, projectEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldProject, ok := oldObj.(*gardencorev1beta1.Project)
+			if !ok {
+				return
+			}
+			newProject, ok := newObj.(*gardencorev1beta1.Project)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeProject, oldProject, newProject, "", newProject.Name, newProject.UID, projectEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			project, ok := handleDelete(obj).(*gardencorev1beta1.Project)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeProject, project, projectEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func projectEdges(project *gardencorev1beta1.Project) []edgeSpec {
+	if project.Spec.Namespace == nil {
+		return nil
+	}
+	return []edgeSpec{newEdge(VertexTypeProject, "", project.Name, VertexTypeNamespace, "", *project.Spec.Namespace, EdgeKindProjectNamespace)}
+}
+
+// projectEdgesUntyped adapts projectEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func projectEdgesUntyped(obj interface{}) []edgeSpec {
+	project, ok := obj.(*gardencorev1beta1.Project)
+	if !ok {
+		return nil
+	}
+	return projectEdges(project)
+}
+
+func (g *graph) setupBackupBucketWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.BackupBucket{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			bb, ok := obj.(*gardencorev1beta1.BackupBucket)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeBackupBucket, bb, "", bb.Name, bb.UID, backupBucketEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBB, ok := oldObj.(*gardencorev1beta1.BackupBucket)
+			if !ok {
+				return
+			}
+			newBB, ok := newObj.(*gardencorev1beta1.BackupBucket)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeBackupBucket, oldBB, newBB, "", newBB.Name, newBB.UID, backupBucketEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			bb, ok := handleDelete(obj).(*gardencorev1beta1.BackupBucket)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeBackupBucket, bb, backupBucketEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func backupBucketEdges(bb *gardencorev1beta1.BackupBucket) []edgeSpec {
+	edges := []edgeSpec{secretRefEdge(bb.Spec.SecretRef, VertexTypeBackupBucket, "", bb.Name, EdgeKindBackupSecret)}
+
+	if bb.Spec.SeedName != nil {
+		edges = append(edges, newEdge(VertexTypeBackupBucket, "", bb.Name, VertexTypeSeed, "", *bb.Spec.SeedName, EdgeKindBackupBucketSeed))
+	}
+
+	return edges
+}
+
+// backupBucketEdgesUntyped adapts backupBucketEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func backupBucketEdgesUntyped(obj interface{}) []edgeSpec {
+	bb, ok := obj.(*gardencorev1beta1.BackupBucket)
+	if !ok {
+		return nil
+	}
+	return backupBucketEdges(bb)
+}
+
+func (g *graph) setupBackupEntryWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.BackupEntry{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			be, ok := obj.(*gardencorev1beta1.BackupEntry)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeBackupEntry, be, be.Namespace, be.Name, be.UID, backupEntryEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBE, ok := oldObj.(*gardencorev1beta1.BackupEntry)
+			if !ok {
+				return
+			}
+			newBE, ok := newObj.(*gardencorev1beta1.BackupEntry)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeBackupEntry, oldBE, newBE, newBE.Namespace, newBE.Name, newBE.UID, backupEntryEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			be, ok := handleDelete(obj).(*gardencorev1beta1.BackupEntry)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeBackupEntry, be, backupEntryEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func backupEntryEdges(be *gardencorev1beta1.BackupEntry) []edgeSpec {
+	edges := []edgeSpec{newEdge(VertexTypeBackupEntry, be.Namespace, be.Name, VertexTypeBackupBucket, "", be.Spec.BucketName, EdgeKindBackupEntryBucket)}
+
+	if be.Spec.SeedName != nil {
+		edges = append(edges, newEdge(VertexTypeBackupEntry, be.Namespace, be.Name, VertexTypeSeed, "", *be.Spec.SeedName, EdgeKindBackupEntrySeed))
+	}
+
+	return edges
+}
+
+// backupEntryEdgesUntyped adapts backupEntryEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func backupEntryEdgesUntyped(obj interface{}) []edgeSpec {
+	be, ok := obj.(*gardencorev1beta1.BackupEntry)
+	if !ok {
+		return nil
+	}
+	return backupEntryEdges(be)
+}
+
+func (g *graph) setupSecretBindingWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.SecretBinding{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			sb, ok := obj.(*gardencorev1beta1.SecretBinding)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeSecretBinding, sb, sb.Namespace, sb.Name, sb.UID, secretBindingEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSB, ok := oldObj.(*gardencorev1beta1.SecretBinding)
+			if !ok {
+				return
+			}
+			newSB, ok := newObj.(*gardencorev1beta1.SecretBinding)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeSecretBinding, oldSB, newSB, newSB.Namespace, newSB.Name, newSB.UID, secretBindingEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			sb, ok := handleDelete(obj).(*gardencorev1beta1.SecretBinding)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeSecretBinding, sb, secretBindingEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func secretBindingEdges(sb *gardencorev1beta1.SecretBinding) []edgeSpec {
+	return []edgeSpec{secretRefEdge(sb.SecretRef, VertexTypeSecretBinding, sb.Namespace, sb.Name, EdgeKindCloudProviderSecret)}
+}
+
+// secretBindingEdgesUntyped adapts secretBindingEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func secretBindingEdgesUntyped(obj interface{}) []edgeSpec {
+	sb, ok := obj.(*gardencorev1beta1.SecretBinding)
+	if !ok {
+		return nil
+	}
+	return secretBindingEdges(sb)
+}
+
+func (g *graph) setupManagedSeedWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &seedmanagementv1alpha1.ManagedSeed{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			managedSeed, ok := obj.(*seedmanagementv1alpha1.ManagedSeed)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeManagedSeed, managedSeed, managedSeed.Namespace, managedSeed.Name, managedSeed.UID, managedSeedEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldManagedSeed, ok := oldObj.(*seedmanagementv1alpha1.ManagedSeed)
+			if !ok {
+				return
+			}
+			newManagedSeed, ok := newObj.(*seedmanagementv1alpha1.ManagedSeed)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeManagedSeed, oldManagedSeed, newManagedSeed, newManagedSeed.Namespace, newManagedSeed.Name, newManagedSeed.UID, managedSeedEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			managedSeed, ok := handleDelete(obj).(*seedmanagementv1alpha1.ManagedSeed)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeManagedSeed, managedSeed, managedSeedEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+// managedSeedEdges returns, besides the ManagedSeed->Seed edge that always exists (a ManagedSeed registers exactly
+// the Seed of the same name), an edge from the Shoot it seeds from, if configured.
+func managedSeedEdges(managedSeed *seedmanagementv1alpha1.ManagedSeed) []edgeSpec {
+	edges := []edgeSpec{
+		newEdge(VertexTypeManagedSeed, managedSeed.Namespace, managedSeed.Name, VertexTypeSeed, "", managedSeed.Name, EdgeKindManagedSeedSeed),
+	}
+
+	if managedSeed.Spec.Shoot != nil {
+		edges = append(edges, newEdge(VertexTypeShoot, managedSeed.Namespace, managedSeed.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed.Namespace, managedSeed.Name, EdgeKindManagedSeedShoot))
+	}
+
+	return edges
+}
+
+// managedSeedEdgesUntyped adapts managedSeedEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func managedSeedEdgesUntyped(obj interface{}) []edgeSpec {
+	managedSeed, ok := obj.(*seedmanagementv1alpha1.ManagedSeed)
+	if !ok {
+		return nil
+	}
+	return managedSeedEdges(managedSeed)
+}
+
+func (g *graph) setupControllerInstallationWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1beta1.ControllerInstallation{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			controllerInstallation, ok := obj.(*gardencorev1beta1.ControllerInstallation)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeControllerInstallation, controllerInstallation, "", controllerInstallation.Name, controllerInstallation.UID, controllerInstallationEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldControllerInstallation, ok := oldObj.(*gardencorev1beta1.ControllerInstallation)
+			if !ok {
+				return
+			}
+			newControllerInstallation, ok := newObj.(*gardencorev1beta1.ControllerInstallation)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeControllerInstallation, oldControllerInstallation, newControllerInstallation, "", newControllerInstallation.Name, newControllerInstallation.UID, controllerInstallationEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			controllerInstallation, ok := handleDelete(obj).(*gardencorev1beta1.ControllerInstallation)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeControllerInstallation, controllerInstallation, controllerInstallationEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func controllerInstallationEdges(controllerInstallation *gardencorev1beta1.ControllerInstallation) []edgeSpec {
+	edges := []edgeSpec{
+		newEdge(VertexTypeControllerInstallation, "", controllerInstallation.Name, VertexTypeSeed, "", controllerInstallation.Spec.SeedRef.Name, EdgeKindControllerInstallationSeed),
+		newEdge(VertexTypeControllerInstallation, "", controllerInstallation.Name, VertexTypeControllerRegistration, "", controllerInstallation.Spec.RegistrationRef.Name, EdgeKindControllerInstallationControllerRegistration),
+	}
+
+	if controllerInstallation.Spec.DeploymentRef != nil {
+		edges = append(edges, newEdge(VertexTypeControllerInstallation, "", controllerInstallation.Name, VertexTypeControllerDeployment, "", controllerInstallation.Spec.DeploymentRef.Name, EdgeKindControllerInstallationControllerDeployment))
+	}
+
+	return edges
+}
+
+// controllerInstallationEdgesUntyped adapts controllerInstallationEdges to the func(interface{}) []edgeSpec shape
+// expected by observeAdd/observeUpdate/observeDelete.
+func controllerInstallationEdgesUntyped(obj interface{}) []edgeSpec {
+	controllerInstallation, ok := obj.(*gardencorev1beta1.ControllerInstallation)
+	if !ok {
+		return nil
+	}
+	return controllerInstallationEdges(controllerInstallation)
+}
+
+func (g *graph) setupShootStateWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &gardencorev1alpha1.ShootState{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			shootState, ok := obj.(*gardencorev1alpha1.ShootState)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeShootState, shootState, shootState.Namespace, shootState.Name, shootState.UID, shootStateEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldShootState, ok := oldObj.(*gardencorev1alpha1.ShootState)
+			if !ok {
+				return
+			}
+			newShootState, ok := newObj.(*gardencorev1alpha1.ShootState)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeShootState, oldShootState, newShootState, newShootState.Namespace, newShootState.Name, newShootState.UID, shootStateEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			shootState, ok := handleDelete(obj).(*gardencorev1alpha1.ShootState)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeShootState, shootState, shootStateEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+// shootStateEdges returns the edge to the Shoot a ShootState persists extension state for. A ShootState always
+// shares its name and namespace with that Shoot.
+func shootStateEdges(shootState *gardencorev1alpha1.ShootState) []edgeSpec {
+	return []edgeSpec{
+		newEdge(VertexTypeShootState, shootState.Namespace, shootState.Name, VertexTypeShoot, shootState.Namespace, shootState.Name, EdgeKindShootStateShoot),
+	}
+}
+
+// shootStateEdgesUntyped adapts shootStateEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func shootStateEdgesUntyped(obj interface{}) []edgeSpec {
+	shootState, ok := obj.(*gardencorev1alpha1.ShootState)
+	if !ok {
+		return nil
+	}
+	return shootStateEdges(shootState)
+}
+
+func (g *graph) setupBastionWatch(ctx context.Context, informerCache cache.Cache) error {
+	informer, err := informerCache.GetInformer(ctx, &operationsv1alpha1.Bastion{})
+	if err != nil {
+		return err
+	}
+
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			bastion, ok := obj.(*operationsv1alpha1.Bastion)
+			if !ok {
+				return
+			}
+			g.observeAdd(VertexTypeBastion, bastion, bastion.Namespace, bastion.Name, bastion.UID, bastionEdgesUntyped)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBastion, ok := oldObj.(*operationsv1alpha1.Bastion)
+			if !ok {
+				return
+			}
+			newBastion, ok := newObj.(*operationsv1alpha1.Bastion)
+			if !ok {
+				return
+			}
+			g.observeUpdate(VertexTypeBastion, oldBastion, newBastion, newBastion.Namespace, newBastion.Name, newBastion.UID, bastionEdgesUntyped)
+		},
+		DeleteFunc: func(obj interface{}) {
+			bastion, ok := handleDelete(obj).(*operationsv1alpha1.Bastion)
+			if !ok {
+				return
+			}
+			g.observeDelete(VertexTypeBastion, bastion, bastionEdgesUntyped)
+		},
+	})
+
+	return nil
+}
+
+func bastionEdges(bastion *operationsv1alpha1.Bastion) []edgeSpec {
+	edges := []edgeSpec{
+		newEdge(VertexTypeShoot, bastion.Namespace, bastion.Spec.ShootRef.Name, VertexTypeBastion, bastion.Namespace, bastion.Name, EdgeKindBastionShoot),
+		secretRefEdge(bastion.Spec.SSHKeypairSecretRef, VertexTypeBastion, bastion.Namespace, bastion.Name, EdgeKindBastionSecret),
+	}
+
+	if bastion.Spec.SeedName != nil {
+		edges = append(edges, newEdge(VertexTypeBastion, bastion.Namespace, bastion.Name, VertexTypeSeed, "", *bastion.Spec.SeedName, EdgeKindBastionSeed))
+	}
+
+	return edges
+}
+
+// bastionEdgesUntyped adapts bastionEdges to the func(interface{}) []edgeSpec shape expected by
+// observeAdd/observeUpdate/observeDelete.
+func bastionEdgesUntyped(obj interface{}) []edgeSpec {
+	bastion, ok := obj.(*operationsv1alpha1.Bastion)
+	if !ok {
+		return nil
+	}
+	return bastionEdges(bastion)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// benchmarkShootCount mimics a mid-size landscape; large enough that lock contention between HasPathFrom and the
+// informer event handlers becomes visible, small enough that each benchmark still runs in a reasonable time.
+const benchmarkShootCount = 10000
+
+func populateForBenchmark(g *graph) {
+	g.addEdgesLocked([]edgeSpec{newEdge(VertexTypeSeed, "", "seed", VertexTypeSeed, "", "seed", edgeKindUnspecified)})
+
+	for i := 0; i < benchmarkShootCount; i++ {
+		name := fmt.Sprintf("shoot-%d", i)
+		g.addEdgesLocked([]edgeSpec{
+			newEdge(VertexTypeNamespace, "", "garden", VertexTypeShoot, "garden", name, edgeKindUnspecified),
+			newEdge(VertexTypeShoot, "garden", name, VertexTypeSeed, "", "seed", edgeKindUnspecified),
+		})
+	}
+}
+
+// benchmarkHasPathFrom runs HasPathFrom in parallel while a single goroutine continuously churns shoot status
+// updates in the background, approximating a landscape with a steady stream of gardenlet heartbeats.
+func benchmarkHasPathFrom(b *testing.B, strategy ConcurrencyStrategy) {
+	g := New(logzap.New(logzap.WriteTo(io.Discard)), WithConcurrencyStrategy(strategy))
+	populateForBenchmark(g)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		var i int
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				name := fmt.Sprintf("shoot-%d", i%benchmarkShootCount)
+				g.addEdgesLocked([]edgeSpec{newEdge(VertexTypeNamespace, "", "garden", VertexTypeShoot, "garden", name, edgeKindUnspecified)})
+				i++
+			}
+		}
+	}()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		var i int64
+		for pb.Next() {
+			n := atomic.AddInt64(&i, 1) % benchmarkShootCount
+			g.HasPathFrom(VertexTypeShoot, "garden", fmt.Sprintf("shoot-%d", n), VertexTypeSeed, "", "seed")
+		}
+	})
+}
+
+// BenchmarkHasPathFrom_Mutex benchmarks the long-standing single RWMutex implementation, where HasPathFrom
+// contends with the writer goroutine for the same lock.
+func BenchmarkHasPathFrom_Mutex(b *testing.B) {
+	benchmarkHasPathFrom(b, ConcurrencyStrategyMutex)
+}
+
+// BenchmarkHasPathFrom_RCU benchmarks the copy-on-write implementation, where HasPathFrom never blocks on the
+// writer goroutine.
+func BenchmarkHasPathFrom_RCU(b *testing.B) {
+	benchmarkHasPathFrom(b, ConcurrencyStrategyRCU)
+}
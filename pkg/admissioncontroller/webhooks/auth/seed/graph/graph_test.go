@@ -17,7 +17,10 @@ package graph
 import (
 	"context"
 
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
 
 	"github.com/go-logr/logr"
@@ -26,6 +29,7 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
@@ -44,7 +48,12 @@ var _ = Describe("graph", func() {
 		fakeInformerBackupBucket  *controllertest.FakeInformer
 		fakeInformerBackupEntry   *controllertest.FakeInformer
 		fakeInformerSecretBinding *controllertest.FakeInformer
-		fakeInformers             *informertest.FakeInformers
+
+		fakeInformerManagedSeed            *controllertest.FakeInformer
+		fakeInformerControllerInstallation *controllertest.FakeInformer
+		fakeInformerShootState             *controllertest.FakeInformer
+		fakeInformerBastion                *controllertest.FakeInformer
+		fakeInformers                      *informertest.FakeInformers
 
 		logger logr.Logger
 		graph  *graph
@@ -69,6 +78,15 @@ var _ = Describe("graph", func() {
 
 		secretBinding1          *gardencorev1beta1.SecretBinding
 		secretBinding1SecretRef = corev1.SecretReference{Namespace: "foobar", Name: "bazfoo"}
+
+		managedSeed1 *seedmanagementv1alpha1.ManagedSeed
+
+		controllerInstallation1 *gardencorev1beta1.ControllerInstallation
+
+		shootState1 *gardencorev1alpha1.ShootState
+
+		bastion1             *operationsv1alpha1.Bastion
+		bastion1SSHSecretRef = corev1.SecretReference{Namespace: "garden-bastion1namespace", Name: "bastion1-ssh-keypair"}
 	)
 
 	BeforeEach(func() {
@@ -81,16 +99,24 @@ var _ = Describe("graph", func() {
 		fakeInformerBackupBucket = &controllertest.FakeInformer{}
 		fakeInformerBackupEntry = &controllertest.FakeInformer{}
 		fakeInformerSecretBinding = &controllertest.FakeInformer{}
+		fakeInformerManagedSeed = &controllertest.FakeInformer{}
+		fakeInformerControllerInstallation = &controllertest.FakeInformer{}
+		fakeInformerShootState = &controllertest.FakeInformer{}
+		fakeInformerBastion = &controllertest.FakeInformer{}
 
 		fakeInformers = &informertest.FakeInformers{
 			Scheme: scheme,
 			InformersByGVK: map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
-				gardencorev1beta1.SchemeGroupVersion.WithKind("Seed"):          fakeInformerSeed,
-				gardencorev1beta1.SchemeGroupVersion.WithKind("Shoot"):         fakeInformerShoot,
-				gardencorev1beta1.SchemeGroupVersion.WithKind("Project"):       fakeInformerProject,
-				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupBucket"):  fakeInformerBackupBucket,
-				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupEntry"):   fakeInformerBackupEntry,
-				gardencorev1beta1.SchemeGroupVersion.WithKind("SecretBinding"): fakeInformerSecretBinding,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("Seed"):                   fakeInformerSeed,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("Shoot"):                  fakeInformerShoot,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("Project"):                fakeInformerProject,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupBucket"):           fakeInformerBackupBucket,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupEntry"):            fakeInformerBackupEntry,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("SecretBinding"):          fakeInformerSecretBinding,
+				seedmanagementv1alpha1.SchemeGroupVersion.WithKind("ManagedSeed"):       fakeInformerManagedSeed,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("ControllerInstallation"): fakeInformerControllerInstallation,
+				gardencorev1alpha1.SchemeGroupVersion.WithKind("ShootState"):            fakeInformerShootState,
+				operationsv1alpha1.SchemeGroupVersion.WithKind("Bastion"):               fakeInformerBastion,
 			},
 		}
 
@@ -155,6 +181,35 @@ var _ = Describe("graph", func() {
 			ObjectMeta: metav1.ObjectMeta{Name: "secretbinding1", Namespace: "sb1namespace"},
 			SecretRef:  secretBinding1SecretRef,
 		}
+
+		managedSeed1 = &seedmanagementv1alpha1.ManagedSeed{
+			ObjectMeta: metav1.ObjectMeta{Name: "managedseed1", Namespace: "garden"},
+			Spec: seedmanagementv1alpha1.ManagedSeedSpec{
+				Shoot: &seedmanagementv1alpha1.Shoot{Name: "managedseed1shoot"},
+			},
+		}
+
+		controllerInstallation1 = &gardencorev1beta1.ControllerInstallation{
+			ObjectMeta: metav1.ObjectMeta{Name: "controllerinstallation1"},
+			Spec: gardencorev1beta1.ControllerInstallationSpec{
+				SeedRef:         corev1.ObjectReference{Name: seed1.Name},
+				RegistrationRef: corev1.ObjectReference{Name: "controllerregistration1"},
+				DeploymentRef:   &corev1.ObjectReference{Name: "controllerdeployment1"},
+			},
+		}
+
+		shootState1 = &gardencorev1alpha1.ShootState{
+			ObjectMeta: metav1.ObjectMeta{Name: "shoot1", Namespace: "namespace1"},
+		}
+
+		bastion1 = &operationsv1alpha1.Bastion{
+			ObjectMeta: metav1.ObjectMeta{Name: "bastion1", Namespace: "garden-bastion1namespace"},
+			Spec: operationsv1alpha1.BastionSpec{
+				ShootRef:            corev1.LocalObjectReference{Name: "bastion1shoot"},
+				SeedName:            pointer.StringPtr("bastion1seed"),
+				SSHKeypairSecretRef: bastion1SSHSecretRef,
+			},
+		}
 	})
 
 	It("should behave as expected for gardencorev1beta1.Seed", func() {
@@ -349,6 +404,22 @@ var _ = Describe("graph", func() {
 		Expect(graph.HasPathFrom(VertexTypeShoot, shoot1.Namespace, shoot1.Name, VertexTypeSeed, "", "newseed")).To(BeTrue())
 		Expect(graph.HasPathFrom(VertexTypeShoot, shoot1.Namespace, shoot1.Name, VertexTypeSeed, "", "seed-in-status")).To(BeTrue())
 
+		By("update (exposure class name)")
+		shoot1Copy = shoot1.DeepCopy()
+		shoot1.Spec.ExposureClassName = pointer.StringPtr("exposureclass1")
+		fakeInformerShoot.Update(shoot1Copy, shoot1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(7))
+		Expect(graph.graph.Edges().Len()).To(Equal(6))
+		Expect(graph.HasPathFrom(VertexTypeExposureClass, "", *shoot1.Spec.ExposureClassName, VertexTypeShoot, shoot1.Namespace, shoot1.Name)).To(BeTrue())
+
+		By("update (remove exposure class name)")
+		shoot1Copy = shoot1.DeepCopy()
+		shoot1.Spec.ExposureClassName = nil
+		fakeInformerShoot.Update(shoot1Copy, shoot1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(6))
+		Expect(graph.graph.Edges().Len()).To(Equal(5))
+		Expect(graph.HasPathFrom(VertexTypeExposureClass, "", *shoot1Copy.Spec.ExposureClassName, VertexTypeShoot, shoot1.Namespace, shoot1.Name)).To(BeFalse())
+
 		By("delete")
 		fakeInformerShoot.Delete(shoot1)
 		Expect(graph.graph.Nodes().Len()).To(BeZero())
@@ -516,4 +587,232 @@ var _ = Describe("graph", func() {
 		Expect(graph.graph.Edges().Len()).To(BeZero())
 		Expect(graph.HasPathFrom(VertexTypeSecret, secretBinding1.SecretRef.Namespace, secretBinding1.SecretRef.Name, VertexTypeSecretBinding, secretBinding1.Namespace, secretBinding1.Name)).To(BeFalse())
 	})
+
+	It("should behave as expected for seedmanagementv1alpha1.ManagedSeed", func() {
+		By("add")
+		fakeInformerManagedSeed.Add(managedSeed1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(3))
+		Expect(graph.graph.Edges().Len()).To(Equal(2))
+		Expect(graph.HasPathFrom(VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name, VertexTypeSeed, "", managedSeed1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeShoot, managedSeed1.Namespace, managedSeed1.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name)).To(BeTrue())
+
+		By("update (irrelevant change)")
+		managedSeed1Copy := managedSeed1.DeepCopy()
+		managedSeed1.Labels = map[string]string{"foo": "bar"}
+		fakeInformerManagedSeed.Update(managedSeed1Copy, managedSeed1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(3))
+		Expect(graph.graph.Edges().Len()).To(Equal(2))
+		Expect(graph.HasPathFrom(VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name, VertexTypeSeed, "", managedSeed1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeShoot, managedSeed1.Namespace, managedSeed1.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name)).To(BeTrue())
+
+		By("update (remove shoot ref)")
+		managedSeed1Copy = managedSeed1.DeepCopy()
+		managedSeed1.Spec.Shoot = nil
+		fakeInformerManagedSeed.Update(managedSeed1Copy, managedSeed1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(2))
+		Expect(graph.graph.Edges().Len()).To(Equal(1))
+		Expect(graph.HasPathFrom(VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name, VertexTypeSeed, "", managedSeed1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeShoot, managedSeed1Copy.Namespace, managedSeed1Copy.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name)).To(BeFalse())
+
+		By("update (change shoot ref)")
+		managedSeed1Copy = managedSeed1.DeepCopy()
+		managedSeed1.Spec.Shoot = &seedmanagementv1alpha1.Shoot{Name: "othershoot"}
+		fakeInformerManagedSeed.Update(managedSeed1Copy, managedSeed1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(3))
+		Expect(graph.graph.Edges().Len()).To(Equal(2))
+		Expect(graph.HasPathFrom(VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name, VertexTypeSeed, "", managedSeed1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeShoot, managedSeed1.Namespace, managedSeed1.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name)).To(BeTrue())
+
+		By("delete")
+		fakeInformerManagedSeed.Delete(managedSeed1)
+		Expect(graph.graph.Nodes().Len()).To(BeZero())
+		Expect(graph.graph.Edges().Len()).To(BeZero())
+		Expect(graph.HasPathFrom(VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name, VertexTypeSeed, "", managedSeed1.Name)).To(BeFalse())
+		Expect(graph.HasPathFrom(VertexTypeShoot, managedSeed1.Namespace, managedSeed1.Spec.Shoot.Name, VertexTypeManagedSeed, managedSeed1.Namespace, managedSeed1.Name)).To(BeFalse())
+	})
+
+	It("should behave as expected for gardencorev1beta1.ControllerInstallation", func() {
+		By("add")
+		fakeInformerControllerInstallation.Add(controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeSeed, "", seed1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerRegistration, "", controllerInstallation1.Spec.RegistrationRef.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerDeployment, "", controllerInstallation1.Spec.DeploymentRef.Name)).To(BeTrue())
+
+		By("update (irrelevant change)")
+		controllerInstallation1Copy := controllerInstallation1.DeepCopy()
+		controllerInstallation1.Labels = map[string]string{"foo": "bar"}
+		fakeInformerControllerInstallation.Update(controllerInstallation1Copy, controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeSeed, "", seed1.Name)).To(BeTrue())
+
+		By("update (change seed ref)")
+		controllerInstallation1Copy = controllerInstallation1.DeepCopy()
+		controllerInstallation1.Spec.SeedRef = corev1.ObjectReference{Name: "otherseed"}
+		fakeInformerControllerInstallation.Update(controllerInstallation1Copy, controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeSeed, "", seed1.Name)).To(BeFalse())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeSeed, "", "otherseed")).To(BeTrue())
+
+		By("update (change controller registration/deployment ref)")
+		controllerInstallation1Copy = controllerInstallation1.DeepCopy()
+		controllerInstallation1.Spec.RegistrationRef = corev1.ObjectReference{Name: "othercontrollerregistration"}
+		controllerInstallation1.Spec.DeploymentRef = &corev1.ObjectReference{Name: "othercontrollerdeployment"}
+		fakeInformerControllerInstallation.Update(controllerInstallation1Copy, controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerRegistration, "", controllerInstallation1Copy.Spec.RegistrationRef.Name)).To(BeFalse())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerRegistration, "", controllerInstallation1.Spec.RegistrationRef.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerDeployment, "", controllerInstallation1.Spec.DeploymentRef.Name)).To(BeTrue())
+
+		By("update (remove deployment ref)")
+		controllerInstallation1Copy = controllerInstallation1.DeepCopy()
+		controllerInstallation1.Spec.DeploymentRef = nil
+		fakeInformerControllerInstallation.Update(controllerInstallation1Copy, controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(3))
+		Expect(graph.graph.Edges().Len()).To(Equal(2))
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeControllerDeployment, "", controllerInstallation1Copy.Spec.DeploymentRef.Name)).To(BeFalse())
+
+		By("delete")
+		fakeInformerControllerInstallation.Delete(controllerInstallation1)
+		Expect(graph.graph.Nodes().Len()).To(BeZero())
+		Expect(graph.graph.Edges().Len()).To(BeZero())
+		Expect(graph.HasPathFrom(VertexTypeControllerInstallation, "", controllerInstallation1.Name, VertexTypeSeed, "", "otherseed")).To(BeFalse())
+	})
+
+	It("should behave as expected for gardencorev1alpha1.ShootState", func() {
+		By("add")
+		fakeInformerShootState.Add(shootState1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(2))
+		Expect(graph.graph.Edges().Len()).To(Equal(1))
+		Expect(graph.HasPathFrom(VertexTypeShootState, shootState1.Namespace, shootState1.Name, VertexTypeShoot, shootState1.Namespace, shootState1.Name)).To(BeTrue())
+
+		By("update (irrelevant change)")
+		shootState1Copy := shootState1.DeepCopy()
+		shootState1.Labels = map[string]string{"foo": "bar"}
+		fakeInformerShootState.Update(shootState1Copy, shootState1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(2))
+		Expect(graph.graph.Edges().Len()).To(Equal(1))
+		Expect(graph.HasPathFrom(VertexTypeShootState, shootState1.Namespace, shootState1.Name, VertexTypeShoot, shootState1.Namespace, shootState1.Name)).To(BeTrue())
+
+		By("delete")
+		fakeInformerShootState.Delete(shootState1)
+		Expect(graph.graph.Nodes().Len()).To(BeZero())
+		Expect(graph.graph.Edges().Len()).To(BeZero())
+		Expect(graph.HasPathFrom(VertexTypeShootState, shootState1.Namespace, shootState1.Name, VertexTypeShoot, shootState1.Namespace, shootState1.Name)).To(BeFalse())
+	})
+
+	It("should behave as expected for operationsv1alpha1.Bastion", func() {
+		By("add")
+		fakeInformerBastion.Add(bastion1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeShoot, bastion1.Namespace, bastion1.Spec.ShootRef.Name, VertexTypeBastion, bastion1.Namespace, bastion1.Name)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeBastion, bastion1.Namespace, bastion1.Name, VertexTypeSeed, "", *bastion1.Spec.SeedName)).To(BeTrue())
+		Expect(graph.HasPathFrom(VertexTypeSecret, bastion1SSHSecretRef.Namespace, bastion1SSHSecretRef.Name, VertexTypeBastion, bastion1.Namespace, bastion1.Name)).To(BeTrue())
+
+		By("update (irrelevant change)")
+		bastion1Copy := bastion1.DeepCopy()
+		bastion1.Labels = map[string]string{"foo": "bar"}
+		fakeInformerBastion.Update(bastion1Copy, bastion1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeShoot, bastion1.Namespace, bastion1.Spec.ShootRef.Name, VertexTypeBastion, bastion1.Namespace, bastion1.Name)).To(BeTrue())
+
+		By("update (change seed name)")
+		bastion1Copy = bastion1.DeepCopy()
+		bastion1.Spec.SeedName = pointer.StringPtr("otherbastionseed")
+		fakeInformerBastion.Update(bastion1Copy, bastion1)
+		Expect(graph.graph.Nodes().Len()).To(Equal(4))
+		Expect(graph.graph.Edges().Len()).To(Equal(3))
+		Expect(graph.HasPathFrom(VertexTypeBastion, bastion1.Namespace, bastion1.Name, VertexTypeSeed, "", *bastion1Copy.Spec.SeedName)).To(BeFalse())
+		Expect(graph.HasPathFrom(VertexTypeBastion, bastion1.Namespace, bastion1.Name, VertexTypeSeed, "", *bastion1.Spec.SeedName)).To(BeTrue())
+
+		By("delete")
+		fakeInformerBastion.Delete(bastion1)
+		Expect(graph.graph.Nodes().Len()).To(BeZero())
+		Expect(graph.graph.Edges().Len()).To(BeZero())
+		Expect(graph.HasPathFrom(VertexTypeShoot, bastion1.Namespace, bastion1.Spec.ShootRef.Name, VertexTypeBastion, bastion1.Namespace, bastion1.Name)).To(BeFalse())
+	})
+
+	Describe("GraphOptions", func() {
+		var (
+			optFakeInformerShoot *controllertest.FakeInformer
+			optFakeInformers     *informertest.FakeInformers
+			optGraph             *graph
+
+			shoot2 *gardencorev1beta1.Shoot
+		)
+
+		BeforeEach(func() {
+			scheme := kubernetes.GardenScheme
+			Expect(metav1.AddMetaToScheme(scheme)).To(Succeed())
+
+			optFakeInformerShoot = &controllertest.FakeInformer{}
+			optFakeInformers = &informertest.FakeInformers{
+				Scheme: scheme,
+				InformersByGVK: map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
+					gardencorev1beta1.SchemeGroupVersion.WithKind("Shoot"): optFakeInformerShoot,
+				},
+			}
+
+			shoot2 = &gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot2", Namespace: "namespace2", Labels: map[string]string{"gardener.cloud/ignore-graph": "true"}},
+				Spec: gardencorev1beta1.ShootSpec{
+					SecretBindingName: "secretbinding2",
+				},
+			}
+		})
+
+		It("excludes objects that don't match Selector", func() {
+			selector, err := labels.Parse("gardener.cloud/ignore-graph != true")
+			Expect(err).NotTo(HaveOccurred())
+
+			optGraph = New(logger, WithGraphOptions(GraphOptions{
+				VertexTypeShoot: {Selector: selector},
+			}))
+			Expect(optGraph.Setup(ctx, optFakeInformers)).To(Succeed())
+
+			By("add (excluded by selector)")
+			optFakeInformerShoot.Add(shoot2)
+			Expect(optGraph.graph.Nodes().Len()).To(BeZero())
+			Expect(optGraph.HasPathFrom(VertexTypeSecretBinding, shoot2.Namespace, shoot2.Spec.SecretBindingName, VertexTypeShoot, shoot2.Namespace, shoot2.Name)).To(BeFalse())
+
+			By("update (starts matching selector)")
+			shoot2Copy := shoot2.DeepCopy()
+			shoot2.Labels = nil
+			optFakeInformerShoot.Update(shoot2Copy, shoot2)
+			Expect(optGraph.HasPathFrom(VertexTypeSecretBinding, shoot2.Namespace, shoot2.Spec.SecretBindingName, VertexTypeShoot, shoot2.Namespace, shoot2.Name)).To(BeTrue())
+
+			By("update (stops matching selector again)")
+			shoot2Copy = shoot2.DeepCopy()
+			shoot2.Labels = map[string]string{"gardener.cloud/ignore-graph": "true"}
+			optFakeInformerShoot.Update(shoot2Copy, shoot2)
+			Expect(optGraph.HasPathFrom(VertexTypeSecretBinding, shoot2.Namespace, shoot2.Spec.SecretBindingName, VertexTypeShoot, shoot2.Namespace, shoot2.Name)).To(BeFalse())
+		})
+
+		It("applies Transform before computing edges", func() {
+			optGraph = New(logger, WithGraphOptions(GraphOptions{
+				VertexTypeShoot: {
+					Transform: func(obj interface{}) (interface{}, error) {
+						shoot, ok := obj.(*gardencorev1beta1.Shoot)
+						if !ok {
+							return obj, nil
+						}
+						transformed := shoot.DeepCopy()
+						transformed.Spec.SecretBindingName = "transformed-" + transformed.Spec.SecretBindingName
+						return transformed, nil
+					},
+				},
+			}))
+			Expect(optGraph.Setup(ctx, optFakeInformers)).To(Succeed())
+
+			optFakeInformerShoot.Add(shoot2)
+			Expect(optGraph.HasPathFrom(VertexTypeSecretBinding, shoot2.Namespace, shoot2.Spec.SecretBindingName, VertexTypeShoot, shoot2.Namespace, shoot2.Name)).To(BeFalse())
+			Expect(optGraph.HasPathFrom(VertexTypeSecretBinding, shoot2.Namespace, "transformed-"+shoot2.Spec.SecretBindingName, VertexTypeShoot, shoot2.Namespace, shoot2.Name)).To(BeTrue())
+		})
+	})
 })
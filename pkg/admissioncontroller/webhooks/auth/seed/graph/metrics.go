@@ -0,0 +1,92 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "gardener_admission_controller"
+
+var (
+	metricNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "nodes",
+		Help:      "Current number of nodes in the seed authorizer graph, partitioned by vertex type.",
+	}, []string{"vertex_type"})
+
+	metricEdges = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "edges",
+		Help:      "Current number of edges in the seed authorizer graph.",
+	})
+
+	metricEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "informer_events_total",
+		Help:      "Number of informer add/update/delete events processed by the seed authorizer graph, partitioned by vertex type and event.",
+	}, []string{"vertex_type", "event"})
+
+	metricPathQueriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "path_queries_total",
+		Help:      "Number of HasPathFrom queries, partitioned by whether a path was found.",
+	}, []string{"result"})
+
+	metricPathQueryDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "path_query_duration_seconds",
+		Help:      "Latency of HasPathFrom queries.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	metricMutationDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "seed_authorizer_graph",
+		Name:      "mutation_duration_seconds",
+		Help:      "Latency of a single graph write (adding or removing the edges for one object).",
+		Buckets:   prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	prometheus.MustRegister(metricNodes, metricEdges, metricEventsTotal, metricPathQueriesTotal, metricPathQueryDuration, metricMutationDuration)
+}
+
+// updateNodeAndEdgeGauges recomputes the node-count-by-type and edge-count gauges from s. The caller must already
+// hold whatever access discipline applies to s (see graph.read/graph.write).
+func updateNodeAndEdgeGauges(s *state) {
+	counts := map[VertexType]int{}
+	for _, key := range s.ids {
+		counts[key.vertexType]++
+	}
+	for vertexType, count := range counts {
+		metricNodes.WithLabelValues(vertexType.String()).Set(float64(count))
+	}
+	metricEdges.Set(float64(s.graph.Edges().Len()))
+}
+
+func observeMutation(fn func()) {
+	start := time.Now()
+	defer func() { metricMutationDuration.Observe(time.Since(start).Seconds()) }()
+	fn()
+}
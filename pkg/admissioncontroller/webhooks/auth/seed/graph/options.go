@@ -0,0 +1,128 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+// VertexOptions configures how a single VertexType's informer events are consumed, mirroring the per-object
+// options sigs.k8s.io/controller-runtime/pkg/cache.ByObject exposes at the cache level (Label/Field selectors,
+// Transform, UnsafeDisableDeepCopy). They're surfaced here too, rather than left entirely to the caller's
+// cache.Options, because a landscape with thousands of Shoots pays the memory cost of every one of them sitting in
+// this package's own vertices/ids/edgeKinds maps - restricting what even reaches those maps matters as much here as
+// it does in the informer's own store.
+type VertexOptions struct {
+	// Selector restricts which objects of this VertexType are reflected in the graph, e.g. to let a landscape with
+	// many thousands of Shoots exclude those labeled gardener.cloud/ignore-graph=true. nil means "no restriction".
+	// A vertex that stops matching Selector on update is treated as deleted; one that starts matching is treated
+	// as added.
+	Selector labels.Selector
+	// Transform projects an informer-delivered object down to only the fields this package's xEdges functions
+	// read, before it's used to compute edges. This is the same shape as
+	// sigs.k8s.io/controller-runtime/pkg/cache.ByObject.Transform and is meant to be configured identically, so
+	// that the object retained by the informer's own store is no larger than what this package actually needs.
+	// nil means "use the object unmodified".
+	Transform toolscache.TransformFunc
+	// UnsafeDisableDeepCopy documents that the cache.Cache passed to Setup/SetupWithRestore has been configured
+	// (via cache.Options.ByObject.UnsafeDisableDeepCopy) to skip deep-copying objects of this VertexType before
+	// delivering them to event handlers. It doesn't configure anything itself - that happens where the cache.Cache
+	// is constructed - it only records, in one place, that doing so is safe: every xEdges function in this
+	// package only reads fields off the object it's given and never mutates or retains it past the handler call.
+	UnsafeDisableDeepCopy bool
+}
+
+// GraphOptions configures, per VertexType, how its informer events are consumed. A VertexType missing from the map
+// gets the zero VertexOptions (no selector, no transform, deep-copy left enabled).
+type GraphOptions map[VertexType]VertexOptions
+
+// WithGraphOptions overrides the graph's GraphOptions. The default, if this option isn't given, is an empty
+// GraphOptions.
+func WithGraphOptions(options GraphOptions) Option {
+	return func(g *graph) { g.options = options }
+}
+
+// acceptVertex applies the Selector and Transform configured for vertexType (see GraphOptions) to obj. accepted is
+// false if a Selector is configured and obj doesn't match it, in which case obj must be treated as absent from the
+// graph. Otherwise, the returned value is the result of Transform (or obj unchanged, if none is configured).
+func (g *graph) acceptVertex(vertexType VertexType, obj interface{}) (out interface{}, accepted bool) {
+	opts := g.options[vertexType]
+
+	if opts.Selector != nil {
+		accessor, err := apimeta.Accessor(obj)
+		if err != nil || !opts.Selector.Matches(labels.Set(accessor.GetLabels())) {
+			return nil, false
+		}
+	}
+
+	if opts.Transform == nil {
+		return obj, true
+	}
+
+	transformed, err := opts.Transform(obj)
+	if err != nil {
+		g.log.Error(err, "failed transforming object for seed authorizer graph", "vertexType", vertexType.String())
+		return obj, true
+	}
+	return transformed, true
+}
+
+// observeAdd applies GraphOptions for vertexType to obj and, if accepted, adds the edges edgesFunc computes for it
+// and records its UID.
+func (g *graph) observeAdd(vertexType VertexType, obj interface{}, namespace, name string, uid types.UID, edgesFunc func(interface{}) []edgeSpec) {
+	accepted, ok := g.acceptVertex(vertexType, obj)
+	if !ok {
+		return
+	}
+
+	metricEventsTotal.WithLabelValues(vertexType.String(), "add").Inc()
+	g.addEdgesLocked(edgesFunc(accepted))
+	g.setVertexUID(vertexType, namespace, name, uid)
+}
+
+// observeUpdate applies GraphOptions for vertexType to oldObj/newObj and reconciles the edges edgesFunc computes
+// for each. A vertex that no longer matches a configured Selector is treated the same as a delete; one that starts
+// matching is treated the same as an add.
+func (g *graph) observeUpdate(vertexType VertexType, oldObj, newObj interface{}, namespace, name string, uid types.UID, edgesFunc func(interface{}) []edgeSpec) {
+	oldAccepted, oldOK := g.acceptVertex(vertexType, oldObj)
+	newAccepted, newOK := g.acceptVertex(vertexType, newObj)
+	if !oldOK && !newOK {
+		return
+	}
+
+	metricEventsTotal.WithLabelValues(vertexType.String(), "update").Inc()
+	if oldOK {
+		g.removeEdgesLocked(edgesFunc(oldAccepted))
+	}
+	if newOK {
+		g.addEdgesLocked(edgesFunc(newAccepted))
+	}
+	g.setVertexUID(vertexType, namespace, name, uid)
+}
+
+// observeDelete applies GraphOptions for vertexType to obj and, if accepted, removes the edges edgesFunc computes
+// for it.
+func (g *graph) observeDelete(vertexType VertexType, obj interface{}, edgesFunc func(interface{}) []edgeSpec) {
+	accepted, ok := g.acceptVertex(vertexType, obj)
+	if !ok {
+		return
+	}
+
+	metricEventsTotal.WithLabelValues(vertexType.String(), "delete").Inc()
+	g.removeEdgesLocked(edgesFunc(accepted))
+}
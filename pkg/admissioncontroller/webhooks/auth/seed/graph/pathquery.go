@@ -0,0 +1,139 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// defaultMaxPathDepth is used by HasPathFromWithLimit/AllPathsFrom if WithMaxPathDepth isn't given. It matches the
+// deepest legitimate reference chain today, e.g. Seed->Shoot->SecretBinding->Secret->Namespace. Deployments that
+// introduce longer legitimate chains should raise it via the gardener-admission-controller flag that plumbs into
+// WithMaxPathDepth.
+const defaultMaxPathDepth = 5
+
+// defaultMaxVisitedNodes bounds the number of nodes HasPathFromWithLimit/AllPathsFrom will visit, independent of
+// maxDepth, so that a vertex with pathological fan-out can't make a single decision run for an unbounded time.
+const defaultMaxVisitedNodes = 10000
+
+// ErrSearchAborted is returned by HasPathFromWithLimit and AllPathsFrom when the search had to stop before it could
+// prove whether a path exists, either because maxDepth or the configured node-visit budget was exceeded, or because
+// ctx was done. Callers making an authorization decision must treat this the same as "path found" (fail closed):
+// an aborted search cannot rule out that a path exists just beyond where it stopped looking.
+var ErrSearchAborted = errors.New("seed authorizer graph: path search aborted before it could complete")
+
+// VertexIdentifier identifies a vertex in the graph for callers outside the package, e.g. HasPathFromWithLimit and
+// AllPathsFrom.
+type VertexIdentifier struct {
+	Type      VertexType
+	Namespace string
+	Name      string
+}
+
+// Path is a sequence of vertices, in traversal order, connecting two vertices in the graph.
+type Path []VertexIdentifier
+
+// WithMaxPathDepth overrides the maximum BFS depth used by HasPathFromWithLimit and AllPathsFrom. The default is
+// defaultMaxPathDepth.
+func WithMaxPathDepth(maxDepth int) Option {
+	return func(g *graph) { g.maxPathDepth = maxDepth }
+}
+
+// WithMaxVisitedNodes overrides the node-visit budget used by HasPathFromWithLimit and AllPathsFrom. The default is
+// defaultMaxVisitedNodes.
+func WithMaxVisitedNodes(maxVisitedNodes int) Option {
+	return func(g *graph) { g.maxVisitedNodes = maxVisitedNodes }
+}
+
+// HasPathFromWithLimit behaves like HasPathFrom, but bounds the traversal to at most maxDepth hops (0 disables the
+// override and falls back to the graph's configured default) and to the graph's configured node-visit budget, and
+// aborts early if ctx is done. Unlike HasPathFrom, it can fail: ErrSearchAborted means the search was cut short
+// before it could prove whether a path exists, which callers making an authorization decision must treat as if a
+// path was found.
+func (g *graph) HasPathFromWithLimit(ctx context.Context, from, to VertexIdentifier, maxDepth int) (bool, error) {
+	if maxDepth <= 0 {
+		maxDepth = g.pathDepthLimit()
+	}
+
+	start := time.Now()
+
+	var (
+		found bool
+		err   error
+	)
+	g.read(func(s *state) {
+		fromID, ok := s.lookup(from.Type, from.Namespace, from.Name)
+		if !ok {
+			return
+		}
+		toID, ok := s.lookup(to.Type, to.Namespace, to.Name)
+		if !ok {
+			return
+		}
+		found, err = s.hasPathFromWithLimit(ctx, fromID, toID, maxDepth, g.visitedNodesLimit())
+	})
+
+	metricPathQueryDuration.Observe(time.Since(start).Seconds())
+	switch {
+	case err != nil:
+		metricPathQueriesTotal.WithLabelValues("aborted").Inc()
+	case found:
+		metricPathQueriesTotal.WithLabelValues("found").Inc()
+	default:
+		metricPathQueriesTotal.WithLabelValues("not_found").Inc()
+	}
+
+	return found, err
+}
+
+// AllPathsFrom returns, for every vertex reachable from the vertex identified by from within maxDepth hops (0 falls
+// back to the graph's configured default), the shortest path leading to it. It is meant for diagnostics (e.g.
+// "everything seed X can reach and why"), not for authorization decisions, so a partial result is returned alongside
+// ErrSearchAborted if the search had to be cut short.
+func (g *graph) AllPathsFrom(ctx context.Context, from VertexIdentifier, maxDepth int) ([]Path, error) {
+	if maxDepth <= 0 {
+		maxDepth = g.pathDepthLimit()
+	}
+
+	var (
+		paths []Path
+		err   error
+	)
+	g.read(func(s *state) {
+		fromID, ok := s.lookup(from.Type, from.Namespace, from.Name)
+		if !ok {
+			return
+		}
+		paths, err = s.allPathsFrom(ctx, fromID, maxDepth, g.visitedNodesLimit())
+	})
+
+	return paths, err
+}
+
+func (g *graph) pathDepthLimit() int {
+	if g.maxPathDepth > 0 {
+		return g.maxPathDepth
+	}
+	return defaultMaxPathDepth
+}
+
+func (g *graph) visitedNodesLimit() int {
+	if g.maxVisitedNodes > 0 {
+		return g.maxVisitedNodes
+	}
+	return defaultMaxVisitedNodes
+}
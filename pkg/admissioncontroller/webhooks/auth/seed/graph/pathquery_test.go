@@ -0,0 +1,179 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("bounded path queries", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Describe("HasPathFromWithLimit", func() {
+		It("finds a path within the depth limit", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeNamespace, "", "a", VertexTypeShoot, "a", "b", edgeKindUnspecified),
+				newEdge(VertexTypeShoot, "a", "b", VertexTypeSeed, "", "c", edgeKindUnspecified),
+			})
+
+			found, err := g.HasPathFromWithLimit(ctx, VertexIdentifier{Type: VertexTypeNamespace, Name: "a"}, VertexIdentifier{Type: VertexTypeSeed, Name: "c"}, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns ErrSearchAborted, not false, for a path that only exists beyond maxDepth", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeNamespace, "", "a", VertexTypeShoot, "a", "b", edgeKindUnspecified),
+				newEdge(VertexTypeShoot, "a", "b", VertexTypeSeed, "", "c", edgeKindUnspecified),
+			})
+
+			found, err := g.HasPathFromWithLimit(ctx, VertexIdentifier{Type: VertexTypeNamespace, Name: "a"}, VertexIdentifier{Type: VertexTypeSeed, Name: "c"}, 1)
+			Expect(err).To(MatchError(ErrSearchAborted))
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not get stuck in a cycle and correctly reports no path to an unrelated vertex", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeNamespace, "", "a", VertexTypeShoot, "", "b", edgeKindUnspecified),
+				newEdge(VertexTypeShoot, "", "b", VertexTypeSeed, "", "c", edgeKindUnspecified),
+				newEdge(VertexTypeSeed, "", "c", VertexTypeNamespace, "", "a", edgeKindUnspecified),
+				newEdge(VertexTypeProject, "", "unrelated", VertexTypeNamespace, "", "other", edgeKindUnspecified),
+			})
+
+			found, err := g.HasPathFromWithLimit(ctx, VertexIdentifier{Type: VertexTypeNamespace, Name: "a"}, VertexIdentifier{Type: VertexTypeProject, Name: "unrelated"}, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("aborts rather than walking a 10k-hop chain past maxDepth", func() {
+			const chainLength = 10000
+
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			for i := 0; i < chainLength; i++ {
+				g.addEdgesLocked([]edgeSpec{
+					newEdge(VertexTypeShoot, "", fmt.Sprintf("n%d", i), VertexTypeShoot, "", fmt.Sprintf("n%d", i+1), edgeKindUnspecified),
+				})
+			}
+
+			found, err := g.HasPathFromWithLimit(ctx, VertexIdentifier{Type: VertexTypeShoot, Name: "n0"}, VertexIdentifier{Type: VertexTypeShoot, Name: fmt.Sprintf("n%d", chainLength)}, 5)
+			Expect(err).To(MatchError(ErrSearchAborted))
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("AllPathsFrom", func() {
+		It("returns the path to every reachable vertex within maxDepth", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeNamespace, "", "a", VertexTypeShoot, "a", "b", edgeKindUnspecified),
+				newEdge(VertexTypeShoot, "a", "b", VertexTypeSeed, "", "c", edgeKindUnspecified),
+			})
+
+			paths, err := g.AllPathsFrom(ctx, VertexIdentifier{Type: VertexTypeNamespace, Name: "a"}, 5)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(paths).To(ConsistOf(
+				Path{{Type: VertexTypeNamespace, Name: "a"}, {Type: VertexTypeShoot, Namespace: "a", Name: "b"}},
+				Path{{Type: VertexTypeNamespace, Name: "a"}, {Type: VertexTypeShoot, Namespace: "a", Name: "b"}, {Type: VertexTypeSeed, Name: "c"}},
+			))
+		})
+
+		It("returns a partial result alongside ErrSearchAborted for a 10k-hop chain", func() {
+			const chainLength = 10000
+
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			for i := 0; i < chainLength; i++ {
+				g.addEdgesLocked([]edgeSpec{
+					newEdge(VertexTypeShoot, "", fmt.Sprintf("n%d", i), VertexTypeShoot, "", fmt.Sprintf("n%d", i+1), edgeKindUnspecified),
+				})
+			}
+
+			paths, err := g.AllPathsFrom(ctx, VertexIdentifier{Type: VertexTypeShoot, Name: "n0"}, 5)
+			Expect(err).To(MatchError(ErrSearchAborted))
+			Expect(paths).To(HaveLen(5))
+		})
+	})
+
+	Describe("HasPathFromWithKinds", func() {
+		It("finds a path made up exclusively of the requested kinds", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeSecretBinding, "garden", "binding", VertexTypeShoot, "garden", "shoot", EdgeKindSecretBindingShoot),
+				newEdge(VertexTypeSecret, "garden", "cloudprovider", VertexTypeSecretBinding, "garden", "binding", EdgeKindCloudProviderSecret),
+			})
+
+			Expect(g.HasPathFromWithKinds(
+				VertexTypeSecret, "garden", "cloudprovider", VertexTypeShoot, "garden", "shoot",
+				EdgeKindCloudProviderSecret, EdgeKindSecretBindingShoot,
+			)).To(BeTrue())
+		})
+
+		It("doesn't traverse an edge of a kind that wasn't requested", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeSecret, "garden", "dns", VertexTypeShoot, "garden", "shoot", EdgeKindDNSProviderSecret),
+			})
+
+			Expect(g.HasPathFromWithKinds(
+				VertexTypeSecret, "garden", "dns", VertexTypeShoot, "garden", "shoot",
+				EdgeKindCloudProviderSecret,
+			)).To(BeFalse())
+		})
+
+		It("falls back to matching any edge when no kinds are given, same as HasPathFrom", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeSecret, "garden", "dns", VertexTypeShoot, "garden", "shoot", EdgeKindDNSProviderSecret),
+			})
+
+			Expect(g.HasPathFromWithKinds(VertexTypeSecret, "garden", "dns", VertexTypeShoot, "garden", "shoot")).To(BeTrue())
+			Expect(g.HasPathFrom(VertexTypeSecret, "garden", "dns", VertexTypeShoot, "garden", "shoot")).To(BeTrue())
+		})
+
+		It("doesn't confuse two different kinds of edge between the same pair of vertices", func() {
+			g := New(logzap.New(logzap.WriteTo(io.Discard)))
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindSpecSeed),
+			})
+
+			Expect(g.HasPathFromWithKinds(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindStatusSeed)).To(BeFalse())
+
+			g.addEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindStatusSeed),
+			})
+
+			Expect(g.HasPathFromWithKinds(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindStatusSeed)).To(BeTrue())
+
+			g.removeEdgesLocked([]edgeSpec{
+				newEdge(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindStatusSeed),
+			})
+
+			Expect(g.HasPathFromWithKinds(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed", EdgeKindStatusSeed)).To(BeFalse())
+			Expect(g.HasPathFrom(VertexTypeShoot, "garden", "shoot", VertexTypeSeed, "", "seed")).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// SnapshotStore is the pluggable persistence backend for graph snapshots. Implementations may back onto a local
+// file (see FileSnapshotStore) or onto shared storage such as a Kubernetes Secret, which is required once
+// gardener-admission-controller runs with more than one replica.
+type SnapshotStore interface {
+	// Load returns a reader for the most recently saved snapshot. It returns an error satisfying
+	// errors.Is(err, os.ErrNotExist) if no snapshot has been saved yet.
+	Load(ctx context.Context) (io.ReadCloser, error)
+	// Save persists the snapshot read from r, replacing any previously saved one.
+	Save(ctx context.Context, r io.Reader) error
+}
+
+// FileSnapshotStore is a SnapshotStore backed by a single local file. It is intended for single-replica setups;
+// landscapes running gardener-admission-controller with multiple replicas should implement a SnapshotStore backed
+// by shared storage instead.
+type FileSnapshotStore struct {
+	Path string
+}
+
+// Load implements SnapshotStore.
+func (s FileSnapshotStore) Load(_ context.Context) (io.ReadCloser, error) {
+	return os.Open(s.Path)
+}
+
+// Save implements SnapshotStore. It writes to a temporary file first and renames it into place, so that a crash
+// mid-write can never leave behind a truncated snapshot.
+func (s FileSnapshotStore) Save(_ context.Context, r io.Reader) error {
+	tmp := s.Path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, s.Path)
+}
+
+// Persister periodically writes a snapshot of a graph to a SnapshotStore, and writes a final snapshot when its
+// context is cancelled, so that a restart of gardener-admission-controller can rehydrate the graph from storage
+// instead of rebuilding it from a cold informer list.
+type Persister struct {
+	Graph    *graph
+	Store    SnapshotStore
+	Interval time.Duration
+}
+
+// Start periodically persists the graph until ctx is cancelled, at which point it persists one final time. It
+// implements sigs.k8s.io/controller-runtime/pkg/manager.Runnable.
+func (p *Persister) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.snapshot(ctx); err != nil {
+				p.Graph.log.Error(err, "Failed to persist seed authorizer graph snapshot")
+			}
+		case <-ctx.Done():
+			return p.snapshot(context.Background())
+		}
+	}
+}
+
+func (p *Persister) snapshot(ctx context.Context) error {
+	var buf bytes.Buffer
+	if err := p.Graph.Snapshot(&buf); err != nil {
+		return err
+	}
+	return p.Store.Save(ctx, &buf)
+}
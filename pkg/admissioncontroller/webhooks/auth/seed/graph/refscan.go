@@ -0,0 +1,183 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+	"strings"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// referenceKind identifies the kind of object a reference discovered by scanReferences points to.
+type referenceKind string
+
+const (
+	referenceKindSecret    referenceKind = "Secret"
+	referenceKindConfigMap referenceKind = "ConfigMap"
+)
+
+func (k referenceKind) vertexType() VertexType {
+	if k == referenceKindConfigMap {
+		return VertexTypeConfigMap
+	}
+	return VertexTypeSecret
+}
+
+func (k referenceKind) edgeKind() EdgeKind {
+	if k == referenceKindConfigMap {
+		return EdgeKindResourceRefConfigMap
+	}
+	return EdgeKindResourceRefSecret
+}
+
+// reference is a (kind, namespace, name) tuple discovered by scanReferences.
+type reference struct {
+	kind      referenceKind
+	namespace string
+	name      string
+}
+
+var (
+	secretReferenceType             = reflect.TypeOf(corev1.SecretReference{})
+	objectReferenceType             = reflect.TypeOf(corev1.ObjectReference{})
+	crossVersionObjectReferenceType = reflect.TypeOf(autoscalingv1.CrossVersionObjectReference{})
+)
+
+// secretAndConfigMapEdges walks obj via reflection (see scanReferences) and returns one edge per discovered
+// Secret/ConfigMap reference, pointing from the referenced Secret/ConfigMap to the vertex identified by
+// (toType, toNamespace, toName). Unlike a hand-maintained list of known fields, this picks up new reference fields
+// (a new provider config, a new extension ProviderConfig, ...) automatically as they're added to obj's type.
+func secretAndConfigMapEdges(toType VertexType, toNamespace, toName string, obj interface{}) []edgeSpec {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	var edges []edgeSpec
+	for _, ref := range scanReferences(v, "", toNamespace) {
+		edges = append(edges, newEdge(ref.kind.vertexType(), ref.namespace, ref.name, toType, toNamespace, toName, ref.kind.edgeKind()))
+	}
+	return edges
+}
+
+// scanReferences recursively walks v, recognizing corev1.SecretReference, corev1.ObjectReference (kind Secret or
+// ConfigMap), and autoscalingv1.CrossVersionObjectReference (kind Secret or ConfigMap) wherever they occur, however
+// deeply nested. fieldName is the name of the struct field v was read from, if any; it's used as a fallback to
+// determine an corev1.ObjectReference's kind for APIs that don't bother setting Kind when it's already implied by
+// the field itself (e.g. a field named "ConfigMapRef"). defaultNamespace is used for references that don't specify
+// their own namespace, i.e. they implicitly live in the same namespace as the object being scanned.
+func scanReferences(v reflect.Value, fieldName, defaultNamespace string) []reference {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return scanReferences(v.Elem(), fieldName, defaultNamespace)
+
+	case reflect.Struct:
+		if ref, ok := referenceFromStruct(v, fieldName, defaultNamespace); ok {
+			return []reference{ref}
+		}
+
+		var refs []reference
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanInterface() {
+				continue
+			}
+			refs = append(refs, scanReferences(field, t.Field(i).Name, defaultNamespace)...)
+		}
+		return refs
+
+	case reflect.Slice, reflect.Array:
+		var refs []reference
+		for i := 0; i < v.Len(); i++ {
+			refs = append(refs, scanReferences(v.Index(i), fieldName, defaultNamespace)...)
+		}
+		return refs
+
+	case reflect.Map:
+		var refs []reference
+		for _, key := range v.MapKeys() {
+			refs = append(refs, scanReferences(v.MapIndex(key), fieldName, defaultNamespace)...)
+		}
+		return refs
+	}
+
+	return nil
+}
+
+func referenceFromStruct(v reflect.Value, fieldName, defaultNamespace string) (reference, bool) {
+	switch v.Type() {
+	case secretReferenceType:
+		ref := v.Interface().(corev1.SecretReference)
+		if ref.Name == "" {
+			return reference{}, false
+		}
+		return reference{kind: referenceKindSecret, namespace: namespaceOrDefault(ref.Namespace, defaultNamespace), name: ref.Name}, true
+
+	case objectReferenceType:
+		ref := v.Interface().(corev1.ObjectReference)
+		kind, ok := referenceKindOf(ref.Kind, fieldName)
+		if !ok || ref.Name == "" {
+			return reference{}, false
+		}
+		return reference{kind: kind, namespace: namespaceOrDefault(ref.Namespace, defaultNamespace), name: ref.Name}, true
+
+	case crossVersionObjectReferenceType:
+		ref := v.Interface().(autoscalingv1.CrossVersionObjectReference)
+		kind, ok := referenceKindOf(ref.Kind, fieldName)
+		if !ok || ref.Name == "" {
+			return reference{}, false
+		}
+		return reference{kind: kind, namespace: defaultNamespace, name: ref.Name}, true
+	}
+
+	return reference{}, false
+}
+
+// referenceKindOf resolves a reference's kind, preferring the explicit kind string (as set on
+// corev1.ObjectReference.Kind or autoscalingv1.CrossVersionObjectReference.Kind) and falling back to the
+// containing field's name for APIs that don't bother setting it when it's already implied by the field.
+func referenceKindOf(kind, fieldName string) (referenceKind, bool) {
+	switch kind {
+	case string(referenceKindSecret):
+		return referenceKindSecret, true
+	case string(referenceKindConfigMap):
+		return referenceKindConfigMap, true
+	}
+
+	switch {
+	case strings.HasSuffix(fieldName, "SecretRef"):
+		return referenceKindSecret, true
+	case strings.HasSuffix(fieldName, "ConfigMapRef"):
+		return referenceKindConfigMap, true
+	}
+
+	return "", false
+}
+
+func namespaceOrDefault(namespace, defaultNamespace string) string {
+	if namespace != "" {
+		return namespace
+	}
+	return defaultNamespace
+}
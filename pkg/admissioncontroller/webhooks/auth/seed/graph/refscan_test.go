@@ -0,0 +1,64 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// someExtensionProviderConfig stands in for a brand new spec field (e.g. a new extension's ProviderConfig) that
+// nobody has taught the graph package about yet. It's declared here, not in the production types, specifically to
+// prove that scanReferences discovers new SecretReference/ObjectReference/CrossVersionObjectReference fields without
+// any change to the graph package itself.
+type someExtensionProviderConfig struct {
+	Foo        string
+	Credential corev1.SecretReference
+	Nested     struct {
+		AuditPolicyConfigMapRef corev1.ObjectReference
+		ResourceRef             autoscalingv1.CrossVersionObjectReference
+	}
+}
+
+var _ = Describe("scanReferences", func() {
+	It("discovers a SecretReference on a field the graph package has never seen", func() {
+		obj := someExtensionProviderConfig{
+			Foo:        "bar",
+			Credential: corev1.SecretReference{Namespace: "extension-ns", Name: "extension-secret"},
+		}
+		obj.Nested.AuditPolicyConfigMapRef = corev1.ObjectReference{Name: "extension-configmap"}
+		obj.Nested.ResourceRef = autoscalingv1.CrossVersionObjectReference{Kind: "Secret", Name: "extension-resource-secret"}
+
+		refs := scanReferences(reflect.ValueOf(obj), "", "default-ns")
+
+		Expect(refs).To(ConsistOf(
+			reference{kind: referenceKindSecret, namespace: "extension-ns", name: "extension-secret"},
+			reference{kind: referenceKindConfigMap, namespace: "default-ns", name: "extension-configmap"},
+			reference{kind: referenceKindSecret, namespace: "default-ns", name: "extension-resource-secret"},
+		))
+	})
+
+	It("ignores an ObjectReference whose kind isn't Secret/ConfigMap and isn't implied by the field name", func() {
+		obj := struct {
+			SomeRef corev1.ObjectReference
+		}{SomeRef: corev1.ObjectReference{Kind: "Pod", Name: "irrelevant"}}
+
+		Expect(scanReferences(reflect.ValueOf(obj), "", "default-ns")).To(BeEmpty())
+	})
+})
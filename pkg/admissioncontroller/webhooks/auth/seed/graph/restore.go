@@ -0,0 +1,143 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetupWithRestore behaves like Setup, but first attempts to restore the graph from store. This turns the usual
+// "rebuild from a cold informer list" startup path into "rehydrate from a snapshot, then reconcile the delta",
+// which avoids readiness being blocked for the time it takes to list and replay every Shoot/Seed/etc. in a large
+// landscape. It is not an error if store has nothing to restore yet (e.g. the very first startup).
+//
+// Once the informers' initial sync has completed, vertices that were part of the restored snapshot but whose
+// object no longer exists, or whose UID no longer matches (i.e. the object was deleted and recreated while
+// gardener-admission-controller was down), are pruned from the graph.
+func (g *graph) SetupWithRestore(ctx context.Context, informerCache cache.Cache, store SnapshotStore) error {
+	if err := g.restoreFrom(ctx, store); err != nil {
+		return fmt.Errorf("failed restoring seed authorizer graph snapshot: %w", err)
+	}
+
+	if err := g.Setup(ctx, informerCache); err != nil {
+		return err
+	}
+
+	watches := []struct {
+		vertexType VertexType
+		obj        client.Object
+		list       client.ObjectList
+	}{
+		{VertexTypeSeed, &gardencorev1beta1.Seed{}, &gardencorev1beta1.SeedList{}},
+		{VertexTypeShoot, &gardencorev1beta1.Shoot{}, &gardencorev1beta1.ShootList{}},
+		{VertexTypeProject, &gardencorev1beta1.Project{}, &gardencorev1beta1.ProjectList{}},
+		{VertexTypeBackupBucket, &gardencorev1beta1.BackupBucket{}, &gardencorev1beta1.BackupBucketList{}},
+		{VertexTypeBackupEntry, &gardencorev1beta1.BackupEntry{}, &gardencorev1beta1.BackupEntryList{}},
+		{VertexTypeSecretBinding, &gardencorev1beta1.SecretBinding{}, &gardencorev1beta1.SecretBindingList{}},
+		{VertexTypeManagedSeed, &seedmanagementv1alpha1.ManagedSeed{}, &seedmanagementv1alpha1.ManagedSeedList{}},
+		{VertexTypeControllerInstallation, &gardencorev1beta1.ControllerInstallation{}, &gardencorev1beta1.ControllerInstallationList{}},
+		{VertexTypeShootState, &gardencorev1alpha1.ShootState{}, &gardencorev1alpha1.ShootStateList{}},
+		{VertexTypeBastion, &operationsv1alpha1.Bastion{}, &operationsv1alpha1.BastionList{}},
+	}
+
+	for _, w := range watches {
+		informer, err := informerCache.GetInformer(ctx, w.obj)
+		if err != nil {
+			return err
+		}
+		if !toolscache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			return fmt.Errorf("failed waiting for %T informer to sync", w.obj)
+		}
+	}
+
+	for _, w := range watches {
+		if err := g.pruneStaleVertices(ctx, informerCache, w.vertexType, w.list); err != nil {
+			return fmt.Errorf("failed reconciling restored %s vertices: %w", w.vertexType, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *graph) restoreFrom(ctx context.Context, store SnapshotStore) error {
+	if store == nil {
+		return nil
+	}
+
+	r, err := store.Load(ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer r.Close()
+
+	return g.Restore(r)
+}
+
+// pruneStaleVertices lists all live objects of the given type via informerCache and removes every vertex of that
+// type from the graph whose name/namespace isn't among them, or whose UID no longer matches. The latter catches
+// objects that were deleted and recreated while gardener-admission-controller was down, since a plain name/
+// namespace match wouldn't notice that case.
+func (g *graph) pruneStaleVertices(ctx context.Context, informerCache cache.Cache, vertexType VertexType, list client.ObjectList) error {
+	if err := informerCache.List(ctx, list); err != nil {
+		return err
+	}
+
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]vertexKey, len(items))
+	for _, item := range items {
+		accessor, err := apimeta.Accessor(item)
+		if err != nil {
+			return err
+		}
+		key := accessor.GetNamespace() + "/" + accessor.GetName()
+		live[key] = vertexKey{vertexType: vertexType, namespace: accessor.GetNamespace(), name: accessor.GetName(), uid: accessor.GetUID()}
+	}
+
+	g.write(func(s *state) {
+		for id, key := range s.ids {
+			if key.vertexType != vertexType {
+				continue
+			}
+
+			liveKey, ok := live[key.namespace+"/"+key.name]
+			if !ok || (key.uid != "" && liveKey.uid != key.uid) {
+				s.removeVertex(key, id)
+			}
+		}
+		updateNodeAndEdgeGauges(s)
+	})
+
+	return nil
+}
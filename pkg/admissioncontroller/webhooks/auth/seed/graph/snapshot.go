@@ -0,0 +1,113 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// snapshotFormatVersion is bumped whenever the wire format of snapshotEnvelope changes in an incompatible way.
+// Restore rejects snapshots written with a different version instead of guessing at a migration.
+const snapshotFormatVersion = 1
+
+type snapshotVertex struct {
+	Type      VertexType
+	Namespace string
+	Name      string
+	UID       types.UID
+}
+
+type snapshotEdge struct {
+	From int
+	To   int
+	// Kind is gob-decoded to the zero value (edgeKindUnspecified) for snapshots written before EdgeKind existed,
+	// which is a safe default: it just means HasPathFromWithKinds won't match those edges until the informers
+	// resync and re-add them with their real kind, same as any other vertex that hasn't been reconciled yet.
+	Kind EdgeKind
+}
+
+type snapshotEnvelope struct {
+	Version  int
+	Vertices []snapshotVertex
+	Edges    []snapshotEdge
+}
+
+// Snapshot serializes the current graph to w in a versioned, gob-encoded format, so that it can later be rehydrated
+// via Restore instead of being rebuilt from a cold informer list.
+func (g *graph) Snapshot(w io.Writer) error {
+	var envelope snapshotEnvelope
+
+	g.read(func(s *state) {
+		indexByID := make(map[int64]int, len(s.ids))
+		envelope = snapshotEnvelope{
+			Version:  snapshotFormatVersion,
+			Vertices: make([]snapshotVertex, 0, len(s.ids)),
+		}
+
+		for id, key := range s.ids {
+			indexByID[id] = len(envelope.Vertices)
+			envelope.Vertices = append(envelope.Vertices, snapshotVertex{
+				Type:      key.vertexType,
+				Namespace: key.namespace,
+				Name:      key.name,
+				UID:       key.uid,
+			})
+		}
+
+		for fromID, byTo := range s.edgeKinds {
+			for toID, kinds := range byTo {
+				for kind := range kinds {
+					envelope.Edges = append(envelope.Edges, snapshotEdge{From: indexByID[fromID], To: indexByID[toID], Kind: kind})
+				}
+			}
+		}
+	})
+
+	return gob.NewEncoder(w).Encode(envelope)
+}
+
+// Restore replaces the graph's contents with the vertices and edges decoded from r, which must have been produced
+// by Snapshot. It is meant to be called once, before the informers registered by Setup start delivering events.
+func (g *graph) Restore(r io.Reader) error {
+	var envelope snapshotEnvelope
+	if err := gob.NewDecoder(r).Decode(&envelope); err != nil {
+		return err
+	}
+	if envelope.Version != snapshotFormatVersion {
+		return fmt.Errorf("unsupported seed authorizer graph snapshot format version %d (expected %d)", envelope.Version, snapshotFormatVersion)
+	}
+
+	g.write(func(s *state) {
+		ids := make([]int64, len(envelope.Vertices))
+		for i, v := range envelope.Vertices {
+			id := s.getOrCreateVertex(v.Type, v.Namespace, v.Name)
+			s.ids[id] = vertexKey{vertexType: v.Type, namespace: v.Namespace, name: v.Name, uid: v.UID}
+			ids[i] = id
+		}
+		for _, e := range envelope.Edges {
+			s.graph.SetEdge(simple.Edge{F: simple.Node(ids[e.From]), T: simple.Node(ids[e.To])})
+			s.addEdgeKind(ids[e.From], ids[e.To], e.Kind)
+		}
+
+		updateNodeAndEdgeGauges(s)
+	})
+
+	return nil
+}
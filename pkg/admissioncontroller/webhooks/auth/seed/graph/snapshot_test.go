@@ -0,0 +1,144 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	logzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var _ = Describe("snapshot and restore", func() {
+	var (
+		ctx = context.TODO()
+
+		seed1          *gardencorev1beta1.Seed
+		seed1SecretRef = corev1.SecretReference{Namespace: "foo", Name: "bar"}
+
+		backupBucket1          *gardencorev1beta1.BackupBucket
+		backupBucket1SecretRef = corev1.SecretReference{Namespace: "baz", Name: "foo"}
+
+		backupEntry1 *gardencorev1beta1.BackupEntry
+
+		secretBinding1          *gardencorev1beta1.SecretBinding
+		secretBinding1SecretRef = corev1.SecretReference{Namespace: "foobar", Name: "bazfoo"}
+
+		source *graph
+	)
+
+	BeforeEach(func() {
+		scheme := kubernetes.GardenScheme
+		Expect(metav1.AddMetaToScheme(scheme)).To(Succeed())
+
+		fakeInformerSeed := &controllertest.FakeInformer{}
+		fakeInformerBackupBucket := &controllertest.FakeInformer{}
+		fakeInformerBackupEntry := &controllertest.FakeInformer{}
+		fakeInformerSecretBinding := &controllertest.FakeInformer{}
+
+		fakeInformers := &informertest.FakeInformers{
+			Scheme: scheme,
+			InformersByGVK: map[schema.GroupVersionKind]toolscache.SharedIndexInformer{
+				gardencorev1beta1.SchemeGroupVersion.WithKind("Seed"):          fakeInformerSeed,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupBucket"):  fakeInformerBackupBucket,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("BackupEntry"):   fakeInformerBackupEntry,
+				gardencorev1beta1.SchemeGroupVersion.WithKind("SecretBinding"): fakeInformerSecretBinding,
+			},
+		}
+
+		source = New(logzap.New(logzap.WriteTo(GinkgoWriter)))
+		Expect(source.Setup(ctx, fakeInformers)).To(Succeed())
+
+		seed1 = &gardencorev1beta1.Seed{
+			ObjectMeta: metav1.ObjectMeta{Name: "seed1", UID: "seed1-uid"},
+			Spec: gardencorev1beta1.SeedSpec{
+				SecretRef: &seed1SecretRef,
+			},
+		}
+		fakeInformerSeed.Add(seed1)
+
+		backupBucket1 = &gardencorev1beta1.BackupBucket{
+			ObjectMeta: metav1.ObjectMeta{Name: "backupbucket1", UID: "backupbucket1-uid"},
+			Spec: gardencorev1beta1.BackupBucketSpec{
+				SecretRef: backupBucket1SecretRef,
+				SeedName:  &seed1.Name,
+			},
+		}
+		fakeInformerBackupBucket.Add(backupBucket1)
+
+		backupEntry1 = &gardencorev1beta1.BackupEntry{
+			ObjectMeta: metav1.ObjectMeta{Name: "backupentry1", Namespace: "backupentry1namespace", UID: "backupentry1-uid"},
+			Spec: gardencorev1beta1.BackupEntrySpec{
+				BucketName: backupBucket1.Name,
+				SeedName:   &seed1.Name,
+			},
+		}
+		fakeInformerBackupEntry.Add(backupEntry1)
+
+		secretBinding1 = &gardencorev1beta1.SecretBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "secretbinding1", Namespace: "sb1namespace", UID: "secretbinding1-uid"},
+			SecretRef:  secretBinding1SecretRef,
+		}
+		fakeInformerSecretBinding.Add(secretBinding1)
+	})
+
+	It("restores a snapshot of a populated graph into a fresh graph with identical HasPathFrom results", func() {
+		var buf bytes.Buffer
+		Expect(source.Snapshot(&buf)).To(Succeed())
+
+		restored := New(logzap.New(logzap.WriteTo(io.Discard)))
+		Expect(restored.Restore(&buf)).To(Succeed())
+
+		checks := []struct {
+			fromType                VertexType
+			fromNamespace, fromName string
+			toType                  VertexType
+			toNamespace, toName     string
+		}{
+			{VertexTypeSecret, seed1SecretRef.Namespace, seed1SecretRef.Name, VertexTypeSeed, "", seed1.Name},
+			{VertexTypeSecret, backupBucket1SecretRef.Namespace, backupBucket1SecretRef.Name, VertexTypeBackupBucket, "", backupBucket1.Name},
+			{VertexTypeBackupBucket, "", backupBucket1.Name, VertexTypeSeed, "", seed1.Name},
+			{VertexTypeBackupEntry, backupEntry1.Namespace, backupEntry1.Name, VertexTypeBackupBucket, "", backupEntry1.Spec.BucketName},
+			{VertexTypeBackupEntry, backupEntry1.Namespace, backupEntry1.Name, VertexTypeSeed, "", *backupEntry1.Spec.SeedName},
+			{VertexTypeSecret, secretBinding1SecretRef.Namespace, secretBinding1SecretRef.Name, VertexTypeSecretBinding, secretBinding1.Namespace, secretBinding1.Name},
+		}
+
+		for _, c := range checks {
+			Expect(restored.HasPathFrom(c.fromType, c.fromNamespace, c.fromName, c.toType, c.toNamespace, c.toName)).
+				To(Equal(source.HasPathFrom(c.fromType, c.fromNamespace, c.fromName, c.toType, c.toNamespace, c.toName)))
+		}
+
+		Expect(restored.HasPathFromWithKinds(
+			VertexTypeSecret, secretBinding1SecretRef.Namespace, secretBinding1SecretRef.Name,
+			VertexTypeSecretBinding, secretBinding1.Namespace, secretBinding1.Name,
+			EdgeKindCloudProviderSecret,
+		)).To(BeTrue())
+
+		Expect(restored.graph.Nodes().Len()).To(Equal(source.graph.Nodes().Len()))
+		Expect(restored.graph.Edges().Len()).To(Equal(source.graph.Edges().Len()))
+	})
+})
@@ -0,0 +1,384 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"context"
+
+	"gonum.org/v1/gonum/graph/simple"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// state holds the actual graph data. All read/write access is mediated by graph.read/graph.write, which apply the
+// locking or copy-on-write discipline appropriate for the graph's ConcurrencyStrategy - state itself assumes the
+// caller already has exclusive (for mutation) or stable (for reading) access.
+type state struct {
+	graph    *simple.DirectedGraph
+	vertices map[VertexType]map[string]map[string]int64
+	ids      map[int64]vertexKey
+	nextID   int64
+
+	// edgeKinds records, for every (from, to) pair with at least one edge, the set of EdgeKinds it exists for.
+	// gonum's simple.DirectedGraph has no concept of edge metadata, so this is tracked alongside it rather than on
+	// the edge itself. More than one kind can apply to the same pair, e.g. two different ShootSpec fields both
+	// referencing the same Secret.
+	edgeKinds map[int64]map[int64]map[EdgeKind]struct{}
+}
+
+func newState() *state {
+	return &state{
+		graph:     simple.NewDirectedGraph(),
+		vertices:  make(map[VertexType]map[string]map[string]int64),
+		ids:       make(map[int64]vertexKey),
+		edgeKinds: make(map[int64]map[int64]map[EdgeKind]struct{}),
+	}
+}
+
+// clone returns a deep copy of s. It is only used by the RCU concurrency strategy, where writers mutate a private
+// clone and publish it atomically once done, leaving concurrent readers of the previous state undisturbed.
+func (s *state) clone() *state {
+	out := &state{
+		graph:     simple.NewDirectedGraph(),
+		vertices:  make(map[VertexType]map[string]map[string]int64, len(s.vertices)),
+		ids:       make(map[int64]vertexKey, len(s.ids)),
+		nextID:    s.nextID,
+		edgeKinds: make(map[int64]map[int64]map[EdgeKind]struct{}, len(s.edgeKinds)),
+	}
+
+	for id, key := range s.ids {
+		out.graph.AddNode(simple.Node(id))
+		out.ids[id] = key
+	}
+	for vertexType, byNamespace := range s.vertices {
+		namespaces := make(map[string]map[string]int64, len(byNamespace))
+		for namespace, byName := range byNamespace {
+			names := make(map[string]int64, len(byName))
+			for name, id := range byName {
+				names[name] = id
+			}
+			namespaces[namespace] = names
+		}
+		out.vertices[vertexType] = namespaces
+	}
+
+	edges := s.graph.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		out.graph.SetEdge(simple.Edge{F: simple.Node(e.From().ID()), T: simple.Node(e.To().ID())})
+	}
+	for fromID, byTo := range s.edgeKinds {
+		kinds := make(map[int64]map[EdgeKind]struct{}, len(byTo))
+		for toID, kindSet := range byTo {
+			kinds[toID] = copyEdgeKindSet(kindSet)
+		}
+		out.edgeKinds[fromID] = kinds
+	}
+
+	return out
+}
+
+func copyEdgeKindSet(kindSet map[EdgeKind]struct{}) map[EdgeKind]struct{} {
+	out := make(map[EdgeKind]struct{}, len(kindSet))
+	for kind := range kindSet {
+		out[kind] = struct{}{}
+	}
+	return out
+}
+
+func (s *state) lookup(vertexType VertexType, namespace, name string) (int64, bool) {
+	byNamespace, ok := s.vertices[vertexType]
+	if !ok {
+		return 0, false
+	}
+	id, ok := byNamespace[namespace][name]
+	return id, ok
+}
+
+func (s *state) getOrCreateVertex(vertexType VertexType, namespace, name string) int64 {
+	if id, ok := s.lookup(vertexType, namespace, name); ok {
+		return id
+	}
+
+	id := s.nextID
+	s.nextID++
+
+	s.graph.AddNode(simple.Node(id))
+
+	if _, ok := s.vertices[vertexType]; !ok {
+		s.vertices[vertexType] = make(map[string]map[string]int64)
+	}
+	if _, ok := s.vertices[vertexType][namespace]; !ok {
+		s.vertices[vertexType][namespace] = make(map[string]int64)
+	}
+	s.vertices[vertexType][namespace][name] = id
+	s.ids[id] = vertexKey{vertexType: vertexType, namespace: namespace, name: name}
+
+	return id
+}
+
+func (s *state) setVertexUID(vertexType VertexType, namespace, name string, uid types.UID) {
+	id, ok := s.lookup(vertexType, namespace, name)
+	if !ok {
+		return
+	}
+	s.ids[id] = vertexKey{vertexType: vertexType, namespace: namespace, name: name, uid: uid}
+}
+
+func (s *state) removeVertex(key vertexKey, id int64) {
+	s.graph.RemoveNode(id)
+	delete(s.vertices[key.vertexType][key.namespace], key.name)
+	delete(s.ids, id)
+}
+
+// gcIfIsolated removes the vertex identified by key/id if it no longer participates in any edge. This keeps the
+// graph from growing unboundedly with stale vertices once the edges that referenced them are gone.
+func (s *state) gcIfIsolated(key vertexKey, id int64) {
+	if s.graph.From(id).Len() == 0 && s.graph.To(id).Len() == 0 {
+		s.removeVertex(key, id)
+	}
+}
+
+func (s *state) addEdges(edges []edgeSpec) {
+	for _, e := range edges {
+		fromID := s.getOrCreateVertex(e.from.vertexType, e.from.namespace, e.from.name)
+		toID := s.getOrCreateVertex(e.to.vertexType, e.to.namespace, e.to.name)
+		s.graph.SetEdge(simple.Edge{F: simple.Node(fromID), T: simple.Node(toID)})
+		s.addEdgeKind(fromID, toID, e.kind)
+	}
+}
+
+func (s *state) removeEdges(edges []edgeSpec) {
+	for _, e := range edges {
+		fromID, fromOK := s.lookup(e.from.vertexType, e.from.namespace, e.from.name)
+		toID, toOK := s.lookup(e.to.vertexType, e.to.namespace, e.to.name)
+		if !fromOK || !toOK {
+			continue
+		}
+
+		s.removeEdgeKind(fromID, toID, e.kind)
+		if len(s.edgeKinds[fromID][toID]) == 0 {
+			s.graph.RemoveEdge(fromID, toID)
+		}
+		s.gcIfIsolated(e.from, fromID)
+		s.gcIfIsolated(e.to, toID)
+	}
+}
+
+// addEdgeKind records that an edge of the given kind exists between fromID and toID. edgeKindUnspecified is
+// recorded like any other kind, so that an untyped edge's removal (see removeEdgeKind) correctly brings the
+// (fromID, toID) pair's kind set back to empty once it's the only kind left.
+func (s *state) addEdgeKind(fromID, toID int64, kind EdgeKind) {
+	if s.edgeKinds[fromID] == nil {
+		s.edgeKinds[fromID] = make(map[int64]map[EdgeKind]struct{})
+	}
+	if s.edgeKinds[fromID][toID] == nil {
+		s.edgeKinds[fromID][toID] = make(map[EdgeKind]struct{})
+	}
+	s.edgeKinds[fromID][toID][kind] = struct{}{}
+}
+
+func (s *state) removeEdgeKind(fromID, toID int64, kind EdgeKind) {
+	kindSet := s.edgeKinds[fromID][toID]
+	delete(kindSet, kind)
+	if len(kindSet) == 0 {
+		delete(s.edgeKinds[fromID], toID)
+		if len(s.edgeKinds[fromID]) == 0 {
+			delete(s.edgeKinds, fromID)
+		}
+	}
+}
+
+// edgeHasAnyKind returns whether the edge from fromID to toID exists for at least one of the given kinds. An empty
+// kinds set matches any edge, which is what makes hasPathFrom ("any-kind") a special case of hasPathFromWithKinds.
+func (s *state) edgeHasAnyKind(fromID, toID int64, kinds map[EdgeKind]struct{}) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for kind := range s.edgeKinds[fromID][toID] {
+		if _, ok := kinds[kind]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPathFrom returns true if there is a directed path, made up of edges of any kind, from the vertex identified by
+// (fromType, fromNamespace, fromName) to the vertex identified by (toType, toNamespace, toName).
+func (s *state) hasPathFrom(fromType VertexType, fromNamespace, fromName string, toType VertexType, toNamespace, toName string) bool {
+	return s.hasPathFromWithKinds(fromType, fromNamespace, fromName, toType, toNamespace, toName, nil)
+}
+
+// hasPathFromWithKinds behaves like hasPathFrom, but only traverses edges whose kind is in kinds. A nil or empty
+// kinds set matches any edge, same as hasPathFrom.
+func (s *state) hasPathFromWithKinds(fromType VertexType, fromNamespace, fromName string, toType VertexType, toNamespace, toName string, kinds map[EdgeKind]struct{}) bool {
+	fromID, ok := s.lookup(fromType, fromNamespace, fromName)
+	if !ok {
+		return false
+	}
+	toID, ok := s.lookup(toType, toNamespace, toName)
+	if !ok {
+		return false
+	}
+	if fromID == toID {
+		return true
+	}
+
+	visited := map[int64]bool{fromID: true}
+	queue := []int64{fromID}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		neighbors := s.graph.From(current)
+		for neighbors.Next() {
+			next := neighbors.Node().ID()
+			if !s.edgeHasAnyKind(current, next, kinds) {
+				continue
+			}
+			if next == toID {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return false
+}
+
+// bfsQueueItem is a node queued for traversal together with its distance, in hops, from the search's starting
+// vertex.
+type bfsQueueItem struct {
+	id    int64
+	depth int
+}
+
+// hasPathFromWithLimit behaves like hasPathFrom, but aborts with ErrSearchAborted rather than exploring past
+// maxDepth hops or visiting more than maxVisited nodes, and rather than running once ctx is done. Because a search
+// that's cut short cannot prove the absence of a path, it never reports "not found" in that case - only a
+// genuinely exhausted search does.
+func (s *state) hasPathFromWithLimit(ctx context.Context, fromID, toID int64, maxDepth, maxVisited int) (bool, error) {
+	if fromID == toID {
+		return true, nil
+	}
+
+	visited := map[int64]struct{}{fromID: {}}
+	queue := []bfsQueueItem{{id: fromID, depth: 0}}
+	truncated := false
+
+	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			return false, ErrSearchAborted
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		neighbors := s.graph.From(current.id)
+		for neighbors.Next() {
+			next := neighbors.Node().ID()
+			if _, ok := visited[next]; ok {
+				continue
+			}
+			if current.depth+1 > maxDepth {
+				truncated = true
+				continue
+			}
+			if next == toID {
+				return true, nil
+			}
+			if len(visited) >= maxVisited {
+				return false, ErrSearchAborted
+			}
+
+			visited[next] = struct{}{}
+			queue = append(queue, bfsQueueItem{id: next, depth: current.depth + 1})
+		}
+	}
+
+	if truncated {
+		return false, ErrSearchAborted
+	}
+	return false, nil
+}
+
+// allPathsFrom returns the shortest path (by hop count) from fromID to every node reachable within maxDepth hops
+// and maxVisited node visits. If the search is cut short, whatever was already discovered is returned alongside
+// ErrSearchAborted.
+func (s *state) allPathsFrom(ctx context.Context, fromID int64, maxDepth, maxVisited int) ([]Path, error) {
+	predecessorPath := map[int64][]int64{fromID: nil}
+	queue := []bfsQueueItem{{id: fromID, depth: 0}}
+	truncated := false
+
+	for len(queue) > 0 {
+		if ctx.Err() != nil {
+			return s.toPaths(predecessorPath), ErrSearchAborted
+		}
+
+		current := queue[0]
+		queue = queue[1:]
+
+		neighbors := s.graph.From(current.id)
+		for neighbors.Next() {
+			next := neighbors.Node().ID()
+			if _, ok := predecessorPath[next]; ok {
+				continue
+			}
+			if current.depth+1 > maxDepth {
+				truncated = true
+				continue
+			}
+			if len(predecessorPath) >= maxVisited {
+				return s.toPaths(predecessorPath), ErrSearchAborted
+			}
+
+			path := make([]int64, 0, len(predecessorPath[current.id])+1)
+			path = append(path, predecessorPath[current.id]...)
+			path = append(path, current.id)
+			predecessorPath[next] = path
+
+			queue = append(queue, bfsQueueItem{id: next, depth: current.depth + 1})
+		}
+	}
+
+	if truncated {
+		return s.toPaths(predecessorPath), ErrSearchAborted
+	}
+	return s.toPaths(predecessorPath), nil
+}
+
+// toPaths renders predecessorPath (as built up by allPathsFrom, mapping a node id to the id sequence leading to it,
+// exclusive of the node itself) into the public Path representation, skipping the search's own starting vertex.
+func (s *state) toPaths(predecessorPath map[int64][]int64) []Path {
+	paths := make([]Path, 0, len(predecessorPath))
+
+	for id, prefix := range predecessorPath {
+		if len(prefix) == 0 {
+			continue
+		}
+
+		path := make(Path, 0, len(prefix)+1)
+		for _, pid := range append(prefix, id) {
+			key := s.ids[pid]
+			path = append(path, VertexIdentifier{Type: key.vertexType, Namespace: key.namespace, Name: key.name})
+		}
+		paths = append(paths, path)
+	}
+
+	return paths
+}
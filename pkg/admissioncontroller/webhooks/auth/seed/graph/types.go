@@ -0,0 +1,191 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import "k8s.io/apimachinery/pkg/types"
+
+// VertexType is the type of an object represented as a vertex in the graph.
+type VertexType byte
+
+const (
+	// VertexTypeNamespace is the vertex type for core/v1.Namespace objects.
+	VertexTypeNamespace VertexType = iota
+	// VertexTypeProject is the vertex type for gardencorev1beta1.Project objects.
+	VertexTypeProject
+	// VertexTypeShoot is the vertex type for gardencorev1beta1.Shoot objects.
+	VertexTypeShoot
+	// VertexTypeSeed is the vertex type for gardencorev1beta1.Seed objects.
+	VertexTypeSeed
+	// VertexTypeCloudProfile is the vertex type for gardencorev1beta1.CloudProfile objects.
+	VertexTypeCloudProfile
+	// VertexTypeSecretBinding is the vertex type for gardencorev1beta1.SecretBinding objects.
+	VertexTypeSecretBinding
+	// VertexTypeSecret is the vertex type for core/v1.Secret objects.
+	VertexTypeSecret
+	// VertexTypeConfigMap is the vertex type for core/v1.ConfigMap objects.
+	VertexTypeConfigMap
+	// VertexTypeBackupBucket is the vertex type for gardencorev1beta1.BackupBucket objects.
+	VertexTypeBackupBucket
+	// VertexTypeBackupEntry is the vertex type for gardencorev1beta1.BackupEntry objects.
+	VertexTypeBackupEntry
+	// VertexTypeManagedSeed is the vertex type for seedmanagementv1alpha1.ManagedSeed objects.
+	VertexTypeManagedSeed
+	// VertexTypeControllerInstallation is the vertex type for gardencorev1beta1.ControllerInstallation objects.
+	VertexTypeControllerInstallation
+	// VertexTypeExposureClass is the vertex type for gardencorev1beta1.ExposureClass objects.
+	VertexTypeExposureClass
+	// VertexTypeControllerRegistration is the vertex type for gardencorev1beta1.ControllerRegistration objects.
+	VertexTypeControllerRegistration
+	// VertexTypeControllerDeployment is the vertex type for gardencorev1beta1.ControllerDeployment objects.
+	VertexTypeControllerDeployment
+	// VertexTypeShootState is the vertex type for gardencorev1alpha1.ShootState objects.
+	VertexTypeShootState
+	// VertexTypeBastion is the vertex type for operationsv1alpha1.Bastion objects.
+	VertexTypeBastion
+)
+
+// String returns the human-readable name of the VertexType, used for logging, metrics labels and the DOT export.
+func (t VertexType) String() string {
+	switch t {
+	case VertexTypeNamespace:
+		return "Namespace"
+	case VertexTypeProject:
+		return "Project"
+	case VertexTypeShoot:
+		return "Shoot"
+	case VertexTypeSeed:
+		return "Seed"
+	case VertexTypeCloudProfile:
+		return "CloudProfile"
+	case VertexTypeSecretBinding:
+		return "SecretBinding"
+	case VertexTypeSecret:
+		return "Secret"
+	case VertexTypeConfigMap:
+		return "ConfigMap"
+	case VertexTypeBackupBucket:
+		return "BackupBucket"
+	case VertexTypeBackupEntry:
+		return "BackupEntry"
+	case VertexTypeManagedSeed:
+		return "ManagedSeed"
+	case VertexTypeControllerInstallation:
+		return "ControllerInstallation"
+	case VertexTypeExposureClass:
+		return "ExposureClass"
+	case VertexTypeControllerRegistration:
+		return "ControllerRegistration"
+	case VertexTypeControllerDeployment:
+		return "ControllerDeployment"
+	case VertexTypeShootState:
+		return "ShootState"
+	case VertexTypeBastion:
+		return "Bastion"
+	default:
+		return "Unknown"
+	}
+}
+
+// vertexKey uniquely identifies a vertex within the graph. uid additionally records the UID of the object the
+// vertex was last created or updated for, if known (e.g. not set for vertices that only exist because something
+// else references them, such as a Secret that has never been watched directly). It is used to detect, after
+// restoring a graph snapshot, whether an object was deleted and recreated while the graph wasn't being updated.
+type vertexKey struct {
+	vertexType VertexType
+	namespace  string
+	name       string
+	uid        types.UID
+}
+
+// EdgeKind records the specific reason an edge exists, e.g. "this Secret is a Shoot's cloudprovider credential" as
+// opposed to "this Secret is merely referenced somewhere in the Shoot spec". It lets HasPathFromWithKinds ask a much
+// narrower question than HasPathFrom: not just "is there a path", but "is there a path made up of edges that mean
+// what I expect them to mean". A Shoot->Seed edge and a BackupEntry->Seed edge both make "something reaches this
+// Seed" true, but a caller deciding whether a gardenlet may read a specific Secret cares which one it is.
+type EdgeKind string
+
+const (
+	// edgeKindUnspecified is used by edges that don't carry a meaningful kind, e.g. in tests that only care about
+	// graph connectivity. HasPathFromWithKinds never matches it on purpose, since "no kind" can't be what a caller
+	// asking for a specific kind meant.
+	edgeKindUnspecified EdgeKind = ""
+
+	// EdgeKindNamespaceShoot is the edge kind for the Namespace a Shoot lives in.
+	EdgeKindNamespaceShoot EdgeKind = "NamespaceShoot"
+	// EdgeKindCloudProfileShoot is the edge kind for the CloudProfile a Shoot was created with.
+	EdgeKindCloudProfileShoot EdgeKind = "CloudProfileShoot"
+	// EdgeKindExposureClassShoot is the edge kind for the ExposureClass a Shoot was created with.
+	EdgeKindExposureClassShoot EdgeKind = "ExposureClassShoot"
+	// EdgeKindSecretBindingShoot is the edge kind for the SecretBinding a Shoot references.
+	EdgeKindSecretBindingShoot EdgeKind = "SecretBindingShoot"
+	// EdgeKindDNSProviderSecret is the edge kind for a Secret referenced by one of a Shoot's DNS providers.
+	EdgeKindDNSProviderSecret EdgeKind = "DNSProviderSecret"
+	// EdgeKindResourceRefSecret is the edge kind for a Secret discovered by scanning a Shoot's spec for
+	// SecretReference/ObjectReference/CrossVersionObjectReference fields (see secretAndConfigMapEdges).
+	EdgeKindResourceRefSecret EdgeKind = "ResourceRefSecret"
+	// EdgeKindResourceRefConfigMap is the edge kind for a ConfigMap discovered the same way as
+	// EdgeKindResourceRefSecret, e.g. a Shoot's audit policy ConfigMap.
+	EdgeKindResourceRefConfigMap EdgeKind = "ResourceRefConfigMap"
+	// EdgeKindSpecSeed is the edge kind for the Seed a Shoot is scheduled to, per spec.seedName.
+	EdgeKindSpecSeed EdgeKind = "SpecSeed"
+	// EdgeKindStatusSeed is the edge kind for the Seed a Shoot is running on, per status.seedName. This can differ
+	// from EdgeKindSpecSeed while a control plane migration is in progress.
+	EdgeKindStatusSeed EdgeKind = "StatusSeed"
+	// EdgeKindProjectNamespace is the edge kind for the Namespace a Project owns.
+	EdgeKindProjectNamespace EdgeKind = "ProjectNamespace"
+	// EdgeKindSeedSecret is the edge kind for the Secret holding the credentials to a Seed's Kubernetes cluster.
+	EdgeKindSeedSecret EdgeKind = "SeedSecret"
+	// EdgeKindBackupSecret is the edge kind for the Secret holding the credentials to a Seed's or BackupBucket's
+	// backup infrastructure.
+	EdgeKindBackupSecret EdgeKind = "BackupSecret"
+	// EdgeKindBackupBucketSeed is the edge kind for the Seed a BackupBucket belongs to.
+	EdgeKindBackupBucketSeed EdgeKind = "BackupBucketSeed"
+	// EdgeKindBackupEntryBucket is the edge kind for the BackupBucket a BackupEntry belongs to.
+	EdgeKindBackupEntryBucket EdgeKind = "BackupEntryBucket"
+	// EdgeKindBackupEntrySeed is the edge kind for the Seed a BackupEntry belongs to.
+	EdgeKindBackupEntrySeed EdgeKind = "BackupEntrySeed"
+	// EdgeKindCloudProviderSecret is the edge kind for the Secret a SecretBinding references, i.e. the cloud
+	// provider credential a Shoot is ultimately created with.
+	EdgeKindCloudProviderSecret EdgeKind = "CloudProviderSecret"
+	// EdgeKindManagedSeedSeed is the edge kind for the Seed a ManagedSeed registers.
+	EdgeKindManagedSeedSeed EdgeKind = "ManagedSeedSeed"
+	// EdgeKindManagedSeedShoot is the edge kind for the Shoot a ManagedSeed seeds from.
+	EdgeKindManagedSeedShoot EdgeKind = "ManagedSeedShoot"
+	// EdgeKindControllerInstallationSeed is the edge kind for the Seed a ControllerInstallation is installed to.
+	EdgeKindControllerInstallationSeed EdgeKind = "ControllerInstallationSeed"
+	// EdgeKindControllerInstallationControllerRegistration is the edge kind for the ControllerRegistration a
+	// ControllerInstallation installs.
+	EdgeKindControllerInstallationControllerRegistration EdgeKind = "ControllerInstallationControllerRegistration"
+	// EdgeKindControllerInstallationControllerDeployment is the edge kind for the ControllerDeployment a
+	// ControllerInstallation installs.
+	EdgeKindControllerInstallationControllerDeployment EdgeKind = "ControllerInstallationControllerDeployment"
+	// EdgeKindShootStateShoot is the edge kind for the Shoot a ShootState persists extension state for.
+	EdgeKindShootStateShoot EdgeKind = "ShootStateShoot"
+	// EdgeKindBastionShoot is the edge kind for the Shoot a Bastion was created for.
+	EdgeKindBastionShoot EdgeKind = "BastionShoot"
+	// EdgeKindBastionSeed is the edge kind for the Seed a Bastion's Shoot runs on.
+	EdgeKindBastionSeed EdgeKind = "BastionSeed"
+	// EdgeKindBastionSecret is the edge kind for the Secret holding a Bastion's SSH keypair.
+	EdgeKindBastionSecret EdgeKind = "BastionSecret"
+)
+
+// edgeSpec describes a directed edge between two (possibly not yet existing) vertices. kind records why the edge
+// exists, so that HasPathFromWithKinds can restrict a traversal to edges that mean what a caller expects them to
+// mean; it may be left as the zero value for edges that HasPathFromWithKinds should never match on purpose.
+type edgeSpec struct {
+	from vertexKey
+	to   vertexKey
+	kind EdgeKind
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// anySeed is a sentinel SeedNameFor may include in its returned owners to indicate that the object is not scoped to
+// any particular seed and every seed caller is allowed (e.g. the shared leader-election Lease).
+const anySeed = "*"
+
+// ErrNotScopable is returned by a Restrictor's SeedNameFor when attrs cannot be resolved to a single object, e.g. an
+// unnamed list/watch request. Decide treats it the same as a NotFound error (see apierrors.IsNotFound): NoOpinion,
+// never Denied.
+var ErrNotScopable = errors.New("request cannot be scoped to a single seed")
+
+// Restrictor decides which seed(s) are allowed to get/list/watch/patch a particular kind of seed-scoped object, so
+// that Decide does not need a dedicated case for every resource kind. Register a Restrictor with a Registry; Decide
+// dispatches to whichever Restrictor is registered for a request's GroupVersionResource. Adding support for a new
+// seed-scoped resource (e.g. InternalSecrets, ExposureClasses, per-seed ConfigMaps) only requires implementing this
+// interface and registering it - Decide itself never changes.
+type Restrictor interface {
+	// Resource identifies the GroupVersionResource this Restrictor decides ownership for.
+	Resource() schema.GroupVersionResource
+	// AllowedVerbs is the set of verbs this Restrictor ever expresses an opinion about; any other verb is left to
+	// Decide's default NoOpinion.
+	AllowedVerbs() sets.String
+	// SeedNameFor returns the name(s) of the seed(s) allowed to access the object identified by attrs - almost
+	// always exactly one. Returns ErrNotScopable if the request cannot be narrowed to a single object (e.g. attrs.Name
+	// is empty), or a NotFound error (see apierrors.IsNotFound) if the object does not exist; Decide treats both as
+	// NoOpinion rather than Denied.
+	SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, reader client.Reader) ([]string, error)
+}
+
+// Registry holds the Restrictors Decide dispatches through, keyed by the GroupVersionResource each one declares via
+// Resource(). It is safe for concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	restrictors map[schema.GroupVersionResource]Restrictor
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{restrictors: map[schema.GroupVersionResource]Restrictor{}}
+}
+
+// Register adds restrictor to the Registry, replacing any Restrictor previously registered for the same Resource().
+func (r *Registry) Register(restrictor Restrictor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.restrictors[restrictor.Resource()] = restrictor
+}
+
+// Get returns the Restrictor registered for gvr, if any.
+func (r *Registry) Get(gvr schema.GroupVersionResource) (Restrictor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	restrictor, ok := r.restrictors[gvr]
+	return restrictor, ok
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *Registry
+
+	BeforeEach(func() {
+		registry = NewRegistry()
+	})
+
+	It("should not find a Restrictor that was never registered", func() {
+		_, ok := registry.Get(schema.GroupVersionResource{Resource: "widgets"})
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should find a registered Restrictor by its GroupVersionResource", func() {
+		registry.Register(widgetRestrictor{})
+
+		restrictor, ok := registry.Get(schema.GroupVersionResource{Group: "example.com", Resource: "widgets"})
+		Expect(ok).To(BeTrue())
+		Expect(restrictor).To(Equal(widgetRestrictor{}))
+	})
+
+	It("should replace a previously registered Restrictor for the same GroupVersionResource", func() {
+		registry.Register(leaseRestrictor{})
+		registry.Register(leaseRestrictor{})
+
+		_, ok := registry.Get(schema.GroupVersionResource{Group: "coordination.k8s.io", Resource: "leases"})
+		Expect(ok).To(BeTrue())
+	})
+})
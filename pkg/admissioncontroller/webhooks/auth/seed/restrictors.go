@@ -0,0 +1,193 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"context"
+
+	"github.com/gardener/gardener/pkg/admissioncontroller/webhooks/auth/seed/seedauthorizer"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readWriteVerbs is the AllowedVerbs() of every built-in Restrictor; they all follow the same get/list/watch/patch
+// convention as the seedrestriction admission webhook.
+var readWriteVerbs = sets.NewString("get", "list", "watch", "patch")
+
+type shootRestrictor struct{ resolver *seedauthorizer.Resolver }
+
+func (shootRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "shoots"}
+}
+
+func (shootRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (r shootRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, _ client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	seedName, err := r.resolver.SeedNameOfShoot(ctx, attrs.Namespace, attrs.Name)
+	if err != nil {
+		return nil, err
+	}
+	if seedName == nil {
+		return nil, nil
+	}
+	return []string{*seedName}, nil
+}
+
+type shootStateRestrictor struct{ resolver *seedauthorizer.Resolver }
+
+func (shootStateRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "shootstates"}
+}
+
+func (shootStateRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (r shootStateRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, _ client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	// A ShootState shares its name and namespace with the Shoot it belongs to.
+	seedName, err := r.resolver.SeedNameOfShoot(ctx, attrs.Namespace, attrs.Name)
+	if err != nil {
+		return nil, err
+	}
+	if seedName == nil {
+		return nil, nil
+	}
+	return []string{*seedName}, nil
+}
+
+type seedRestrictor struct{ resolver *seedauthorizer.Resolver }
+
+func (seedRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "seeds"}
+}
+
+func (seedRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (r seedRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, _ client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	// A seed is always allowed to access its own Seed object, in addition to whichever seed is hosting the Shoot
+	// that backs its ManagedSeed (if any) while it is being bootstrapped.
+	owners := []string{attrs.Name}
+
+	managedSeedShootSeedName, err := r.resolver.SeedNameOfManagedSeedShoot(ctx, attrs.Name)
+	if err != nil {
+		return nil, err
+	}
+	if managedSeedShootSeedName != nil {
+		owners = append(owners, *managedSeedShootSeedName)
+	}
+	return owners, nil
+}
+
+type backupBucketRestrictor struct{}
+
+func (backupBucketRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupbuckets"}
+}
+
+func (backupBucketRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (backupBucketRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, reader client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	backupBucket := &gardencorev1beta1.BackupBucket{}
+	if err := reader.Get(ctx, kutil.Key(attrs.Name), backupBucket); err != nil {
+		return nil, err
+	}
+	if backupBucket.Spec.SeedName == nil {
+		return nil, nil
+	}
+	return []string{*backupBucket.Spec.SeedName}, nil
+}
+
+type backupEntryRestrictor struct{}
+
+func (backupEntryRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "backupentries"}
+}
+
+func (backupEntryRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (backupEntryRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, reader client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	backupEntry := &gardencorev1beta1.BackupEntry{}
+	if err := reader.Get(ctx, kutil.Key(attrs.Namespace, attrs.Name), backupEntry); err != nil {
+		return nil, err
+	}
+	if backupEntry.Spec.SeedName == nil {
+		return nil, nil
+	}
+	return []string{*backupEntry.Spec.SeedName}, nil
+}
+
+type controllerInstallationRestrictor struct{}
+
+func (controllerInstallationRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: gardencorev1beta1.SchemeGroupVersion.Group, Resource: "controllerinstallations"}
+}
+
+func (controllerInstallationRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (controllerInstallationRestrictor) SeedNameFor(ctx context.Context, attrs *authorizationv1.ResourceAttributes, reader client.Reader) ([]string, error) {
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+
+	controllerInstallation := &gardencorev1beta1.ControllerInstallation{}
+	if err := reader.Get(ctx, kutil.Key(attrs.Name), controllerInstallation); err != nil {
+		return nil, err
+	}
+	return []string{controllerInstallation.Spec.SeedRef.Name}, nil
+}
+
+type leaseRestrictor struct{}
+
+func (leaseRestrictor) Resource() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: coordinationv1.SchemeGroupVersion.Group, Resource: "leases"}
+}
+
+func (leaseRestrictor) AllowedVerbs() sets.String { return readWriteVerbs }
+
+func (leaseRestrictor) SeedNameFor(_ context.Context, attrs *authorizationv1.ResourceAttributes, _ client.Reader) ([]string, error) {
+	// The leader-election lease is not owned by any particular seed and must always be allowed.
+	if attrs.Name == leaderElectionLeaseName {
+		return []string{anySeed}, nil
+	}
+	if attrs.Name == "" {
+		return nil, ErrNotScopable
+	}
+	// Every other Lease is conventionally named after the seed it belongs to.
+	return []string{attrs.Name}, nil
+}
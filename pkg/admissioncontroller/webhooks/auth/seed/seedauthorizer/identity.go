@@ -0,0 +1,59 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package seedauthorizer holds the seed-identity and seed-ownership resolution logic shared by the seedrestriction
+// admission webhook (pkg/admissioncontroller/webhooks/admission/seedrestriction) and the seed authorization webhook
+// (pkg/admissioncontroller/webhooks/auth/seed), so that both consult exactly one policy for "who is this seed" and
+// "does this object belong to it".
+package seedauthorizer
+
+import (
+	"strings"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+// SeedIdentityFromUserInfo resolves the name of the seed a request is acting on behalf of from the conventional
+// "gardener.cloud:system:seed:<name>" username prefix (also used as the CommonName of a seed's client certificate),
+// requiring userInfo to additionally be a member of the "gardener.cloud:system:seeds" group. isSeed is false if
+// userInfo does not match this convention at all. ambiguous is true for the special "<ambiguous>" seed name that
+// gardenlet falls back to for requests that cannot be attributed to a single seed (e.g. during bootstrap).
+func SeedIdentityFromUserInfo(userInfo authenticationv1.UserInfo) (seedName string, isSeed, ambiguous bool) {
+	if !strings.HasPrefix(userInfo.Username, v1beta1constants.SeedUserNamePrefix) {
+		return "", false, false
+	}
+
+	hasSeedsGroup := false
+	for _, group := range userInfo.Groups {
+		if group == v1beta1constants.SeedsGroup {
+			hasSeedsGroup = true
+			break
+		}
+	}
+	if !hasSeedsGroup {
+		return "", false, false
+	}
+
+	name := strings.TrimPrefix(userInfo.Username, v1beta1constants.SeedUserNamePrefix)
+	return name, true, name == v1beta1constants.SeedUserNameSuffixAmbiguous
+}
+
+// SeedNameMatches reports whether candidate (typically a *string field such as Shoot.Spec.SeedName or
+// BackupBucket.Spec.SeedName) identifies seedName, or whether the caller is the ambiguous seed, which is exempt from
+// ownership checks entirely.
+func SeedNameMatches(ambiguous bool, seedName string, candidate *string) bool {
+	return ambiguous || (candidate != nil && *candidate == seedName)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedauthorizer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// gardenSeedManagementNamespace is the namespace in which ManagedSeed objects live.
+const gardenSeedManagementNamespace = "garden"
+
+// managedSeedShootCacheEntry remembers the outcome of resolving a ManagedSeed's Shoot to a seed name, valid only for
+// as long as the ManagedSeed's Generation has not advanced (e.g. its Spec.Shoot reference has not changed).
+type managedSeedShootCacheEntry struct {
+	generation int64
+	seedName   *string
+}
+
+// Resolver answers "which seed does this Shoot/ManagedSeed belong to" by reading through reader (normally a
+// controller-runtime cache.Cache, which is already backed by an informer and therefore cheap), additionally caching
+// the multi-hop ManagedSeed -> Shoot resolution keyed by the ManagedSeed's Generation. This avoids repeating the
+// second Get and the chain walk on every request in a burst of list/watch fan-out that targets the same, unchanged
+// ManagedSeed.
+type Resolver struct {
+	reader client.Reader
+
+	mu                sync.RWMutex
+	managedSeedShoots map[string]managedSeedShootCacheEntry
+}
+
+// NewResolver returns a Resolver that reads Shoots and ManagedSeeds through reader.
+func NewResolver(reader client.Reader) *Resolver {
+	return &Resolver{reader: reader, managedSeedShoots: map[string]managedSeedShootCacheEntry{}}
+}
+
+// SeedNameOfShoot returns the seed a Shoot is (or was last) scheduled to, or nil if it is not yet scheduled.
+func (r *Resolver) SeedNameOfShoot(ctx context.Context, namespace, name string) (*string, error) {
+	shoot := &gardencorev1beta1.Shoot{}
+	if err := r.reader.Get(ctx, kutil.Key(namespace, name), shoot); err != nil {
+		return nil, err
+	}
+	return shoot.Spec.SeedName, nil
+}
+
+// SeedNameOfManagedSeedShoot resolves the seed name of the Shoot referenced by the ManagedSeed named name (which
+// always lives in the garden namespace), i.e. the seed that the ManagedSeed itself bootstraps. It returns nil if the
+// ManagedSeed does not exist, has no Shoot reference, or the referenced Shoot is not yet scheduled.
+func (r *Resolver) SeedNameOfManagedSeedShoot(ctx context.Context, name string) (*string, error) {
+	managedSeed := &seedmanagementv1alpha1.ManagedSeed{}
+	if err := r.reader.Get(ctx, kutil.Key(gardenSeedManagementNamespace, name), managedSeed); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	cacheKey := fmt.Sprintf("%s/%s", managedSeed.Namespace, managedSeed.Name)
+
+	r.mu.RLock()
+	entry, ok := r.managedSeedShoots[cacheKey]
+	r.mu.RUnlock()
+	if ok && entry.generation == managedSeed.Generation {
+		return entry.seedName, nil
+	}
+
+	var seedName *string
+	if managedSeed.Spec.Shoot != nil {
+		resolved, err := r.SeedNameOfShoot(ctx, managedSeed.Namespace, managedSeed.Spec.Shoot.Name)
+		if err != nil {
+			return nil, err
+		}
+		seedName = resolved
+	}
+
+	r.mu.Lock()
+	r.managedSeedShoots[cacheKey] = managedSeedShootCacheEntry{generation: managedSeed.Generation, seedName: seedName}
+	r.mu.Unlock()
+
+	return seedName, nil
+}
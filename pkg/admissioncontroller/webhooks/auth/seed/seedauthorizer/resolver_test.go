@@ -0,0 +1,155 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seedauthorizer_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/gardener/gardener/pkg/admissioncontroller/webhooks/auth/seed/seedauthorizer"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeReader is a minimal client.Reader backed by an in-memory map, indexed by namespace/name. It counts the number
+// of Get calls per object so tests can assert the Resolver's generation-keyed cache is actually being hit.
+type fakeReader struct {
+	objects map[string]client.Object
+	gets    map[string]int
+}
+
+func newFakeReader() *fakeReader {
+	return &fakeReader{objects: map[string]client.Object{}, gets: map[string]int{}}
+}
+
+func (r *fakeReader) set(obj client.Object) {
+	r.objects[fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())] = obj
+}
+
+func (r *fakeReader) Get(_ context.Context, key client.ObjectKey, obj client.Object) error {
+	cacheKey := fmt.Sprintf("%T/%s/%s", obj, key.Namespace, key.Name)
+	r.gets[cacheKey]++
+
+	stored, ok := r.objects[cacheKey]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
+
+	switch o := obj.(type) {
+	case *gardencorev1beta1.Shoot:
+		*o = *stored.(*gardencorev1beta1.Shoot)
+	case *seedmanagementv1alpha1.ManagedSeed:
+		*o = *stored.(*seedmanagementv1alpha1.ManagedSeed)
+	default:
+		return fmt.Errorf("fakeReader: unsupported type %T", obj)
+	}
+	return nil
+}
+
+func (r *fakeReader) List(_ context.Context, _ client.ObjectList, _ ...client.ListOption) error {
+	return fmt.Errorf("fakeReader: List is not supported")
+}
+
+var _ = Describe("Resolver", func() {
+	var (
+		reader   *fakeReader
+		resolver *Resolver
+	)
+
+	BeforeEach(func() {
+		reader = newFakeReader()
+		resolver = NewResolver(reader)
+	})
+
+	Describe("#SeedNameOfShoot", func() {
+		It("should return the scheduled seed name", func() {
+			reader.set(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden-foo", Name: "my-shoot"},
+				Spec:       gardencorev1beta1.ShootSpec{SeedName: pointer.StringPtr("seed-a")},
+			})
+
+			seedName, err := resolver.SeedNameOfShoot(context.TODO(), "garden-foo", "my-shoot")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedName).To(Equal(pointer.StringPtr("seed-a")))
+		})
+
+		It("should return an error if the Shoot does not exist", func() {
+			_, err := resolver.SeedNameOfShoot(context.TODO(), "garden-foo", "missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#SeedNameOfManagedSeedShoot", func() {
+		It("should return nil if the ManagedSeed does not exist", func() {
+			seedName, err := resolver.SeedNameOfManagedSeedShoot(context.TODO(), "missing")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedName).To(BeNil())
+		})
+
+		It("should resolve the seed name via the referenced Shoot, and cache it by Generation", func() {
+			reader.set(&seedmanagementv1alpha1.ManagedSeed{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "seed-a", Generation: 1},
+				Spec:       seedmanagementv1alpha1.ManagedSeedSpec{Shoot: &seedmanagementv1alpha1.Shoot{Name: "my-shoot"}},
+			})
+			reader.set(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "my-shoot"},
+				Spec:       gardencorev1beta1.ShootSpec{SeedName: pointer.StringPtr("seed-a")},
+			})
+
+			seedName, err := resolver.SeedNameOfManagedSeedShoot(context.TODO(), "seed-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedName).To(Equal(pointer.StringPtr("seed-a")))
+			Expect(reader.gets[fmt.Sprintf("%T/garden/my-shoot", &gardencorev1beta1.Shoot{})]).To(Equal(1))
+
+			// A second resolution for the same, unchanged (Generation: 1) ManagedSeed must hit the cache and not
+			// re-Get the Shoot.
+			seedName, err = resolver.SeedNameOfManagedSeedShoot(context.TODO(), "seed-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(seedName).To(Equal(pointer.StringPtr("seed-a")))
+			Expect(reader.gets[fmt.Sprintf("%T/garden/my-shoot", &gardencorev1beta1.Shoot{})]).To(Equal(1))
+		})
+
+		It("should re-resolve once the ManagedSeed's Generation advances", func() {
+			reader.set(&seedmanagementv1alpha1.ManagedSeed{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "seed-a", Generation: 1},
+				Spec:       seedmanagementv1alpha1.ManagedSeedSpec{Shoot: &seedmanagementv1alpha1.Shoot{Name: "my-shoot"}},
+			})
+			reader.set(&gardencorev1beta1.Shoot{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "my-shoot"},
+				Spec:       gardencorev1beta1.ShootSpec{SeedName: pointer.StringPtr("seed-a")},
+			})
+
+			_, err := resolver.SeedNameOfManagedSeedShoot(context.TODO(), "seed-a")
+			Expect(err).NotTo(HaveOccurred())
+
+			reader.set(&seedmanagementv1alpha1.ManagedSeed{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "garden", Name: "seed-a", Generation: 2},
+				Spec:       seedmanagementv1alpha1.ManagedSeedSpec{Shoot: &seedmanagementv1alpha1.Shoot{Name: "my-shoot"}},
+			})
+
+			_, err = resolver.SeedNameOfManagedSeedShoot(context.TODO(), "seed-a")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reader.gets[fmt.Sprintf("%T/garden/my-shoot", &gardencorev1beta1.Shoot{})]).To(Equal(2))
+		})
+	})
+})
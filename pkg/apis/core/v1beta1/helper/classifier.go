@@ -0,0 +1,183 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Classifier determines the Garden error codes that apply to a given error. Implementations are expected to be
+// cheap and side-effect free, as they may be invoked for every reconciliation error.
+type Classifier interface {
+	// Name returns a human-readable identifier for the classifier, used for metrics and diagnostics.
+	Name() string
+	// Match returns the error codes that apply to the given error, or nil if the classifier does not recognize it.
+	Match(err error) []gardencorev1beta1.ErrorCode
+}
+
+// nonRetryableCodes are consulted by HasNonRetryableErrorCode for codes that a Classifier did not itself declare
+// a retryability for. Classifiers registered via RegisterRetryableCodes can extend or override this default set.
+var nonRetryableCodes = sets.NewString(
+	string(gardencorev1beta1.ErrorInfraUnauthorized),
+	string(gardencorev1beta1.ErrorInfraInsufficientPrivileges),
+	string(gardencorev1beta1.ErrorInfraDependencies),
+	string(gardencorev1beta1.ErrorInfraQuotaExceeded),
+	string(gardencorev1beta1.ErrorInfraRateLimitsExceeded),
+	string(gardencorev1beta1.ErrorConfigurationProblem),
+)
+
+// ClassifierRegistry holds the set of Classifiers that DetermineErrorCodes consults, in registration order.
+// Extensions (e.g. provider-aws, provider-azure) register their own Classifiers into the DefaultClassifierRegistry
+// during their startup so that provider-specific error strings don't need to live in Gardener core.
+type ClassifierRegistry struct {
+	mutex       sync.RWMutex
+	classifiers []Classifier
+}
+
+// NewClassifierRegistry creates an empty ClassifierRegistry.
+func NewClassifierRegistry() *ClassifierRegistry {
+	return &ClassifierRegistry{}
+}
+
+// Register adds the given Classifier to the registry. Classifiers are consulted in the order they were registered;
+// a later Classifier can still contribute additional codes for an error that an earlier one already matched.
+func (r *ClassifierRegistry) Register(classifier Classifier) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.classifiers = append(r.classifiers, classifier)
+}
+
+// RegisterRetryableCodes marks the given error codes as non-retryable (if nonRetryable is true) or retryable
+// (if false), overriding the built-in default used by HasNonRetryableErrorCode.
+func (r *ClassifierRegistry) RegisterRetryableCodes(nonRetryable bool, codes ...gardencorev1beta1.ErrorCode) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, code := range codes {
+		if nonRetryable {
+			nonRetryableCodes.Insert(string(code))
+		} else {
+			nonRetryableCodes.Delete(string(code))
+		}
+	}
+}
+
+// Classify runs all registered classifiers against the given error and returns the union of matched error codes.
+func (r *ClassifierRegistry) Classify(err error) []gardencorev1beta1.ErrorCode {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	codes := sets.NewString()
+	for _, classifier := range r.classifiers {
+		for _, code := range classifier.Match(err) {
+			codes.Insert(string(code))
+		}
+	}
+
+	var out []gardencorev1beta1.ErrorCode
+	for _, c := range codes.List() {
+		out = append(out, gardencorev1beta1.ErrorCode(c))
+	}
+	return out
+}
+
+// DefaultClassifierRegistry is the process-wide ClassifierRegistry consulted by DetermineErrorCodes. Extensions
+// should call DefaultClassifierRegistry.Register(...) from an init() function, mirroring how client-go schemes
+// register themselves.
+var DefaultClassifierRegistry = NewClassifierRegistry()
+
+// regexClassifier is a Classifier backed by a static set of named regular expressions. It ships the same patterns
+// that DetermineErrorCodes used to hard-code, preserved here for backwards compatibility.
+type regexClassifier struct {
+	name  string
+	rules map[gardencorev1beta1.ErrorCode]*regexp.Regexp
+}
+
+// Name implements Classifier.
+func (c *regexClassifier) Name() string {
+	return c.name
+}
+
+// Match implements Classifier.
+func (c *regexClassifier) Match(err error) []gardencorev1beta1.ErrorCode {
+	if err == nil {
+		return nil
+	}
+
+	message := err.Error()
+
+	var codes []gardencorev1beta1.ErrorCode
+	for code, rule := range c.rules {
+		if rule.MatchString(message) {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// NewRegexClassifier creates a Classifier that matches the given named regular expressions against the error
+// message. It is the mechanism used both by the built-in classifier and by cluster-scoped ErrorCodeRuleSets.
+func NewRegexClassifier(name string, rules map[gardencorev1beta1.ErrorCode]*regexp.Regexp) Classifier {
+	return &regexClassifier{name: name, rules: rules}
+}
+
+// ValidateClassifierRule checks that a user-provided rule (as used by an ErrorCodeRuleSet) refers to a known error
+// code and compiles to a valid regular expression, returning the compiled regexp on success.
+func ValidateClassifierRule(code gardencorev1beta1.ErrorCode, pattern string) (*regexp.Regexp, error) {
+	if !knownErrorCodes.Has(string(code)) {
+		return nil, fmt.Errorf("unknown error code %q", code)
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+	}
+	return compiled, nil
+}
+
+var knownErrorCodes = sets.NewString(
+	string(gardencorev1beta1.ErrorInfraUnauthorized),
+	string(gardencorev1beta1.ErrorInfraQuotaExceeded),
+	string(gardencorev1beta1.ErrorInfraRateLimitsExceeded),
+	string(gardencorev1beta1.ErrorInfraInsufficientPrivileges),
+	string(gardencorev1beta1.ErrorInfraDependencies),
+	string(gardencorev1beta1.ErrorRetryableInfraDependencies),
+	string(gardencorev1beta1.ErrorInfraResourcesDepleted),
+	string(gardencorev1beta1.ErrorConfigurationProblem),
+	string(gardencorev1beta1.ErrorRetryableConfigurationProblem),
+	string(gardencorev1beta1.ErrorInfraNodeDrainTimeout),
+)
+
+func init() {
+	DefaultClassifierRegistry.Register(NewRegexClassifier("builtin", map[gardencorev1beta1.ErrorCode]*regexp.Regexp{
+		gardencorev1beta1.ErrorInfraUnauthorized:             unauthorizedRegexp,
+		gardencorev1beta1.ErrorInfraQuotaExceeded:            quotaExceededRegexp,
+		gardencorev1beta1.ErrorInfraRateLimitsExceeded:       rateLimitsExceededRegexp,
+		gardencorev1beta1.ErrorInfraInsufficientPrivileges:   insufficientPrivilegesRegexp,
+		gardencorev1beta1.ErrorInfraDependencies:             dependenciesRegexp,
+		gardencorev1beta1.ErrorRetryableInfraDependencies:    retryableDependenciesRegexp,
+		gardencorev1beta1.ErrorInfraResourcesDepleted:        resourcesDepletedRegexp,
+		gardencorev1beta1.ErrorConfigurationProblem:          configurationProblemRegexp,
+		gardencorev1beta1.ErrorRetryableConfigurationProblem: retryableConfigurationProblemRegexp,
+		gardencorev1beta1.ErrorInfraNodeDrainTimeout:         nodeDrainTimeoutRegexp,
+	}))
+}
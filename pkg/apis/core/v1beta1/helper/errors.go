@@ -21,6 +21,7 @@ import (
 	"time"
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/apis/core/v1beta1/helper/providererrors"
 	utilerrors "github.com/gardener/gardener/pkg/utils/errors"
 
 	errors2 "github.com/pkg/errors"
@@ -59,6 +60,7 @@ var (
 	resourcesDepletedRegexp             = regexp.MustCompile(`(?i)(not available in the current hardware cluster|InsufficientInstanceCapacity|SkuNotAvailable|ZonalAllocationFailed|out of stock)`)
 	configurationProblemRegexp          = regexp.MustCompile(`(?i)(AzureBastionSubnet|not supported in your requested Availability Zone|InvalidParameter|InvalidParameterValue|notFound|NetcfgInvalidSubnet|InvalidSubnet|Invalid value|KubeletHasInsufficientMemory|KubeletHasDiskPressure|KubeletHasInsufficientPID|violates constraint|no attached internet gateway found|Your query returned no results|PrivateEndpointNetworkPoliciesCannotBeEnabledOnPrivateEndpointSubnet|invalid VPC attributes|PrivateLinkServiceNetworkPoliciesCannotBeEnabledOnPrivateLinkServiceSubnet|unrecognized feature gate|runtime-config invalid key|LoadBalancingRuleMustDisableSNATSinceSameFrontendIPConfigurationIsReferencedByOutboundRule|strict decoder error|not allowed to configure an unsupported)`)
 	retryableConfigurationProblemRegexp = regexp.MustCompile(`(?i)(is misconfigured and requires zero voluntary evictions)`)
+	nodeDrainTimeoutRegexp              = regexp.MustCompile(`(?i)(did not finish draining within)`)
 )
 
 // DetermineError determines the Garden error code for the given error and creates a new error with the given message.
@@ -79,39 +81,33 @@ func DetermineError(err error, message string) error {
 	return &ErrorWithCodes{errMsg, codes}
 }
 
-// DetermineErrorCodes determines error codes based on the given error.
+// DetermineErrorCodes determines error codes based on the given error. It re-uses codes the error already carries
+// via the Coder interface, then prefers a typed providererrors.Inspect match (structured provider SDK fields) over
+// DefaultClassifierRegistry's regex-based classifiers, which are only consulted if no inspector recognizes the error.
+// The registry by default contains a single classifier covering the regexes below but may be extended by extensions
+// or cluster-scoped ErrorCodeRuleSets.
 func DetermineErrorCodes(err error) []gardencorev1beta1.ErrorCode {
 	var (
-		coder   Coder
-		message = err.Error()
-		codes   = sets.NewString()
-
-		knownCodes = map[string]func(string) bool{
-			string(gardencorev1beta1.ErrorInfraUnauthorized):             unauthorizedRegexp.MatchString,
-			string(gardencorev1beta1.ErrorInfraQuotaExceeded):            quotaExceededRegexp.MatchString,
-			string(gardencorev1beta1.ErrorInfraRateLimitsExceeded):       rateLimitsExceededRegexp.MatchString,
-			string(gardencorev1beta1.ErrorInfraInsufficientPrivileges):   insufficientPrivilegesRegexp.MatchString,
-			string(gardencorev1beta1.ErrorInfraDependencies):             dependenciesRegexp.MatchString,
-			string(gardencorev1beta1.ErrorRetryableInfraDependencies):    retryableDependenciesRegexp.MatchString,
-			string(gardencorev1beta1.ErrorInfraResourcesDepleted):        resourcesDepletedRegexp.MatchString,
-			string(gardencorev1beta1.ErrorConfigurationProblem):          configurationProblemRegexp.MatchString,
-			string(gardencorev1beta1.ErrorRetryableConfigurationProblem): retryableConfigurationProblemRegexp.MatchString,
-		}
+		coder Coder
+		codes = sets.NewString()
 	)
 
 	// try to re-use codes from error
 	if errors.As(err, &coder) {
 		for _, code := range coder.Codes() {
 			codes.Insert(string(code))
-			// found codes don't need to be checked any more
-			delete(knownCodes, string(code))
 		}
 	}
 
-	// determine error codes
-	for code, matchFn := range knownCodes {
-		if !codes.Has(code) && matchFn(message) {
-			codes.Insert(code)
+	// prefer typed inspection of wrapped provider SDK errors over message matching
+	if inspection, ok := providererrors.Inspect(err); ok {
+		for _, code := range inspection.Codes {
+			codes.Insert(string(code))
+		}
+	} else {
+		// determine error codes via the registered classifiers
+		for _, code := range DefaultClassifierRegistry.Classify(err) {
+			codes.Insert(string(code))
 		}
 	}
 
@@ -161,10 +157,17 @@ func NewWrappedLastErrors(description string, err error) *WrappedLastErrors {
 	var lastErrors []gardencorev1beta1.LastError
 
 	for _, partError := range utilerrors.Errors(err) {
+		cause := errors2.Cause(partError)
+
+		taskID := utilerrors.GetID(partError)
+		if inspection, ok := providererrors.Inspect(cause); ok && inspection.RequestID != "" {
+			taskID = inspection.RequestID
+		}
+
 		lastErrors = append(lastErrors, *LastErrorWithTaskID(
 			partError.Error(),
-			utilerrors.GetID(partError),
-			DetermineErrorCodes(errors2.Cause(partError))...))
+			taskID,
+			DetermineErrorCodes(cause)...))
 	}
 
 	return &WrappedLastErrors{
@@ -197,16 +200,13 @@ func LastErrorWithTaskID(description string, taskID string, codes ...gardencorev
 }
 
 // HasNonRetryableErrorCode returns true if at least one of given list of last errors has at least one error code that
-// indicates that an automatic retry would not help fixing the problem.
+// indicates that an automatic retry would not help fixing the problem. The set of non-retryable codes defaults to
+// the classic infrastructure/configuration problems, but can be extended by classifiers via
+// ClassifierRegistry.RegisterRetryableCodes, e.g. for rules contributed by an ErrorCodeRuleSet.
 func HasNonRetryableErrorCode(lastErrors ...gardencorev1beta1.LastError) bool {
 	for _, lastError := range lastErrors {
 		for _, code := range lastError.Codes {
-			if code == gardencorev1beta1.ErrorInfraUnauthorized ||
-				code == gardencorev1beta1.ErrorInfraInsufficientPrivileges ||
-				code == gardencorev1beta1.ErrorInfraDependencies ||
-				code == gardencorev1beta1.ErrorInfraQuotaExceeded ||
-				code == gardencorev1beta1.ErrorInfraRateLimitsExceeded ||
-				code == gardencorev1beta1.ErrorConfigurationProblem {
+			if nonRetryableCodes.Has(string(code)) {
 				return true
 			}
 		}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package providererrors allows cloud providers to plug typed inspection of their SDK error types into Gardener's
+// error code determination, instead of Gardener having to match on the provider's English error message.
+package providererrors
+
+import (
+	"sync"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// Inspection is the result of an SDKErrorInspector recognizing a provider SDK error.
+type Inspection struct {
+	// Codes are the Garden error codes derived from the inspected error's structured fields.
+	Codes []gardencorev1beta1.ErrorCode
+	// RequestID is the provider's request- or correlation-id, if the SDK error type exposes one. It is surfaced via
+	// LastError.TaskID so that support can correlate a Gardener error with the provider-side request.
+	RequestID string
+}
+
+// SDKErrorInspector inspects a provider SDK error type (e.g. awserr.Error, autorest.DetailedError, googleapi.Error)
+// and derives Garden error codes from its structured fields (HTTP status, service code, request-id) rather than
+// from the error message. Inspect returns ok=false if it does not recognize the given error.
+type SDKErrorInspector interface {
+	Inspect(err error) (inspection Inspection, ok bool)
+}
+
+// registry holds the SDKErrorInspectors registered by provider extensions.
+var registry struct {
+	mutex      sync.RWMutex
+	inspectors []SDKErrorInspector
+}
+
+// RegisterInspector registers the given SDKErrorInspector so that it is consulted by Inspect. Providers are
+// expected to call this from an init() function of their adapter package, similar to how kube client-go schemes
+// register themselves.
+func RegisterInspector(inspector SDKErrorInspector) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registry.inspectors = append(registry.inspectors, inspector)
+}
+
+// Inspect runs all registered SDKErrorInspectors against the given error and returns the first match. It is meant
+// to be consulted before falling back to regex-based message matching.
+func Inspect(err error) (Inspection, bool) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	for _, inspector := range registry.inspectors {
+		if inspection, ok := inspector.Inspect(err); ok {
+			return inspection, true
+		}
+	}
+	return Inspection{}, false
+}
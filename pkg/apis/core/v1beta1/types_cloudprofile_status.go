@@ -0,0 +1,42 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ObservedVersion is intended to live as an entry of CloudProfileStatus.ObservedKubernetesVersions (types_cloudprofile.go
+// is not part of this checkout, so it cannot be wired in here). It is a snapshot of what the CloudProfile
+// auto-version-management subsystem last saw for a single version, so that a version absent from this list can be
+// told apart from one the subsystem decided not to change.
+type ObservedVersion struct {
+	// Version is the major.minor.patch version this observation is about.
+	Version string `json:"version" protobuf:"bytes,1,opt,name=version"`
+	// ObservedExpirationDate is the expiration date the subsystem has derived for Version, or nil if none applies.
+	// +optional
+	ObservedExpirationDate *metav1.Time `json:"observedExpirationDate,omitempty" protobuf:"bytes,2,opt,name=observedExpirationDate"`
+	// ObservedClassification is the classification the subsystem has derived for Version.
+	// +optional
+	ObservedClassification *VersionClassification `json:"observedClassification,omitempty" protobuf:"bytes,3,opt,name=observedClassification,casttype=VersionClassification"`
+}
+
+// ObservedMachineImageVersion scopes an ObservedVersion to the machine image it belongs to.
+type ObservedMachineImageVersion struct {
+	// Name is the name of the machine image this observation is about.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// ObservedVersion is the observation itself.
+	ObservedVersion `json:",inline" protobuf:"bytes,2,opt,name=observedVersion"`
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloudProfileVersionProposal is a cluster-scoped resource the CloudProfile controller creates (in
+// VersionManagementModePropose) to hand a batch of computed Kubernetes or machine image version changes to a human
+// operator or GitOps pipeline for approval, instead of applying them to the CloudProfile directly.
+type CloudProfileVersionProposal struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec contains the proposed version changes.
+	Spec CloudProfileVersionProposalSpec `json:"spec"`
+	// Status reflects the current approval state of the proposal.
+	// +optional
+	Status CloudProfileVersionProposalStatus `json:"status,omitempty"`
+}
+
+// CloudProfileVersionProposalList is a list of CloudProfileVersionProposals.
+type CloudProfileVersionProposalList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of CloudProfileVersionProposals.
+	Items []CloudProfileVersionProposal `json:"items"`
+}
+
+// CloudProfileVersionProposalSpec describes a batch of version changes proposed for a single CloudProfile.
+type CloudProfileVersionProposalSpec struct {
+	// CloudProfileName is the name of the CloudProfile this proposal applies to.
+	CloudProfileName string `json:"cloudProfileName"`
+	// Kind is the axis these changes belong to, "Kubernetes" or "MachineImage".
+	Kind string `json:"kind"`
+	// Changes is the list of individual version changes the CloudProfile controller would apply if this proposal
+	// is approved.
+	Changes []ProposedVersionChange `json:"changes"`
+}
+
+// ProposedVersionChange is a single version addition, deprecation, or expiration proposed for approval.
+type ProposedVersionChange struct {
+	// ImageName is set if Kind is "MachineImage", naming which machine image Version belongs to.
+	// +optional
+	ImageName string `json:"imageName,omitempty"`
+	// Version is the affected major.minor.patch version.
+	Version string `json:"version"`
+	// Action describes what the CloudProfile controller computed for Version, e.g. "Added", "Deprecated", or
+	// "Expired".
+	Action string `json:"action"`
+}
+
+// CloudProfileVersionProposalPhase is the approval state of a CloudProfileVersionProposal.
+type CloudProfileVersionProposalPhase string
+
+const (
+	// CloudProfileVersionProposalPending is the initial phase: the proposal is waiting for a human or GitOps
+	// pipeline to approve or reject it.
+	CloudProfileVersionProposalPending CloudProfileVersionProposalPhase = "Pending"
+	// CloudProfileVersionProposalApproved is set once an operator (or automation acting on their behalf) has
+	// approved the proposal. The CloudProfile controller applies Spec.Changes on its next reconciliation and
+	// transitions the phase to CloudProfileVersionProposalApplied.
+	CloudProfileVersionProposalApproved CloudProfileVersionProposalPhase = "Approved"
+	// CloudProfileVersionProposalApplied is set once the CloudProfile controller has applied an approved
+	// proposal's changes to the CloudProfile.
+	CloudProfileVersionProposalApplied CloudProfileVersionProposalPhase = "Applied"
+	// CloudProfileVersionProposalRejected is set once an operator has rejected the proposal. The CloudProfile
+	// controller leaves the CloudProfile unchanged and recomputes a fresh proposal on its next reconciliation.
+	CloudProfileVersionProposalRejected CloudProfileVersionProposalPhase = "Rejected"
+)
+
+// CloudProfileVersionProposalStatus reflects the current approval state of a CloudProfileVersionProposal.
+type CloudProfileVersionProposalStatus struct {
+	// Phase is the current approval state. Defaults to CloudProfileVersionProposalPending.
+	// +optional
+	Phase CloudProfileVersionProposalPhase `json:"phase,omitempty"`
+	// ApprovedBy is the identity that last transitioned Phase to CloudProfileVersionProposalApproved.
+	// +optional
+	ApprovedBy string `json:"approvedBy,omitempty"`
+	// ApprovedAt is when Phase was last transitioned to CloudProfileVersionProposalApproved.
+	// +optional
+	ApprovedAt *metav1.Time `json:"approvedAt,omitempty"`
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+// Channel is a maintenance auto-update channel a CloudProfile operator can tag an ExpirableVersion with, and a
+// Shoot can subscribe to via MaintenanceAutoUpdate, to control the rollout risk of automatic updates independently
+// of per-Shoot version pinning.
+type Channel string
+
+const (
+	// ChannelStable only receives versions an operator considers battle-tested.
+	ChannelStable Channel = "stable"
+	// ChannelRegular receives versions that have had some soak time, ahead of ChannelStable.
+	ChannelRegular Channel = "regular"
+	// ChannelRapid receives versions shortly after their release.
+	ChannelRapid Channel = "rapid"
+	// ChannelLatest always receives the newest available version, including ones not yet classified for any other
+	// channel.
+	ChannelLatest Channel = "latest"
+)
@@ -0,0 +1,59 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EncryptionConfig is intended to live as KubeAPIServerConfig.EncryptionConfig (types_shoot.go is not part of this
+// checkout, so it cannot be wired in here). It lets a Shoot owner supplement Gardener's automatically managed
+// aescbc/identity EncryptionConfiguration (see the etcdencryption package) with one or more external KMS providers.
+type EncryptionConfig struct {
+	// KMS is the list of external KMS providers to prepend ahead of the built-in aescbc provider, in order. Gardener
+	// keeps aescbc/identity as a fallback provider so that existing encrypted data stays readable if a KMS plugin
+	// becomes unavailable.
+	// +optional
+	KMS []KMSEncryptionConfig `json:"kms,omitempty" protobuf:"bytes,1,rep,name=kms"`
+}
+
+// ShootEtcdEncryptionKeyRotation is a constant for a condition type indicating the progress of an etcd encryption
+// key rotation, i.e. the period between a new key being introduced as the secondary provider and the old key being
+// dropped once every Secret has been confirmed to be re-encrypted under the new one. See
+// Botanist.RotateEtcdEncryptionKey.
+const ShootEtcdEncryptionKeyRotation ConditionType = "EtcdEncryptionKeyRotation"
+
+// KMSEncryptionConfig configures a single external KMS provider, deployed by Gardener as a sidecar to kube-apiserver
+// and reached over a shared Unix domain socket (see Botanist.writeEncryptionConfiguration).
+type KMSEncryptionConfig struct {
+	// Name is the provider name as it appears in the resulting EncryptionConfiguration and in kube-apiserver's
+	// --encryption-provider-config.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// APIVersion selects the KMS plugin API version to speak, "v1" or "v2". Defaults to "v1" if empty.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty" protobuf:"bytes,2,opt,name=apiVersion"`
+	// Endpoint is the gRPC endpoint the KMS plugin sidecar listens on, e.g. unix:///var/run/kmsplugin/socket.sock.
+	Endpoint string `json:"endpoint" protobuf:"bytes,3,opt,name=endpoint"`
+	// Timeout is the gRPC timeout used by kube-apiserver when calling the KMS plugin. Defaults to 3s if empty.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty" protobuf:"bytes,4,opt,name=timeout"`
+	// CacheSize is the number of data encryption keys the KMS plugin may cache in memory. Defaults to 1000 if empty.
+	// +optional
+	CacheSize *int32 `json:"cacheSize,omitempty" protobuf:"varint,5,opt,name=cacheSize"`
+	// CredentialsSecretRef references the Secret in the Shoot's project namespace holding the cloud-provider
+	// credentials the KMS plugin sidecar needs to talk to the external KMS (e.g. AWS KMS, GCP KMS, Azure Key Vault).
+	CredentialsSecretRef corev1.SecretReference `json:"credentialsSecretRef" protobuf:"bytes,6,opt,name=credentialsSecretRef"`
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HealthCheck is a named, operator- or extension-deployed probe (HTTP GET, TCP dial, DNS lookup, or exec-in-pod)
+// against a Shoot's control plane or data plane, whose result is rolled into the standard Shoot condition it
+// declares via Spec.ShootCondition. Unlike the other resources in this package, a single HealthCheck kind is shared
+// by every probe; what Shoot condition a given HealthCheck feeds into has to be read off the object itself rather
+// than being implied by its kind.
+type HealthCheck struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Spec contains the specification of this HealthCheck.
+	Spec HealthCheckSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	// Status contains the most recently observed status of this HealthCheck.
+	// +optional
+	Status HealthCheckStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// HealthCheckSpec is the spec of a HealthCheck.
+type HealthCheckSpec struct {
+	// ShootCondition is the standard Shoot condition this HealthCheck's result is merged into by
+	// Health.getHealthCheckExtensionConditions, e.g. ShootControlPlaneHealthy or ShootSystemComponentsHealthy.
+	ShootCondition gardencorev1beta1.ConditionType `json:"shootCondition" protobuf:"bytes,1,opt,name=shootCondition,casttype=github.com/gardener/gardener/pkg/apis/core/v1beta1.ConditionType"`
+	// HTTPGet, if set, probes the given URL with an HTTP GET request.
+	// +optional
+	HTTPGet *HTTPGetHealthCheck `json:"httpGet,omitempty" protobuf:"bytes,2,opt,name=httpGet"`
+	// TCPSocket, if set, probes the given host/port by opening a TCP connection.
+	// +optional
+	TCPSocket *TCPSocketHealthCheck `json:"tcpSocket,omitempty" protobuf:"bytes,3,opt,name=tcpSocket"`
+	// Exec, if set, probes by running a command inside an existing Pod.
+	// +optional
+	Exec *ExecHealthCheck `json:"exec,omitempty" protobuf:"bytes,4,opt,name=exec"`
+	// PeriodSeconds is how often the probe is executed. Defaults to 30 if unset.
+	// +optional
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty" protobuf:"varint,5,opt,name=periodSeconds"`
+}
+
+// HTTPGetHealthCheck describes an HTTP GET probe.
+type HTTPGetHealthCheck struct {
+	// URL is the URL to send the GET request to.
+	URL string `json:"url" protobuf:"bytes,1,opt,name=url"`
+	// ExpectedStatusCodes lists the HTTP status codes that count as a successful probe. Defaults to [200, 399] if
+	// empty.
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty" protobuf:"varint,2,rep,name=expectedStatusCodes"`
+}
+
+// TCPSocketHealthCheck describes a TCP dial probe.
+type TCPSocketHealthCheck struct {
+	// Host is the host to dial. Defaults to the Shoot's internal API server address if empty.
+	// +optional
+	Host string `json:"host,omitempty" protobuf:"bytes,1,opt,name=host"`
+	// Port is the port to dial.
+	Port int32 `json:"port" protobuf:"varint,2,opt,name=port"`
+}
+
+// ExecHealthCheck describes an exec-in-pod probe.
+type ExecHealthCheck struct {
+	// Name is the name of the Pod to exec into.
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+	// Namespace is the namespace of the Pod to exec into.
+	Namespace string `json:"namespace" protobuf:"bytes,2,opt,name=namespace"`
+	// Container is the container within the Pod to exec into. Defaults to the Pod's only container if it has
+	// exactly one.
+	// +optional
+	Container string `json:"container,omitempty" protobuf:"bytes,3,opt,name=container"`
+	// Command is the command (and arguments) to run. A zero exit code counts as a successful probe.
+	Command []string `json:"command" protobuf:"bytes,4,rep,name=command"`
+}
+
+// HealthCheckStatus is the status of a HealthCheck.
+type HealthCheckStatus struct {
+	// Conditions represents the latest available observations of this HealthCheck's current state.
+	// +optional
+	Conditions []gardencorev1beta1.Condition `json:"conditions,omitempty" protobuf:"bytes,1,rep,name=conditions"`
+	// ObservedGeneration is the most recent generation observed for this HealthCheck.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,2,opt,name=observedGeneration"`
+	// LastProbeTime is the time the probe was last executed.
+	// +optional
+	LastProbeTime metav1.Time `json:"lastProbeTime,omitempty" protobuf:"bytes,3,opt,name=lastProbeTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HealthCheckList is a list of HealthCheck resources.
+type HealthCheckList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	// Items is the list of HealthChecks.
+	Items []HealthCheck `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
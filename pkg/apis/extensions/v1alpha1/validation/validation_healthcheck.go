@@ -0,0 +1,89 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateHealthCheck validates a HealthCheck object.
+func ValidateHealthCheck(healthCheck *extensionsv1alpha1.HealthCheck) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&healthCheck.ObjectMeta, true, apivalidation.NameIsDNSSubdomain, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateHealthCheckSpec(&healthCheck.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateHealthCheckUpdate validates a HealthCheck object before an update.
+func ValidateHealthCheckUpdate(newHealthCheck, oldHealthCheck *extensionsv1alpha1.HealthCheck) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&newHealthCheck.ObjectMeta, &oldHealthCheck.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateHealthCheck(newHealthCheck)...)
+	return allErrs
+}
+
+// ValidateHealthCheckSpec validates the specification of a HealthCheck object. Exactly one of HTTPGet, TCPSocket, or
+// Exec must be set, mirroring the mutual exclusivity of corev1.Handler's probe actions.
+func ValidateHealthCheckSpec(spec *extensionsv1alpha1.HealthCheckSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ShootCondition == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("shootCondition"), "shootCondition is required"))
+	}
+
+	actionsSet := 0
+	if spec.HTTPGet != nil {
+		actionsSet++
+		if spec.HTTPGet.URL == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("httpGet", "url"), "url is required"))
+		}
+	}
+	if spec.TCPSocket != nil {
+		actionsSet++
+		if spec.TCPSocket.Port <= 0 || spec.TCPSocket.Port > 65535 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("tcpSocket", "port"), spec.TCPSocket.Port, "must be between 1 and 65535"))
+		}
+	}
+	if spec.Exec != nil {
+		actionsSet++
+		if spec.Exec.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exec", "name"), "name is required"))
+		}
+		if spec.Exec.Namespace == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exec", "namespace"), "namespace is required"))
+		}
+		if len(spec.Exec.Command) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("exec", "command"), "command is required"))
+		}
+	}
+
+	switch actionsSet {
+	case 0:
+		allErrs = append(allErrs, field.Required(fldPath, "exactly one of httpGet, tcpSocket or exec must be set"))
+	case 1:
+		// ok
+	default:
+		allErrs = append(allErrs, field.Invalid(fldPath, spec, "only one of httpGet, tcpSocket or exec may be set"))
+	}
+
+	if spec.PeriodSeconds != nil && *spec.PeriodSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("periodSeconds"), *spec.PeriodSeconds, "must be greater than 0"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// This file is intended to extend BastionStatus (defined in the absent types_bastion.go of this checkout) with a
+// new `Sessions []BastionSessionRecord` field, so an SSH session established against a Bastion is auditable.
+
+// BastionSessionRecord references one recorded SSH session established against a Bastion.
+type BastionSessionRecord struct {
+	// ID identifies this session record among others on the same Bastion.
+	ID string `json:"id"`
+	// Principal is the authenticated identity (e.g. an SSH public key fingerprint, or the Gardener user who
+	// requested the Bastion) that established the session.
+	Principal string `json:"principal"`
+	// SourceIP is the client IP address the session connected from.
+	SourceIP string `json:"sourceIP"`
+	// ConnectedAt is when the session was established.
+	ConnectedAt metav1.Time `json:"connectedAt"`
+	// DisconnectedAt is when the session ended, or nil while it is still open.
+	DisconnectedAt *metav1.Time `json:"disconnectedAt,omitempty"`
+	// TranscriptRef points at the full typescript-style session transcript, if one was recorded, in the session
+	// recording sink. Its format is sink-specific and opaque to the Bastion controller.
+	TranscriptRef *corev1.TypedLocalObjectReference `json:"transcriptRef,omitempty"`
+}
+
+// BastionSessionRetentionPolicy determines what happens to BastionSessionRecord data once its owning Bastion is
+// garbage-collected.
+type BastionSessionRetentionPolicy string
+
+const (
+	// PreserveBastionSessionRetentionPolicy keeps session recordings in the configured sink after the Bastion
+	// itself is deleted, for as long as the sink's own retention policy allows (e.g. an S3 bucket lifecycle rule).
+	PreserveBastionSessionRetentionPolicy BastionSessionRetentionPolicy = "Preserve"
+	// PurgeBastionSessionRetentionPolicy deletes session recordings from the configured sink at the same time the
+	// Bastion they belong to is garbage-collected.
+	PurgeBastionSessionRetentionPolicy BastionSessionRetentionPolicy = "Purge"
+)
@@ -16,6 +16,7 @@ package seedmanagement
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	gardencore "github.com/gardener/gardener/pkg/apis/core"
 )
@@ -24,6 +25,8 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // ManagedSeedSet represents a set of identical ManagedSeeds.
+// TODOME: register a scale subresource (Spec.Replicas / Status.Replicas / Status selector) for this type once
+// pkg/registry/seedmanagement/managedseedset exists; that REST storage layer is not present in this checkout.
 type ManagedSeedSet struct {
 	metav1.TypeMeta
 	// Standard object metadata.
@@ -65,16 +68,38 @@ type ManagedSeedSetSpec struct {
 	// RevisionHistoryLimit is the maximum number of revisions that will
 	// be maintained in the ManagedSeedSet's revision history. Defaults to 10.
 	RevisionHistoryLimit *int32
+	// ReplicaManagementPolicy controls how new replicas are provisioned relative to one another. Defaults to
+	// OrderedReadyReplicaManagementPolicy.
+	ReplicaManagementPolicy *ReplicaManagementPolicy
 }
 
+// ReplicaManagementPolicy is a string enumeration type that enumerates the policies the ManagedSeedSet controller
+// can use to decide whether a new replica must wait on a previous one before it is provisioned. This is the
+// ManagedSeedSet analog of a StatefulSet's PodManagementPolicy, adapted to the fact that provisioning a replica
+// here means bootstrapping an entire Shoot-hosted seed, which can take minutes to hours rather than seconds.
+type ReplicaManagementPolicy string
+
+const (
+	// OrderedReadyReplicaManagementPolicy indicates that replicas are created in strictly increasing ordinal order,
+	// waiting for each replica to become Ready before the next one is provisioned, and torn down in strictly
+	// decreasing ordinal order. This is the default, as it gives the most predictable bootstrap behavior.
+	OrderedReadyReplicaManagementPolicy ReplicaManagementPolicy = "OrderedReady"
+	// ParallelReplicaManagementPolicy indicates that the controller creates and deletes replicas as soon as it
+	// determines it is necessary, without waiting for other replicas to become Ready or to be fully terminated
+	// first.
+	ParallelReplicaManagementPolicy ReplicaManagementPolicy = "Parallel"
+)
+
 // ManagedSeedSetUpdateStrategy specifies the strategy that the ManagedSeedSet
 // controller will use to perform updates. It includes any additional parameters
 // necessary to perform the update for the indicated strategy.
 type ManagedSeedSetUpdateStrategy struct {
 	// Type indicates the type of the ManagedSeedSetUpdateStrategy. Defaults to ManagedSeedSetUpdateStrategyType.
 	Type *ManagedSeedSetUpdateStrategyType
-	// RollingUpdate is used to communicate parameters when Type is ManagedSeedSetUpdateStrategyType.
+	// RollingUpdate is used to communicate parameters when Type is RollingUpdateManagedSeedSetUpdateStrategyType.
 	RollingUpdate *RollingUpdateManagedSeedSetUpdateStrategy
+	// Canary is used to communicate parameters when Type is CanaryManagedSeedSetUpdateStrategyType.
+	Canary *CanaryManagedSeedSetUpdateStrategy
 }
 
 // ManagedSeedSetUpdateStrategyType is a string enumeration type that enumerates
@@ -86,14 +111,56 @@ const (
 	// applied to all ManagedSeeds / Shoots in the ManagedSeedSet with respect to the ManagedSeedSet
 	// ordering constraints.
 	RollingUpdateManagedSeedSetUpdateStrategyType ManagedSeedSetUpdateStrategyType = "RollingUpdate"
+	// OnDeleteManagedSeedSetUpdateStrategyType indicates that the controller will not apply updates to existing
+	// ManagedSeeds / Shoots at all. Users are responsible for deleting a replica, at which point it is re-created
+	// from the current Template / ShootTemplate. This exists because rolling a ManagedSeed is far more expensive
+	// than rolling a Pod in a StatefulSet, since it reconciles an entire Shoot-hosted seed.
+	OnDeleteManagedSeedSetUpdateStrategyType ManagedSeedSetUpdateStrategyType = "OnDelete"
+	// CanaryManagedSeedSetUpdateStrategyType indicates that the update is first applied to CanaryReplicas replicas
+	// with the highest ordinal numbers, and only proceeds to the remaining replicas once the canary replicas have
+	// been healthy for the configured soak time (or, for PromotionPolicyManual, once promoted explicitly).
+	CanaryManagedSeedSetUpdateStrategyType ManagedSeedSetUpdateStrategyType = "Canary"
 )
 
 // RollingUpdateManagedSeedSetStrategy is used to communicate parameter for RollingUpdateManagedSeedSetUpdateStrategyType.
 type RollingUpdateManagedSeedSetUpdateStrategy struct {
 	// Partition indicates the ordinal at which the ManagedSeedSet should be partitioned. Defaults to 0.
 	Partition *int32
+	// MaxUnavailable is the maximum number of ManagedSeeds that can be simultaneously updated (i.e. not at the
+	// update revision, or not yet Ready) during a rolling update, counted from the highest ordinal numbers down to
+	// Partition. Can be an absolute number or a percentage of the set's replicas. Defaults to 1.
+	MaxUnavailable *intstr.IntOrString
+	// Paused halts rollout progression when true: no further ManagedSeeds are moved to the update revision, but
+	// scaling (changing Replicas) still proceeds normally. Defaults to false.
+	Paused *bool
+}
+
+// CanaryManagedSeedSetUpdateStrategy is used to communicate parameters for CanaryManagedSeedSetUpdateStrategyType.
+type CanaryManagedSeedSetUpdateStrategy struct {
+	// CanaryReplicas is the number of replicas, counted from the highest ordinal numbers, that are updated to the
+	// new Template / ShootTemplate before the rest of the set is touched. Defaults to 1.
+	CanaryReplicas *int32
+	// PromotionPolicy determines whether the remaining replicas are rolled automatically once the canary replicas
+	// are healthy, or only after an operator promotes the rollout explicitly. Defaults to PromotionPolicyAutomatic.
+	PromotionPolicy *PromotionPolicy
+	// HealthSoakTime is the minimum duration the canary replicas must have been continuously healthy for before
+	// PromotionPolicyAutomatic proceeds to roll the remaining replicas. Defaults to 0 (promote as soon as healthy).
+	HealthSoakTime *metav1.Duration
 }
 
+// PromotionPolicy is a string enumeration type that enumerates the policies for promoting a canary rollout to the
+// rest of a ManagedSeedSet.
+type PromotionPolicy string
+
+const (
+	// PromotionPolicyAutomatic promotes a canary rollout to the remaining replicas as soon as the canary replicas
+	// have been healthy for HealthSoakTime, without requiring operator intervention.
+	PromotionPolicyAutomatic PromotionPolicy = "Automatic"
+	// PromotionPolicyManual holds a canary rollout at CanaryReplicas until an operator promotes it, e.g. by raising
+	// CanaryReplicas or switching the strategy back to RollingUpdate.
+	PromotionPolicyManual PromotionPolicy = "Manual"
+)
+
 // ManagedSeedSetStatus represents the current state of a ManagedSeedSet.
 type ManagedSeedSetStatus struct {
 	// ObservedGeneration is the most recent generation observed for this ManagedSeedSet. It corresponds to the
@@ -103,7 +170,9 @@ type ManagedSeedSetStatus struct {
 	Replicas int32
 	// ReadyReplicas is the number of ManagedSeeds created by the ManagedSeedSet controller that have a Ready Condition.
 	ReadyReplicas int32
-	// NextReplicaNumber is the ordinal number that will be assigned to the next replica of the ManagedSeedSet.
+	// NextReplicaNumber is the ordinal number that will be assigned to the next replica of the ManagedSeedSet. It
+	// only ever advances, including across a scale-down followed by a scale-up, so that an ordinal that has been
+	// deleted is never reused while older replicas sharing its name's Secrets/Shoot state could still be draining.
 	NextReplicaNumber int32
 	// CurrentReplicas is the number of ManagedSeeds created by the ManagedSeedSet controller from the ManagedSeedSet version
 	// indicated by CurrentRevision.
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 )
 
@@ -34,6 +35,12 @@ func SetDefaults_ManagedSeedSet(obj *ManagedSeedSet) {
 	if obj.Spec.RevisionHistoryLimit == nil {
 		obj.Spec.RevisionHistoryLimit = pointer.Int32Ptr(10)
 	}
+
+	// Set default replica management policy
+	if obj.Spec.ReplicaManagementPolicy == nil {
+		p := OrderedReadyReplicaManagementPolicy
+		obj.Spec.ReplicaManagementPolicy = &p
+	}
 }
 
 // SetDefaults_ManagedSeedSetUpdateStrategy sets default values for ManagedSeedSetUpdateStrategy objects.
@@ -44,11 +51,17 @@ func SetDefaults_ManagedSeedSetUpdateStrategy(obj *ManagedSeedSetUpdateStrategy)
 		obj.Type = &t
 	}
 
-	if *obj.Type == RollingUpdateManagedSeedSetUpdateStrategyType {
+	switch *obj.Type {
+	case RollingUpdateManagedSeedSetUpdateStrategyType:
 		// Set rolling update defaults
 		if obj.RollingUpdate == nil {
 			obj.RollingUpdate = &RollingUpdateManagedSeedSetUpdateStrategy{}
 		}
+	case CanaryManagedSeedSetUpdateStrategyType:
+		// Set canary defaults
+		if obj.Canary == nil {
+			obj.Canary = &CanaryManagedSeedSetUpdateStrategy{}
+		}
 	}
 }
 
@@ -58,4 +71,29 @@ func SetDefaults_RollingUpdateManagedSeedSetUpdateStrategy(obj *RollingUpdateMan
 	if obj.Partition == nil {
 		obj.Partition = pointer.Int32Ptr(0)
 	}
+
+	// Set default max unavailable
+	if obj.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		obj.MaxUnavailable = &maxUnavailable
+	}
+
+	// Set default paused
+	if obj.Paused == nil {
+		obj.Paused = pointer.BoolPtr(false)
+	}
+}
+
+// SetDefaults_CanaryManagedSeedSetUpdateStrategy sets default values for CanaryManagedSeedSetUpdateStrategy objects.
+func SetDefaults_CanaryManagedSeedSetUpdateStrategy(obj *CanaryManagedSeedSetUpdateStrategy) {
+	// Set default canary replicas
+	if obj.CanaryReplicas == nil {
+		obj.CanaryReplicas = pointer.Int32Ptr(1)
+	}
+
+	// Set default promotion policy
+	if obj.PromotionPolicy == nil {
+		p := PromotionPolicyAutomatic
+		obj.PromotionPolicy = &p
+	}
 }
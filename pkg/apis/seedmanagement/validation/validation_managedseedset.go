@@ -0,0 +1,90 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"github.com/gardener/gardener/pkg/apis/seedmanagement"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ValidateManagedSeedSet validates a ManagedSeedSet object.
+func ValidateManagedSeedSet(managedSeedSet *seedmanagement.ManagedSeedSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMeta(&managedSeedSet.ObjectMeta, true, apivalidation.NameIsDNSSubdomain, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateManagedSeedSetSpec(&managedSeedSet.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateManagedSeedSetUpdate validates a ManagedSeedSet object before an update.
+func ValidateManagedSeedSetUpdate(newManagedSeedSet, oldManagedSeedSet *seedmanagement.ManagedSeedSet) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, apivalidation.ValidateObjectMetaUpdate(&newManagedSeedSet.ObjectMeta, &oldManagedSeedSet.ObjectMeta, field.NewPath("metadata"))...)
+	allErrs = append(allErrs, ValidateManagedSeedSet(newManagedSeedSet)...)
+	return allErrs
+}
+
+// ValidateManagedSeedSetSpec validates the specification of a ManagedSeedSet object.
+func ValidateManagedSeedSetSpec(spec *seedmanagement.ManagedSeedSetSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Replicas != nil && *spec.Replicas < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("replicas"), *spec.Replicas, apivalidation.IsNegativeErrorMsg))
+	}
+
+	if spec.Selector == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("selector"), "selector is required"))
+	} else {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.Selector, fldPath.Child("selector"))...)
+
+		selector, err := metav1validation.LabelSelectorAsSelector(spec.Selector)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("selector"), spec.Selector, err.Error()))
+		} else {
+			allErrs = append(allErrs, validateSelectorMatchesLabels(selector, spec.Template.Labels, fldPath.Child("selector"), "spec.template.metadata.labels")...)
+			allErrs = append(allErrs, validateSelectorMatchesLabels(selector, spec.ShootTemplate.Labels, fldPath.Child("selector"), "spec.shootTemplate.metadata.labels")...)
+		}
+	}
+
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("revisionHistoryLimit"), *spec.RevisionHistoryLimit, apivalidation.IsNegativeErrorMsg))
+	}
+
+	if policy := spec.ReplicaManagementPolicy; policy != nil {
+		switch *policy {
+		case seedmanagement.OrderedReadyReplicaManagementPolicy, seedmanagement.ParallelReplicaManagementPolicy:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("replicaManagementPolicy"), *policy, []string{
+				string(seedmanagement.OrderedReadyReplicaManagementPolicy),
+				string(seedmanagement.ParallelReplicaManagementPolicy),
+			}))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSelectorMatchesLabels checks that selector matches labels, i.e. that every replica the template would
+// produce is actually counted by the ManagedSeedSet controller when it reconciles Spec.Replicas.
+func validateSelectorMatchesLabels(selector labels.Selector, templateLabels map[string]string, selectorPath *field.Path, templateLabelsFieldName string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !selector.Matches(labels.Set(templateLabels)) {
+		allErrs = append(allErrs, field.Invalid(selectorPath, selector.String(), "selector does not match "+templateLabelsFieldName))
+	}
+	return allErrs
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config contains the (partial) component configuration types for gardener-controller-manager that this
+// package's callers reference. It does not attempt to reproduce every field of the real configuration - only the
+// ones exercised by code in this checkout.
+package config
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// ControllerManagerConfiguration defines the configuration for the Gardener controller manager.
+type ControllerManagerConfiguration struct {
+	metav1.TypeMeta
+	// Controllers defines the configuration of the controllers.
+	Controllers ControllerManagerControllerConfiguration
+}
+
+// ControllerManagerControllerConfiguration defines the configuration of the controllers.
+type ControllerManagerControllerConfiguration struct {
+	// Seed is the configuration for the Seed controller.
+	Seed *SeedControllerConfiguration
+}
+
+// SeedControllerConfiguration defines the configuration of the Seed controller.
+type SeedControllerConfiguration struct {
+	// SyncPeriod is the duration how often the existing resources are reconciled.
+	SyncPeriod *metav1.Duration
+	// MonitorPeriod is the duration after the Seed lease expires before its GardenletReady condition is marked
+	// False.
+	MonitorPeriod *metav1.Duration
+	// ShootMonitorPeriod is the duration after which Shoots belonging to an unreachable Seed are marked as such.
+	ShootMonitorPeriod *metav1.Duration
+	// CloudEventsSink optionally configures a push-based CloudEvents 1.0 emitter for Seed lifecycle transitions
+	// (gardenlet readiness, backup bucket readiness). If nil, no events are emitted.
+	CloudEventsSink *CloudEventsSink
+	// Seed configures the queue that reconciles Seeds themselves.
+	Seed *QueueConfiguration
+	// SeedLifecycle configures the queue that processes Seed lease heartbeats.
+	SeedLifecycle *QueueConfiguration
+	// SeedBackupBucket configures the queue that reconciles the readiness of Seeds' BackupBuckets.
+	SeedBackupBucket *QueueConfiguration
+}
+
+// QueueConfiguration configures the concurrency, retry, and rate-limiting behaviour of a single work queue.
+type QueueConfiguration struct {
+	// ConcurrentSyncs is the number of workers processing this queue's items concurrently.
+	ConcurrentSyncs *int
+	// MaxRetries is the number of times an item is retried after a failed reconciliation before it is dropped and
+	// an event is recorded on the Seed instead of requeuing it indefinitely.
+	MaxRetries *int
+	// RateLimiter tunes the backoff applied to retried items. If nil, workqueue.DefaultControllerRateLimiter() is
+	// used.
+	RateLimiter *RateLimiterConfiguration
+}
+
+// RateLimiterConfiguration configures a workqueue.RateLimiter composed of a per-item exponential backoff and an
+// overall token-bucket limit, mirroring the composition workqueue.DefaultControllerRateLimiter uses internally.
+type RateLimiterConfiguration struct {
+	// BaseDelay is the backoff applied after an item's first failure.
+	BaseDelay *metav1.Duration
+	// MaxDelay caps the per-item exponential backoff.
+	MaxDelay *metav1.Duration
+	// Qps is the overall sustained rate, across all items, at which the queue may be worked.
+	Qps *float64
+	// Burst is the overall burst size allowed on top of Qps.
+	Burst *int
+}
+
+// CloudEventsSink configures an optional CloudEvents 1.0 (https://github.com/cloudevents/spec) sink that the Seed
+// controller publishes lifecycle transition events to.
+type CloudEventsSink struct {
+	// URL is the endpoint CloudEvents are delivered to.
+	URL string
+	// Transport selects the wire protocol used to deliver events. Only "http" is currently implemented; "nats" is
+	// accepted for forward compatibility but not yet supported.
+	Transport string
+	// AuthHeader, if set, is sent verbatim as the HTTP Authorization header on every delivery.
+	AuthHeader string
+}
+
+// CloudProfileControllerConfiguration defines the configuration of the CloudProfile controller.
+type CloudProfileControllerConfiguration struct {
+	// KubernetesVersionManagement configures automatic Kubernetes version lifecycle management.
+	KubernetesVersionManagement VersionManagementConfiguration
+	// MachineImageVersionManagement configures automatic machine image version lifecycle management.
+	MachineImageVersionManagement VersionManagementConfiguration
+	// AllowAnnotationOverrides controls whether individual CloudProfiles may override parts of
+	// KubernetesVersionManagement/MachineImageVersionManagement's policy via annotations. If false, any such
+	// annotations found on a CloudProfile are rejected via an Event and otherwise ignored.
+	AllowAnnotationOverrides bool
+	// DeletionGracePeriod is how long a CloudProfile that still has Shoots referencing it is kept blocked from
+	// deletion before the controller instead reports a MigrationRequired condition for it. Nil means deletion is
+	// blocked indefinitely, as before this field was introduced.
+	DeletionGracePeriod *metav1.Duration
+	// AutoMigrateShoots, if true, allows the controller to patch the spec.cloudProfileName of Shoots still
+	// referencing a CloudProfile pending deletion once DeletionGracePeriod has elapsed, provided the CloudProfile
+	// carries a migration-target annotation pinning where to. If false, the controller only ever suggests a
+	// target via the MigrationRequired condition and never mutates Shoots itself.
+	AutoMigrateShoots bool
+}
+
+// VersionManagementMode selects how a VersionManagementConfiguration axis applies the version changes it computes.
+type VersionManagementMode string
+
+const (
+	// VersionManagementModeEnforce applies computed version changes to the CloudProfile directly. This is the
+	// default if Mode is left empty, preserving the controller's original, pre-Mode behaviour.
+	VersionManagementModeEnforce VersionManagementMode = "Enforce"
+	// VersionManagementModeDryRun only reports the version changes that would have been applied, via events and
+	// metrics, without ever mutating the CloudProfile.
+	VersionManagementModeDryRun VersionManagementMode = "DryRun"
+	// VersionManagementModePropose creates or updates a CloudProfileVersionProposal for a human or GitOps pipeline
+	// to approve, and only applies the change once that proposal has been approved.
+	VersionManagementModePropose VersionManagementMode = "Propose"
+)
+
+// VersionManagementConfiguration configures one axis (Kubernetes or machine image versions) of automatic version
+// lifecycle management for CloudProfiles.
+type VersionManagementConfiguration struct {
+	// Enabled activates this axis of automatic version management.
+	Enabled bool
+	// Mode selects how computed version changes are applied. Defaults to VersionManagementModeEnforce if empty.
+	Mode VersionManagementMode
+	// ExpirationDuration is how long after release a version remains supported before it is given an expiration
+	// date. Zero means versions are never automatically given one.
+	ExpirationDuration *metav1.Duration
+	// ClassificationLifecycle configures how long a version spends in each classification before the controller
+	// advances it to the next one. Nil fields within it are left to advance solely by ExpirationDuration.
+	ClassificationLifecycle *ClassificationLifecycleConfiguration
+	// MaintainMinorVersions, if true, keeps the highest patch version of every still-supported minor version
+	// instead of only ever advancing the single latest overall version.
+	MaintainMinorVersions bool
+	// ExcludedVersions lists versions that this axis must never modify, regardless of what it would otherwise
+	// compute for them.
+	ExcludedVersions []string
+}
+
+// ClassificationLifecycleConfiguration configures how long a version spends in each VersionClassification before
+// the controller advances it to the next one (preview -> supported -> deprecated).
+type ClassificationLifecycleConfiguration struct {
+	// PreviewDuration is how long a version stays classified as preview before becoming supported.
+	PreviewDuration *metav1.Duration
+	// SupportedDuration is how long a version stays classified as supported before becoming deprecated.
+	SupportedDuration *metav1.Duration
+	// DeprecatedDuration is how long a version stays classified as deprecated before it is given an expiration
+	// date.
+	DeprecatedDuration *metav1.Duration
+}
@@ -21,6 +21,7 @@ import (
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+	"github.com/gardener/gardener/pkg/controllermanager/controller/bastion/sessionrecording"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/go-logr/logr"
 
@@ -31,9 +32,27 @@ import (
 )
 
 type reconciler struct {
-	logger       logr.Logger
-	gardenClient client.Client
-	maxLifetime  time.Duration
+	logger                 logr.Logger
+	gardenClient           client.Client
+	maxLifetime            time.Duration
+	sessionRecordingSink   sessionrecording.Sink
+	sessionRetentionPolicy operationsv1alpha1.BastionSessionRetentionPolicy
+}
+
+// purgeSessionRecordingsIfNeeded deletes bastion's recorded SSH sessions from the configured sink before the
+// Bastion itself is deleted, unless r.sessionRetentionPolicy is PreserveBastionSessionRetentionPolicy, in which
+// case the recordings outlive the Bastion (subject to the sink's own retention rules).
+func (r *reconciler) purgeSessionRecordingsIfNeeded(ctx context.Context, logger logr.Logger, bastion *operationsv1alpha1.Bastion) error {
+	if r.sessionRecordingSink == nil || r.sessionRetentionPolicy == operationsv1alpha1.PreserveBastionSessionRetentionPolicy {
+		return nil
+	}
+
+	if err := r.sessionRecordingSink.Purge(ctx, bastion.Namespace, bastion.Name); err != nil {
+		return fmt.Errorf("failed purging session recordings for bastion %s/%s: %w", bastion.Namespace, bastion.Name, err)
+	}
+	logger.Info("Purged session recordings for expired bastion")
+
+	return nil
 }
 
 // Reconcile reacts to updates on Bastion resources and also cleans up expired Bastions.
@@ -95,13 +114,21 @@ func (r *reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	// delete the bastion once it has expired
 	if bastion.Status.ExpirationTimestamp != nil && now.After(bastion.Status.ExpirationTimestamp.Time) {
-		logger.WithValues("expired", bastion.Status.ExpirationTimestamp.Time).Info("Deleting expired bastion")
+		logger = logger.WithValues("expired", bastion.Status.ExpirationTimestamp.Time)
+		logger.Info("Deleting expired bastion")
+		if err := r.purgeSessionRecordingsIfNeeded(ctx, logger, bastion); err != nil {
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{}, client.IgnoreNotFound(r.gardenClient.Delete(ctx, bastion))
 	}
 
 	// delete the bastion once it has reached its maximum lifetime
 	if time.Since(bastion.CreationTimestamp.Time) > r.maxLifetime {
-		logger.WithValues("created", bastion.CreationTimestamp.Time).Info("Deleting bastion because it reached its maximum lifetime")
+		logger = logger.WithValues("created", bastion.CreationTimestamp.Time)
+		logger.Info("Deleting bastion because it reached its maximum lifetime")
+		if err := r.purgeSessionRecordingsIfNeeded(ctx, logger, bastion); err != nil {
+			return reconcile.Result{}, err
+		}
 		return reconcile.Result{}, client.IgnoreNotFound(r.gardenClient.Delete(ctx, bastion))
 	}
 
@@ -0,0 +1,81 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sessionrecording
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// eventSinkReason is the Event reason the bastion agent/sidecar uses when it reports session metadata; metadata is
+// carried as JSON in the Event message, since a plain Event has no structured payload field.
+const eventSinkReason = "BastionSessionRecorded"
+
+// eventSink reads and purges session metadata that the bastion agent/sidecar records as Kubernetes Events on the
+// Bastion object. It requires no additional infrastructure; full session transcripts are not retained by this sink,
+// only the session metadata itself.
+type eventSink struct {
+	client client.Client
+}
+
+// NewEventSink returns a Sink backed by Kubernetes Events on the Bastion object.
+func NewEventSink(c client.Client) Sink {
+	return &eventSink{client: c}
+}
+
+func (s *eventSink) ListSessions(ctx context.Context, bastionNamespace, bastionName string) ([]operationsv1alpha1.BastionSessionRecord, error) {
+	events := &corev1.EventList{}
+	if err := s.client.List(ctx, events, client.InNamespace(bastionNamespace), client.MatchingFields{
+		"involvedObject.name": bastionName,
+		"reason":              eventSinkReason,
+	}); err != nil {
+		return nil, fmt.Errorf("failed listing session recording events for bastion %s/%s: %w", bastionNamespace, bastionName, err)
+	}
+
+	sessions := make([]operationsv1alpha1.BastionSessionRecord, 0, len(events.Items))
+	for _, event := range events.Items {
+		var session operationsv1alpha1.BastionSessionRecord
+		if err := json.Unmarshal([]byte(event.Message), &session); err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (s *eventSink) Purge(ctx context.Context, bastionNamespace, bastionName string) error {
+	events := &corev1.EventList{}
+	if err := s.client.List(ctx, events, client.InNamespace(bastionNamespace), client.MatchingFields{
+		"involvedObject.name": bastionName,
+		"reason":              eventSinkReason,
+	}); err != nil {
+		return fmt.Errorf("failed listing session recording events for bastion %s/%s: %w", bastionNamespace, bastionName, err)
+	}
+
+	for i := range events.Items {
+		if err := client.IgnoreNotFound(s.client.Delete(ctx, &events.Items[i])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
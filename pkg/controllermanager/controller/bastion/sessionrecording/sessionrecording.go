@@ -0,0 +1,34 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sessionrecording reads SSH session metadata recorded by the bastion agent/sidecar from a sink, and lets the
+// bastion controller purge or preserve those recordings once their Bastion is garbage-collected.
+package sessionrecording
+
+import (
+	"context"
+
+	operationsv1alpha1 "github.com/gardener/gardener/pkg/apis/operations/v1alpha1"
+)
+
+// Sink lists and retires Bastion session recordings. The only implementation in this package is backed by
+// Kubernetes Events on the Bastion (see NewEventSink); there is no S3-compatible object store or Loki
+// implementation. The bastion controller itself only depends on this interface.
+type Sink interface {
+	// ListSessions returns every session recorded for the given Bastion so far.
+	ListSessions(ctx context.Context, bastionNamespace, bastionName string) ([]operationsv1alpha1.BastionSessionRecord, error)
+	// Purge deletes every recording for the given Bastion from the sink. Called when the Bastion is garbage
+	// collected and its BastionSessionRetentionPolicy is PurgeBastionSessionRetentionPolicy.
+	Purge(ctx context.Context, bastionNamespace, bastionName string) error
+}
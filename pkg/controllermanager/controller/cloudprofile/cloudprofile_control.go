@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -96,15 +97,16 @@ type ControlInterface interface {
 
 // NewDefaultControl returns a new instance of the default implementation ControlInterface that
 // implements the documented semantics for CloudProfiles.
-func NewDefaultControl(config *controllermgrconfig.CloudProfileControllerConfiguration, k8sGardenClient kubernetes.Interface, shootLister gardencorelisters.ShootLister, recorder record.EventRecorder) ControlInterface {
-	return &defaultControl{config, k8sGardenClient, shootLister, recorder}
+func NewDefaultControl(config *controllermgrconfig.CloudProfileControllerConfiguration, k8sGardenClient kubernetes.Interface, shootLister gardencorelisters.ShootLister, cloudProfileLister gardencorelisters.CloudProfileLister, recorder record.EventRecorder) ControlInterface {
+	return &defaultControl{config, k8sGardenClient, shootLister, cloudProfileLister, recorder}
 }
 
 type defaultControl struct {
-	config          *controllermgrconfig.CloudProfileControllerConfiguration
-	k8sGardenClient kubernetes.Interface
-	shootLister     gardencorelisters.ShootLister
-	recorder        record.EventRecorder
+	config             *controllermgrconfig.CloudProfileControllerConfiguration
+	k8sGardenClient    kubernetes.Interface
+	shootLister        gardencorelisters.ShootLister
+	cloudProfileLister gardencorelisters.CloudProfileLister
+	recorder           record.EventRecorder
 }
 
 func (c *defaultControl) ReconcileCloudProfile(ctx context.Context, obj *gardencorev1beta1.CloudProfile, cloudProfileLogger *logrus.Entry) error {
@@ -147,7 +149,17 @@ func (c *defaultControl) ReconcileCloudProfile(ctx context.Context, obj *gardenc
 		cloudProfileLogger.Info(message)
 		c.recorder.Event(cloudProfile, corev1.EventTypeNormal, v1beta1constants.EventResourceReferenced, message)
 
-		return errors.New("CloudProfile still has references")
+		if statusErr := c.patchCloudProfileStatus(ctx, cloudProfile, func(status *gardencorev1beta1.CloudProfileStatus) {
+			status.Conditions = deletionBlockedCondition(status.Conditions, associatedShoots)
+		}); statusErr != nil {
+			cloudProfileLogger.Errorf("failed to patch CloudProfile status with DeletionBlocked condition: %v", statusErr)
+		}
+
+		if c.config.DeletionGracePeriod == nil || time.Since(cloudProfile.DeletionTimestamp.Time) < c.config.DeletionGracePeriod.Duration {
+			return errors.New("CloudProfile still has references")
+		}
+
+		return c.handleOrphanedShoots(ctx, cloudProfile, associatedShoots, cloudProfileLogger)
 	}
 
 	if err := controllerutils.EnsureFinalizer(context.TODO(), c.k8sGardenClient.Client(), cloudProfile, gardencorev1beta1.GardenerName); err != nil {
@@ -155,16 +167,50 @@ func (c *defaultControl) ReconcileCloudProfile(ctx context.Context, obj *gardenc
 		return err
 	}
 
+	associatedShoots, err := controllerutils.DetermineShootsAssociatedTo(cloudProfile, c.shootLister)
+	if err != nil {
+		cloudProfileLogger.Error(err.Error())
+		return err
+	}
+
 	profile := cloudProfile.DeepCopy()
+	var kubernetesVersionsErr, machineImageVersionsErr error
+
 	if c.config.KubernetesVersionManagement.Enabled {
-		if profile, err = kubernetesversion.ReconcileKubernetesVersions(cloudProfileLogger, c.config, profile); err != nil {
-			return err
+		effectiveConfig := *c.config
+		effectiveConfig.KubernetesVersionManagement = c.effectiveVersionManagementConfiguration(cloudProfile, "Kubernetes", AnnotationKubernetesVersionManagementPolicy, c.config.KubernetesVersionManagement)
+
+		candidate, reconcileErr := kubernetesversion.ReconcileKubernetesVersions(cloudProfileLogger, &effectiveConfig, cloudProfile.DeepCopy())
+		kubernetesVersionsErr = reconcileErr
+		if reconcileErr != nil {
+			cloudProfileLogger.Errorf("failed to reconcile Kubernetes versions: %v", reconcileErr)
+		} else {
+			changes := diffVersions(cloudProfile.Spec.Kubernetes.Versions, candidate.Spec.Kubernetes.Versions)
+			apply, err := c.handleVersionManagementChanges(ctx, "Kubernetes", cloudProfile, c.config.KubernetesVersionManagement.Mode, changes)
+			if err != nil {
+				cloudProfileLogger.Errorf("failed to process Kubernetes version management mode: %v", err)
+			} else if apply {
+				profile.Spec.Kubernetes.Versions = candidate.Spec.Kubernetes.Versions
+			}
 		}
 	}
 
 	if c.config.MachineImageVersionManagement.Enabled {
-		if profile, err = machineversion.ReconcileMachineImageVersions(cloudProfileLogger, c.config, profile); err != nil {
-			return err
+		effectiveConfig := *c.config
+		effectiveConfig.MachineImageVersionManagement = c.effectiveVersionManagementConfiguration(cloudProfile, "MachineImage", AnnotationMachineImageVersionManagementPolicy, c.config.MachineImageVersionManagement)
+
+		candidate, reconcileErr := machineversion.ReconcileMachineImageVersions(cloudProfileLogger, &effectiveConfig, cloudProfile.DeepCopy())
+		machineImageVersionsErr = reconcileErr
+		if reconcileErr != nil {
+			cloudProfileLogger.Errorf("failed to reconcile machine image versions: %v", machineImageVersionsErr)
+		} else {
+			changes := diffMachineImageVersions(cloudProfile.Spec.MachineImages, candidate.Spec.MachineImages)
+			apply, err := c.handleVersionManagementChanges(ctx, "MachineImage", cloudProfile, c.config.MachineImageVersionManagement.Mode, changes)
+			if err != nil {
+				cloudProfileLogger.Errorf("failed to process machine image version management mode: %v", err)
+			} else if apply {
+				profile.Spec.MachineImages = candidate.Spec.MachineImages
+			}
 		}
 	}
 
@@ -175,5 +221,22 @@ func (c *defaultControl) ReconcileCloudProfile(ctx context.Context, obj *gardenc
 		}
 	}
 
+	if statusErr := c.patchCloudProfileStatus(ctx, profile, func(status *gardencorev1beta1.CloudProfileStatus) {
+		status.Conditions = versionManagementCondition(status.Conditions, ConditionKubernetesVersionsReconciled, c.config.KubernetesVersionManagement.Enabled, kubernetesVersionsErr)
+		status.Conditions = versionManagementCondition(status.Conditions, ConditionMachineImageVersionsReconciled, c.config.MachineImageVersionManagement.Enabled, machineImageVersionsErr)
+		status.Conditions = referencedByShootsCondition(status.Conditions, associatedShoots)
+		status.ObservedKubernetesVersions = observeKubernetesVersions(profile.Spec.Kubernetes.Versions)
+		status.ObservedMachineImageVersions = observeMachineImageVersions(profile.Spec.MachineImages)
+	}); statusErr != nil {
+		cloudProfileLogger.Errorf("failed to patch CloudProfile status for '%s': %v", obj.Name, statusErr)
+	}
+
+	if kubernetesVersionsErr != nil {
+		return kubernetesVersionsErr
+	}
+	if machineImageVersionsErr != nil {
+		return machineImageVersionsErr
+	}
+
 	return nil
 }
@@ -0,0 +1,44 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprofile
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var metricVersionProposedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gardener",
+	Subsystem: "cloudprofile",
+	Name:      "version_proposed_total",
+	Help:      "Number of version changes the CloudProfile controller computed for a DryRun or Propose axis, by version kind, CloudProfile, and action.",
+}, []string{"kind", "profile", "action"})
+
+var metricMigrationRequiredTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gardener",
+	Subsystem: "cloudprofile",
+	Name:      "migration_required_total",
+	Help:      "Number of times the CloudProfile controller found a CloudProfile pending deletion whose DeletionGracePeriod elapsed while Shoots still referenced it.",
+}, []string{"profile"})
+
+var metricShootsAutoMigratedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gardener",
+	Subsystem: "cloudprofile",
+	Name:      "shoots_auto_migrated_total",
+	Help:      "Number of Shoots the CloudProfile controller automatically migrated to a different CloudProfile.",
+}, []string{"profile", "target"})
+
+func init() {
+	prometheus.MustRegister(metricVersionProposedTotal, metricMigrationRequiredTotal, metricShootsAutoMigratedTotal)
+}
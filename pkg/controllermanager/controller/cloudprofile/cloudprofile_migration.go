@@ -0,0 +1,194 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprofile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllerutils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AnnotationMigrationTarget, if present on a CloudProfile pending deletion whose DeletionGracePeriod has elapsed,
+// pins the name of the CloudProfile its referencing Shoots should be migrated to. It is only acted upon - patching
+// the referencing Shoots' spec.cloudProfileName - if CloudProfileControllerConfiguration.AutoMigrateShoots is set.
+const AnnotationMigrationTarget = "cloudprofile.core.gardener.cloud/migration-target"
+
+// handleOrphanedShoots is invoked once a CloudProfile pending deletion has had Shoots referencing it for longer
+// than DeletionGracePeriod. It reports a MigrationRequired condition naming those Shoots and a suggested target
+// CloudProfile (or AnnotationMigrationTarget's value, if set), and - only if AutoMigrateShoots is enabled and
+// AnnotationMigrationTarget pins a target - patches the Shoots over to it and removes cloudProfile's finalizer.
+func (c *defaultControl) handleOrphanedShoots(ctx context.Context, cloudProfile *gardencorev1beta1.CloudProfile, associatedShoots []string, cloudProfileLogger *logrus.Entry) error {
+	pinnedTarget := cloudProfile.Annotations[AnnotationMigrationTarget]
+
+	suggestedTarget := pinnedTarget
+	if suggestedTarget == "" {
+		computed, err := c.suggestMigrationTarget(cloudProfile)
+		if err != nil {
+			cloudProfileLogger.Errorf("failed to compute a suggested migration target for CloudProfile %q: %v", cloudProfile.Name, err)
+		}
+		suggestedTarget = computed
+	}
+
+	if suggestedTarget == "" {
+		c.recorder.Eventf(cloudProfile, corev1.EventTypeWarning, reasonNoMigrationTarget, "Deletion grace period elapsed, but no migration target CloudProfile could be found for the Shoots still referencing it: %+v", associatedShoots)
+	} else {
+		c.recorder.Eventf(cloudProfile, corev1.EventTypeWarning, reasonMigrationSuggested, "Deletion grace period elapsed; suggesting migration of Shoots %+v to CloudProfile %q", associatedShoots, suggestedTarget)
+	}
+	metricMigrationRequiredTotal.WithLabelValues(cloudProfile.Name).Inc()
+
+	if statusErr := c.patchCloudProfileStatus(ctx, cloudProfile, func(status *gardencorev1beta1.CloudProfileStatus) {
+		status.Conditions = migrationRequiredCondition(status.Conditions, associatedShoots, suggestedTarget)
+	}); statusErr != nil {
+		cloudProfileLogger.Errorf("failed to patch CloudProfile status with MigrationRequired condition: %v", statusErr)
+	}
+
+	if !c.config.AutoMigrateShoots || pinnedTarget == "" {
+		return fmt.Errorf("CloudProfile still has references; migration to %q required but not yet performed", suggestedTarget)
+	}
+
+	if err := c.migrateShoots(ctx, associatedShoots, pinnedTarget); err != nil {
+		cloudProfileLogger.Errorf("failed to auto-migrate Shoots off CloudProfile %q to %q: %v", cloudProfile.Name, pinnedTarget, err)
+		return err
+	}
+
+	c.recorder.Eventf(cloudProfile, corev1.EventTypeNormal, reasonShootsMigrated, "Automatically migrated Shoots %+v to CloudProfile %q", associatedShoots, pinnedTarget)
+	metricShootsAutoMigratedTotal.WithLabelValues(cloudProfile.Name, pinnedTarget).Add(float64(len(associatedShoots)))
+
+	finalizers := sets.NewString(cloudProfile.Finalizers...)
+	finalizers.Delete(gardencorev1beta1.GardenerName)
+	cloudProfile.Finalizers = finalizers.UnsortedList()
+
+	return controllerutils.RemoveFinalizer(ctx, c.k8sGardenClient.Client(), cloudProfile, gardencorev1beta1.GardenerName)
+}
+
+// migrateShoots patches spec.cloudProfileName to targetCloudProfileName on every Shoot named by shootKeys
+// ("namespace/name", as returned by controllerutils.DetermineShootsAssociatedTo).
+func (c *defaultControl) migrateShoots(ctx context.Context, shootKeys []string, targetCloudProfileName string) error {
+	for _, key := range shootKeys {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return fmt.Errorf("invalid Shoot key %q: %w", key, err)
+		}
+
+		shoot := &gardencorev1beta1.Shoot{}
+		if err := c.k8sGardenClient.Client().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, shoot); err != nil {
+			return err
+		}
+
+		patch := client.MergeFrom(shoot.DeepCopy())
+		shoot.Spec.CloudProfileName = targetCloudProfileName
+		if err := c.k8sGardenClient.Client().Patch(ctx, shoot, patch); err != nil {
+			return fmt.Errorf("failed to patch Shoot %q to CloudProfile %q: %w", key, targetCloudProfileName, err)
+		}
+	}
+
+	return nil
+}
+
+// suggestMigrationTarget picks the other CloudProfile of the same Spec.Type with the highest region, Kubernetes
+// version, and machine image version overlap with cloudProfile. It returns "" if no other CloudProfile of the same
+// type overlaps with cloudProfile at all.
+func (c *defaultControl) suggestMigrationTarget(cloudProfile *gardencorev1beta1.CloudProfile) (string, error) {
+	candidates, err := c.cloudProfileLister.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+
+	var bestName string
+	var bestScore int
+	for _, candidate := range candidates {
+		if candidate.Name == cloudProfile.Name || candidate.Spec.Type != cloudProfile.Spec.Type {
+			continue
+		}
+
+		score := regionOverlap(cloudProfile.Spec.Regions, candidate.Spec.Regions) +
+			kubernetesVersionOverlap(cloudProfile.Spec.Kubernetes.Versions, candidate.Spec.Kubernetes.Versions) +
+			machineImageOverlap(cloudProfile.Spec.MachineImages, candidate.Spec.MachineImages)
+		if score <= 0 {
+			continue
+		}
+
+		if bestName == "" || score > bestScore {
+			bestName, bestScore = candidate.Name, score
+		}
+	}
+
+	return bestName, nil
+}
+
+func regionOverlap(source, target []gardencorev1beta1.Region) int {
+	names := make(map[string]struct{}, len(target))
+	for _, region := range target {
+		names[region.Name] = struct{}{}
+	}
+
+	var overlap int
+	for _, region := range source {
+		if _, ok := names[region.Name]; ok {
+			overlap++
+		}
+	}
+	return overlap
+}
+
+func kubernetesVersionOverlap(source, target []gardencorev1beta1.ExpirableVersion) int {
+	versions := make(map[string]struct{}, len(target))
+	for _, v := range target {
+		versions[v.Version] = struct{}{}
+	}
+
+	var overlap int
+	for _, v := range source {
+		if _, ok := versions[v.Version]; ok {
+			overlap++
+		}
+	}
+	return overlap
+}
+
+func machineImageOverlap(source, target []gardencorev1beta1.MachineImage) int {
+	targetVersions := make(map[string]map[string]struct{}, len(target))
+	for _, image := range target {
+		versions := make(map[string]struct{}, len(image.Versions))
+		for _, v := range image.Versions {
+			versions[v.Version] = struct{}{}
+		}
+		targetVersions[image.Name] = versions
+	}
+
+	var overlap int
+	for _, image := range source {
+		versions, ok := targetVersions[image.Name]
+		if !ok {
+			continue
+		}
+		for _, v := range image.Versions {
+			if _, ok := versions[v.Version]; ok {
+				overlap++
+			}
+		}
+	}
+	return overlap
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprofile
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	controllermgrconfig "github.com/gardener/gardener/pkg/controllermanager/apis/config"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// AnnotationKubernetesVersionManagementPolicy, if present on a CloudProfile, carries a JSON-encoded
+	// VersionManagementPolicyOverride that overrides KubernetesVersionManagement's policy for that CloudProfile,
+	// provided the controller-wide AllowAnnotationOverrides gate permits it.
+	AnnotationKubernetesVersionManagementPolicy = "cloudprofile.core.gardener.cloud/kubernetes-version-management"
+	// AnnotationMachineImageVersionManagementPolicy is AnnotationKubernetesVersionManagementPolicy's counterpart
+	// for MachineImageVersionManagement.
+	AnnotationMachineImageVersionManagementPolicy = "cloudprofile.core.gardener.cloud/machine-image-version-management"
+
+	reasonVersionManagementPolicyInvalid    = "VersionManagementPolicyInvalid"
+	reasonVersionManagementPolicyForbidden  = "VersionManagementPolicyForbidden"
+	versionManagementPolicyForbiddenMessage = "ignoring annotation %q: per-CloudProfile version management overrides are disabled landscape-wide"
+)
+
+// VersionManagementPolicyOverride is the schema of the JSON payload carried by
+// AnnotationKubernetesVersionManagementPolicy/AnnotationMachineImageVersionManagementPolicy. Every field is
+// optional; a nil field leaves the corresponding global VersionManagementConfiguration field untouched.
+type VersionManagementPolicyOverride struct {
+	// ExpirationDuration overrides VersionManagementConfiguration.ExpirationDuration.
+	ExpirationDuration *metav1.Duration `json:"expirationDuration,omitempty"`
+	// ClassificationLifecycle overrides VersionManagementConfiguration.ClassificationLifecycle.
+	ClassificationLifecycle *controllermgrconfig.ClassificationLifecycleConfiguration `json:"classificationLifecycle,omitempty"`
+	// MaintainMinorVersions overrides VersionManagementConfiguration.MaintainMinorVersions.
+	MaintainMinorVersions *bool `json:"maintainMinorVersions,omitempty"`
+	// ExcludedVersions overrides VersionManagementConfiguration.ExcludedVersions.
+	ExcludedVersions []string `json:"excludedVersions,omitempty"`
+}
+
+// effectiveVersionManagementConfiguration resolves the VersionManagementConfiguration the given kind (Kubernetes or
+// MachineImage) should reconcile with for cloudProfile: it parses and validates cloudProfile's annotation (if any),
+// and either merges it onto global, or - if annotation overrides are disabled, or the annotation is malformed -
+// falls back to global unchanged, in both cases recording an Event explaining why.
+func (c *defaultControl) effectiveVersionManagementConfiguration(cloudProfile *gardencorev1beta1.CloudProfile, kind, annotationKey string, global controllermgrconfig.VersionManagementConfiguration) controllermgrconfig.VersionManagementConfiguration {
+	raw, ok := cloudProfile.Annotations[annotationKey]
+	if !ok {
+		return global
+	}
+
+	if !c.config.AllowAnnotationOverrides {
+		c.recorder.Eventf(cloudProfile, corev1.EventTypeWarning, reasonVersionManagementPolicyForbidden, versionManagementPolicyForbiddenMessage, annotationKey)
+		return global
+	}
+
+	override, errs := parseVersionManagementPolicyOverride(raw, field.NewPath("metadata", "annotations").Key(annotationKey))
+	if len(errs) > 0 {
+		c.recorder.Eventf(cloudProfile, corev1.EventTypeWarning, reasonVersionManagementPolicyInvalid, "ignoring malformed %s version management policy annotation %q: %v", kind, annotationKey, errs.ToAggregate())
+		return global
+	}
+
+	return mergeVersionManagementConfiguration(global, override)
+}
+
+// parseVersionManagementPolicyOverride unmarshals and validates raw as a VersionManagementPolicyOverride.
+func parseVersionManagementPolicyOverride(raw string, fldPath *field.Path) (*VersionManagementPolicyOverride, field.ErrorList) {
+	override := &VersionManagementPolicyOverride{}
+	if err := json.Unmarshal([]byte(raw), override); err != nil {
+		return nil, field.ErrorList{field.Invalid(fldPath, raw, fmt.Sprintf("must be a valid JSON-encoded version management policy: %v", err))}
+	}
+	return override, validateVersionManagementPolicyOverride(override, fldPath)
+}
+
+func validateVersionManagementPolicyOverride(override *VersionManagementPolicyOverride, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if override.ExpirationDuration != nil && override.ExpirationDuration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("expirationDuration"), override.ExpirationDuration.Duration.String(), "must not be negative"))
+	}
+
+	if lifecycle := override.ClassificationLifecycle; lifecycle != nil {
+		lifecyclePath := fldPath.Child("classificationLifecycle")
+		for name, duration := range map[string]*metav1.Duration{
+			"previewDuration":    lifecycle.PreviewDuration,
+			"supportedDuration":  lifecycle.SupportedDuration,
+			"deprecatedDuration": lifecycle.DeprecatedDuration,
+		} {
+			if duration != nil && duration.Duration < 0 {
+				allErrs = append(allErrs, field.Invalid(lifecyclePath.Child(name), duration.Duration.String(), "must not be negative"))
+			}
+		}
+	}
+
+	for i, version := range override.ExcludedVersions {
+		if version == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("excludedVersions").Index(i), "must not be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+// mergeVersionManagementConfiguration overlays override onto global, leaving global's Enabled/Mode untouched -
+// only the version-lifecycle policy fields are annotation-overridable, not whether or how the axis is applied.
+func mergeVersionManagementConfiguration(global controllermgrconfig.VersionManagementConfiguration, override *VersionManagementPolicyOverride) controllermgrconfig.VersionManagementConfiguration {
+	effective := global
+
+	if override.ExpirationDuration != nil {
+		effective.ExpirationDuration = override.ExpirationDuration
+	}
+	if override.ClassificationLifecycle != nil {
+		effective.ClassificationLifecycle = override.ClassificationLifecycle
+	}
+	if override.MaintainMinorVersions != nil {
+		effective.MaintainMinorVersions = *override.MaintainMinorVersions
+	}
+	if override.ExcludedVersions != nil {
+		effective.ExcludedVersions = override.ExcludedVersions
+	}
+
+	return effective
+}
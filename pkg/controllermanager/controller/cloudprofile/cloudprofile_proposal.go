@@ -0,0 +1,178 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprofile
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	controllermgrconfig "github.com/gardener/gardener/pkg/controllermanager/apis/config"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// versionChange is a single version addition, deprecation, or expiration that diffVersions/diffMachineImageVersions
+// found between a CloudProfile's current spec and the candidate computed by ReconcileKubernetesVersions or
+// ReconcileMachineImageVersions.
+type versionChange struct {
+	imageName string
+	version   string
+	action    string
+}
+
+const (
+	versionActionAdded      = "Added"
+	versionActionDeprecated = "Deprecated"
+	versionActionExpired    = "Expired"
+)
+
+// diffVersions compares before and after (both cloudProfile.Spec.Kubernetes.Versions, or a single machine image's
+// Versions) and reports every version that was added, newly classified as deprecated, or newly given an
+// expiration date.
+func diffVersions(before, after []gardencorev1beta1.ExpirableVersion) []versionChange {
+	existing := make(map[string]gardencorev1beta1.ExpirableVersion, len(before))
+	for _, v := range before {
+		existing[v.Version] = v
+	}
+
+	var changes []versionChange
+	for _, v := range after {
+		old, found := existing[v.Version]
+		if !found {
+			changes = append(changes, versionChange{version: v.Version, action: versionActionAdded})
+			continue
+		}
+		if (old.Classification == nil || *old.Classification != gardencorev1beta1.ClassificationDeprecated) &&
+			v.Classification != nil && *v.Classification == gardencorev1beta1.ClassificationDeprecated {
+			changes = append(changes, versionChange{version: v.Version, action: versionActionDeprecated})
+		}
+		if old.ExpirationDate == nil && v.ExpirationDate != nil {
+			changes = append(changes, versionChange{version: v.Version, action: versionActionExpired})
+		}
+	}
+	return changes
+}
+
+// diffMachineImageVersions is diffVersions scoped across every machine image in a CloudProfile.
+func diffMachineImageVersions(before, after []gardencorev1beta1.MachineImage) []versionChange {
+	existing := make(map[string][]gardencorev1beta1.ExpirableVersion, len(before))
+	for _, image := range before {
+		existing[image.Name] = image.Versions
+	}
+
+	var changes []versionChange
+	for _, image := range after {
+		for _, change := range diffVersions(existing[image.Name], image.Versions) {
+			change.imageName = image.Name
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// handleVersionManagementChanges applies mode's policy to changes computed for one version-management axis (kind
+// is "Kubernetes" or "MachineImage"). It returns whether the caller should fold the recomputed versions for this
+// axis into the CloudProfile that gets persisted.
+func (c *defaultControl) handleVersionManagementChanges(ctx context.Context, kind string, cloudProfile *gardencorev1beta1.CloudProfile, mode controllermgrconfig.VersionManagementMode, changes []versionChange) (bool, error) {
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	switch mode {
+	case controllermgrconfig.VersionManagementModeDryRun:
+		c.recordDryRun(cloudProfile, kind, changes)
+		return false, nil
+
+	case controllermgrconfig.VersionManagementModePropose:
+		return c.reconcileVersionProposal(ctx, cloudProfile, kind, changes)
+
+	default:
+		return true, nil
+	}
+}
+
+// recordDryRun surfaces changes as both a Kubernetes Event on cloudProfile and a
+// gardener_cloudprofile_version_proposed_total increment, without ever mutating cloudProfile.
+func (c *defaultControl) recordDryRun(cloudProfile *gardencorev1beta1.CloudProfile, kind string, changes []versionChange) {
+	for _, change := range changes {
+		subject := change.version
+		if change.imageName != "" {
+			subject = fmt.Sprintf("%s/%s", change.imageName, change.version)
+		}
+		c.recorder.Eventf(cloudProfile, corev1.EventTypeNormal, "VersionManagementDryRun", "[dry-run] would %s %s version %s", change.action, kind, subject)
+		metricVersionProposedTotal.WithLabelValues(kind, cloudProfile.Name, change.action).Inc()
+	}
+}
+
+// reconcileVersionProposal creates or updates the CloudProfileVersionProposal for cloudProfile/kind with changes.
+// It returns true only once an operator has approved a proposal whose Changes still match the ones just computed,
+// at which point it also marks that proposal CloudProfileVersionProposalApplied.
+func (c *defaultControl) reconcileVersionProposal(ctx context.Context, cloudProfile *gardencorev1beta1.CloudProfile, kind string, changes []versionChange) (bool, error) {
+	desired := toProposedVersionChanges(changes)
+
+	existing := &gardencorev1beta1.CloudProfileVersionProposal{ObjectMeta: metav1.ObjectMeta{Name: versionProposalName(cloudProfile.Name, kind)}}
+	err := c.k8sGardenClient.Client().Get(ctx, client.ObjectKeyFromObject(existing), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err == nil && existing.Status.Phase == gardencorev1beta1.CloudProfileVersionProposalApproved && reflect.DeepEqual(existing.Spec.Changes, desired) {
+		existing.Status.Phase = gardencorev1beta1.CloudProfileVersionProposalApplied
+		if err := c.k8sGardenClient.Client().Status().Update(ctx, existing); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	proposal := &gardencorev1beta1.CloudProfileVersionProposal{ObjectMeta: metav1.ObjectMeta{Name: versionProposalName(cloudProfile.Name, kind)}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, c.k8sGardenClient.Client(), proposal, func() error {
+		// A proposal whose Changes no longer match what was last computed is stale - reset it to Pending so it is
+		// not silently auto-applied on the strength of an approval that covered a different diff.
+		if !reflect.DeepEqual(proposal.Spec.Changes, desired) {
+			proposal.Status.Phase = gardencorev1beta1.CloudProfileVersionProposalPending
+		}
+		proposal.Spec.CloudProfileName = cloudProfile.Name
+		proposal.Spec.Kind = kind
+		proposal.Spec.Changes = desired
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+func toProposedVersionChanges(changes []versionChange) []gardencorev1beta1.ProposedVersionChange {
+	proposed := make([]gardencorev1beta1.ProposedVersionChange, 0, len(changes))
+	for _, change := range changes {
+		proposed = append(proposed, gardencorev1beta1.ProposedVersionChange{
+			ImageName: change.imageName,
+			Version:   change.version,
+			Action:    change.action,
+		})
+	}
+	return proposed
+}
+
+func versionProposalName(cloudProfileName, kind string) string {
+	return fmt.Sprintf("%s-%s", cloudProfileName, strings.ToLower(kind))
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudprofile
+
+import (
+	"context"
+	"fmt"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"k8s.io/client-go/util/retry"
+)
+
+// Condition types maintained on a CloudProfile's status by ReconcileCloudProfile, giving operators a visible signal
+// for what the auto-version-management subsystem did (or why it could not act) and whether deletion is currently
+// withheld, mirroring the granular, reason-carrying condition style used for Shoot maintenance (see
+// controller/shoot/maintenance_conditions.go).
+const (
+	// ConditionKubernetesVersionsReconciled reports the outcome of the last ReconcileKubernetesVersions run.
+	ConditionKubernetesVersionsReconciled gardencorev1beta1.ConditionType = "KubernetesVersionsReconciled"
+	// ConditionMachineImageVersionsReconciled reports the outcome of the last ReconcileMachineImageVersions run.
+	ConditionMachineImageVersionsReconciled gardencorev1beta1.ConditionType = "MachineImageVersionsReconciled"
+	// ConditionReferencedByShoots is True if one or more Shoots currently reference this CloudProfile.
+	ConditionReferencedByShoots gardencorev1beta1.ConditionType = "ReferencedByShoots"
+	// ConditionDeletionBlocked is True while deletion is withheld because Shoots still reference this CloudProfile.
+	ConditionDeletionBlocked gardencorev1beta1.ConditionType = "DeletionBlocked"
+	// ConditionMigrationRequired is True once a CloudProfile's DeletionGracePeriod has elapsed while Shoots still
+	// reference it, reporting those Shoots and, if one could be found, a suggested target CloudProfile to migrate
+	// them to.
+	ConditionMigrationRequired gardencorev1beta1.ConditionType = "MigrationRequired"
+)
+
+const (
+	reasonManagementDisabled     = "ManagementDisabled"
+	reasonReconciled             = "Reconciled"
+	reasonReconcileFailed        = "ReconcileFailed"
+	reasonReferenced             = "Referenced"
+	reasonNotReferenced          = "NotReferenced"
+	reasonShootsStillReferencing = "ShootsStillReferencing"
+	reasonNotBlocked             = "NotBlocked"
+	reasonMigrationSuggested     = "MigrationSuggested"
+	reasonNoMigrationTarget      = "NoMigrationTargetFound"
+	reasonShootsMigrated         = "ShootsMigrated"
+)
+
+func mergeCloudProfileCondition(conditions []gardencorev1beta1.Condition, conditionType gardencorev1beta1.ConditionType, status gardencorev1beta1.ConditionStatus, reason, message string) []gardencorev1beta1.Condition {
+	condition := gardencorev1beta1helper.GetOrInitCondition(conditions, conditionType)
+	condition = gardencorev1beta1helper.UpdatedCondition(condition, status, reason, message)
+	return gardencorev1beta1helper.MergeConditions(conditions, condition)
+}
+
+// versionManagementCondition reports whether a version-management axis is disabled, failed, or reconciled cleanly.
+func versionManagementCondition(conditions []gardencorev1beta1.Condition, conditionType gardencorev1beta1.ConditionType, enabled bool, reconcileErr error) []gardencorev1beta1.Condition {
+	if !enabled {
+		return mergeCloudProfileCondition(conditions, conditionType, gardencorev1beta1.ConditionUnknown, reasonManagementDisabled, "Automatic version management is disabled for this axis.")
+	}
+	if reconcileErr != nil {
+		return mergeCloudProfileCondition(conditions, conditionType, gardencorev1beta1.ConditionFalse, reasonReconcileFailed, reconcileErr.Error())
+	}
+	return mergeCloudProfileCondition(conditions, conditionType, gardencorev1beta1.ConditionTrue, reasonReconciled, "The last reconciliation run completed successfully.")
+}
+
+func referencedByShootsCondition(conditions []gardencorev1beta1.Condition, associatedShoots []string) []gardencorev1beta1.Condition {
+	if len(associatedShoots) == 0 {
+		return mergeCloudProfileCondition(conditions, ConditionReferencedByShoots, gardencorev1beta1.ConditionFalse, reasonNotReferenced, "No Shoots currently reference this CloudProfile.")
+	}
+	return mergeCloudProfileCondition(conditions, ConditionReferencedByShoots, gardencorev1beta1.ConditionTrue, reasonReferenced, fmt.Sprintf("%d Shoot(s) currently reference this CloudProfile: %+v", len(associatedShoots), associatedShoots))
+}
+
+func deletionBlockedCondition(conditions []gardencorev1beta1.Condition, associatedShoots []string) []gardencorev1beta1.Condition {
+	if len(associatedShoots) == 0 {
+		return mergeCloudProfileCondition(conditions, ConditionDeletionBlocked, gardencorev1beta1.ConditionFalse, reasonNotBlocked, "No Shoots are blocking deletion of this CloudProfile.")
+	}
+	return mergeCloudProfileCondition(conditions, ConditionDeletionBlocked, gardencorev1beta1.ConditionTrue, reasonShootsStillReferencing, fmt.Sprintf("Deletion is blocked because the following Shoots are still referencing this CloudProfile: %+v", associatedShoots))
+}
+
+// migrationRequiredCondition reports the Shoots still referencing a CloudProfile whose DeletionGracePeriod has
+// elapsed, together with suggestedTarget if one could be found.
+func migrationRequiredCondition(conditions []gardencorev1beta1.Condition, associatedShoots []string, suggestedTarget string) []gardencorev1beta1.Condition {
+	if suggestedTarget == "" {
+		return mergeCloudProfileCondition(conditions, ConditionMigrationRequired, gardencorev1beta1.ConditionTrue, reasonNoMigrationTarget, fmt.Sprintf("Deletion grace period elapsed, but no migration target CloudProfile could be found for the following Shoots: %+v", associatedShoots))
+	}
+	return mergeCloudProfileCondition(conditions, ConditionMigrationRequired, gardencorev1beta1.ConditionTrue, reasonMigrationSuggested, fmt.Sprintf("Deletion grace period elapsed; suggesting migration of the following Shoots to CloudProfile %q: %+v", suggestedTarget, associatedShoots))
+}
+
+// observeKubernetesVersions snapshots versions' expiration dates and classifications into
+// CloudProfileStatus.ObservedKubernetesVersions, so operators can tell apart "the subsystem hasn't run yet" from
+// "it ran and decided this particular version didn't need to change".
+func observeKubernetesVersions(versions []gardencorev1beta1.ExpirableVersion) []gardencorev1beta1.ObservedVersion {
+	observed := make([]gardencorev1beta1.ObservedVersion, 0, len(versions))
+	for _, v := range versions {
+		observed = append(observed, gardencorev1beta1.ObservedVersion{
+			Version:                v.Version,
+			ObservedExpirationDate: v.ExpirationDate,
+			ObservedClassification: v.Classification,
+		})
+	}
+	return observed
+}
+
+func observeMachineImageVersions(images []gardencorev1beta1.MachineImage) []gardencorev1beta1.ObservedMachineImageVersion {
+	var observed []gardencorev1beta1.ObservedMachineImageVersion
+	for _, image := range images {
+		for _, v := range image.Versions {
+			observed = append(observed, gardencorev1beta1.ObservedMachineImageVersion{
+				Name: image.Name,
+				ObservedVersion: gardencorev1beta1.ObservedVersion{
+					Version:                v.Version,
+					ObservedExpirationDate: v.ExpirationDate,
+					ObservedClassification: v.Classification,
+				},
+			})
+		}
+	}
+	return observed
+}
+
+// patchCloudProfileStatus patches cloudProfile's status subresource with the result of mutate, following the same
+// kutil.TryPatchStatus pattern the project controller already uses for its own status subresource (see
+// controller/project/project_stale_notify.go).
+func (c *defaultControl) patchCloudProfileStatus(ctx context.Context, cloudProfile *gardencorev1beta1.CloudProfile, mutate func(status *gardencorev1beta1.CloudProfileStatus)) error {
+	return kutil.TryPatchStatus(ctx, retry.DefaultBackoff, c.k8sGardenClient.Client(), cloudProfile, func() error {
+		mutate(&cloudProfile.Status)
+		return nil
+	})
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package managedseedset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	seedmanagementv1alpha1 "github.com/gardener/gardener/pkg/apis/seedmanagement/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	hashutil "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// This file computes and maintains the ControllerRevision history backing ManagedSeedSet.Status.CurrentRevision /
+// UpdateRevision, and decides, for a given replica ordinal, which of the two revisions it should be running -
+// mirroring the approach of the StatefulSet controller (k8s.io/kubernetes/pkg/controller/statefulset), adapted to
+// ManagedSeedSet's two extra strategies (OnDelete, Canary) on top of RollingUpdate. Applying a decided revision to
+// an actual ManagedSeed/Shoot pair - i.e. the rest of the replica lifecycle state machine predicates.go expects
+// (PendingReplica, *Reason) - is out of scope for this file.
+
+// managedSeedSetNameLabel is set on every ControllerRevision owned by a ManagedSeedSet, so its history can be
+// listed back out by label selector.
+const managedSeedSetNameLabel = "managedseedset.gardener.cloud/name"
+
+// revisionData is the part of a ManagedSeedSet's spec that participates in the update revision hash: changing
+// either of these fields is what "rolling out a new version" means for a ManagedSeedSet.
+type revisionData struct {
+	Template      seedmanagementv1alpha1.ManagedSeedTemplate `json:"template"`
+	ShootTemplate runtime.RawExtension                       `json:"shootTemplate"`
+}
+
+// newControllerRevision builds the ControllerRevision for set's current Template/ShootTemplate, owned by set, with
+// its name derived from a hash of the revision data plus collisionCount - exactly as the StatefulSet controller
+// does, so that a genuine collision (the same hash already used by different revision data) is resolved by
+// incrementing collisionCount and re-hashing rather than by failing the reconciliation.
+func newControllerRevision(scheme *runtime.Scheme, set *seedmanagementv1alpha1.ManagedSeedSet, revision int64, collisionCount *int32) (*appsv1.ControllerRevision, error) {
+	data, err := json.Marshal(revisionData{
+		Template:      set.Spec.Template,
+		ShootTemplate: set.Spec.ShootTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hashValue := hashControllerRevisionData(data, collisionCount)
+
+	cr := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      controllerRevisionName(set.Name, hashValue),
+			Namespace: set.Namespace,
+			Labels:    map[string]string{managedSeedSetNameLabel: set.Name},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: revision,
+	}
+	if err := controllerutil.SetControllerReference(set, cr, scheme); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// hashControllerRevisionData hashes data together with collisionCount (left out if nil), the same way the StatefulSet
+// controller's HashControllerRevision does, so a genuine hash collision can be told apart from a duplicate by
+// bumping collisionCount and re-hashing.
+func hashControllerRevisionData(data []byte, collisionCount *int32) string {
+	hf := fnv.New32()
+	hf.Write(data)
+	if collisionCount != nil {
+		hf.Write([]byte(fmt.Sprintf("%d", *collisionCount)))
+	}
+	return hashutil.SafeEncodeString(fmt.Sprint(hf.Sum32()))
+}
+
+func controllerRevisionName(setName, hashValue string) string {
+	return fmt.Sprintf("%s-%s", setName, hashValue)
+}
+
+// listControllerRevisions returns every ControllerRevision owned by set, ordered by Revision ascending.
+func listControllerRevisions(ctx context.Context, c client.Client, set *seedmanagementv1alpha1.ManagedSeedSet) ([]*appsv1.ControllerRevision, error) {
+	list := &appsv1.ControllerRevisionList{}
+	if err := c.List(ctx, list, client.InNamespace(set.Namespace), client.MatchingLabels{managedSeedSetNameLabel: set.Name}); err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*appsv1.ControllerRevision, 0, len(list.Items))
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], set) {
+			revisions = append(revisions, &list.Items[i])
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+
+	return revisions, nil
+}
+
+// truncateControllerRevisionHistory deletes the oldest ControllerRevisions beyond set.Spec.RevisionHistoryLimit,
+// keeping currentRevision and updateRevision untouched regardless of the limit.
+func truncateControllerRevisionHistory(ctx context.Context, c client.Client, set *seedmanagementv1alpha1.ManagedSeedSet, revisions []*appsv1.ControllerRevision, currentRevision, updateRevision string) error {
+	limit := 10
+	if set.Spec.RevisionHistoryLimit != nil {
+		limit = int(*set.Spec.RevisionHistoryLimit)
+	}
+
+	var live []*appsv1.ControllerRevision
+	for _, r := range revisions {
+		if r.Name == currentRevision || r.Name == updateRevision {
+			continue
+		}
+		live = append(live, r)
+	}
+
+	for len(live) > limit {
+		if err := c.Delete(ctx, live[0]); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		live = live[1:]
+	}
+
+	return nil
+}
+
+// ensureUpdateRevision finds, among revisions, one whose data matches set's current Template/ShootTemplate, or else
+// creates and persists a new ControllerRevision for it (bumping set.Status.CollisionCount on a genuine hash
+// collision, exactly as the StatefulSet controller does), and returns its name.
+func ensureUpdateRevision(ctx context.Context, c client.Client, scheme *runtime.Scheme, set *seedmanagementv1alpha1.ManagedSeedSet, revisions []*appsv1.ControllerRevision) (string, error) {
+	want, err := newControllerRevision(scheme, set, nextRevisionNumber(revisions), set.Status.CollisionCount)
+	if err != nil {
+		return "", err
+	}
+
+	for _, existing := range revisions {
+		if existing.Name == want.Name {
+			return existing.Name, nil
+		}
+	}
+
+	for {
+		err := c.Create(ctx, want)
+		if err == nil {
+			return want.Name, nil
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return "", err
+		}
+
+		// Name collision: either it's truly the same revision (reuse it) or a genuine hash collision, in which
+		// case bump CollisionCount and re-hash, like the StatefulSet controller does.
+		existing := &appsv1.ControllerRevision{}
+		if getErr := c.Get(ctx, client.ObjectKeyFromObject(want), existing); getErr != nil {
+			return "", getErr
+		}
+		if string(existing.Data.Raw) == string(want.Data.Raw) {
+			return existing.Name, nil
+		}
+
+		collisionCount := int32(0)
+		if set.Status.CollisionCount != nil {
+			collisionCount = *set.Status.CollisionCount
+		}
+		collisionCount++
+		set.Status.CollisionCount = pointer.Int32(collisionCount)
+
+		want, err = newControllerRevision(scheme, set, want.Revision, set.Status.CollisionCount)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func nextRevisionNumber(revisions []*appsv1.ControllerRevision) int64 {
+	if len(revisions) == 0 {
+		return 1
+	}
+	return revisions[len(revisions)-1].Revision + 1
+}
+
+// replicaRevision decides which revision (currentRevision or updateRevision) the replica at the given 0-indexed
+// ordinal should run, according to set's UpdateStrategy. total is the total number of desired replicas.
+// updatedCount is the number of replicas (as computed by computeReplicaCounts) already running updateRevision; it
+// is only consulted by the RollingUpdate strategy, to let Paused freeze the rollout without undoing progress
+// already made.
+//
+// Note: for RollingUpdate, this only bounds how many replicas above the partition may be concurrently in flight to
+// updateRevision per reconcile (via MaxUnavailable); it does not itself track which of those replicas have actually
+// become Ready before advancing further - that progressive, readiness-driven stepping belongs to the replica
+// lifecycle state machine that predicates.go expects, which (like applying a decided revision to an actual
+// ManagedSeed/Shoot pair) is out of scope for this file.
+func replicaRevision(set *seedmanagementv1alpha1.ManagedSeedSet, ordinal, total int, currentRevision, updateRevision string, updatedCount int32) string {
+	if currentRevision == updateRevision {
+		return updateRevision
+	}
+
+	strategy := set.Spec.UpdateStrategy
+	if strategy == nil || strategy.Type == nil {
+		return updateRevision
+	}
+
+	switch *strategy.Type {
+	case seedmanagementv1alpha1.OnDeleteManagedSeedSetUpdateStrategyType:
+		// The controller never rolls an existing replica under OnDelete; it only assigns a revision to replicas
+		// that don't exist yet. Callers are expected to keep an existing replica on whatever revision it is
+		// already running rather than calling this function for it at all.
+		return updateRevision
+
+	case seedmanagementv1alpha1.CanaryManagedSeedSetUpdateStrategyType:
+		canaryReplicas := 1
+		if strategy.Canary != nil && strategy.Canary.CanaryReplicas != nil {
+			canaryReplicas = int(*strategy.Canary.CanaryReplicas)
+		}
+		// Canary replicas are the ones with the highest ordinals, mirroring RollingUpdate's partition semantics.
+		if ordinal >= total-canaryReplicas {
+			return updateRevision
+		}
+		return currentRevision
+
+	case seedmanagementv1alpha1.RollingUpdateManagedSeedSetUpdateStrategyType:
+		fallthrough
+	default:
+		partition := 0
+		if strategy.RollingUpdate != nil && strategy.RollingUpdate.Partition != nil {
+			partition = int(*strategy.RollingUpdate.Partition)
+		}
+		if ordinal < partition {
+			return currentRevision
+		}
+
+		if rollingUpdatePaused(strategy.RollingUpdate) {
+			// Freeze rollout progression: only report updateRevision for replicas that are already counted as
+			// updated (the highest-ordinal ones, mirroring the partition's own direction); every other replica
+			// above the partition stays on currentRevision until Paused is cleared.
+			if ordinal >= total-int(updatedCount) {
+				return updateRevision
+			}
+			return currentRevision
+		}
+
+		maxUnavailable := rollingUpdateMaxUnavailable(strategy.RollingUpdate, total-partition)
+		if ordinal >= total-maxUnavailable {
+			return updateRevision
+		}
+		return currentRevision
+	}
+}
+
+// rollingUpdatePaused reports whether strategy.Paused is set and true.
+func rollingUpdatePaused(strategy *seedmanagementv1alpha1.RollingUpdateManagedSeedSetUpdateStrategy) bool {
+	return strategy != nil && strategy.Paused != nil && *strategy.Paused
+}
+
+// rollingUpdateMaxUnavailable resolves strategy.MaxUnavailable (an absolute number or a percentage of total)
+// against total, defaulting to 1 and never returning less than 1 (matching the default of
+// SetDefaults_RollingUpdateManagedSeedSetUpdateStrategy, so a malformed/absent value can never unintentionally pause
+// the rollout entirely).
+func rollingUpdateMaxUnavailable(strategy *seedmanagementv1alpha1.RollingUpdateManagedSeedSetUpdateStrategy, total int) int {
+	if strategy == nil || strategy.MaxUnavailable == nil {
+		return 1
+	}
+
+	maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(strategy.MaxUnavailable, total, true)
+	if err != nil || maxUnavailable < 1 {
+		return 1
+	}
+
+	return maxUnavailable
+}
+
+// computeReplicaCounts populates CurrentReplicas/UpdatedReplicas by counting, for each existing replica, whether the
+// revision it is actually running (as recorded on its owned ControllerRevision) matches set.Status.CurrentRevision
+// or set.Status.UpdateRevision. replicaRevisions maps a replica's identity to the revision name it is running.
+func computeReplicaCounts(set *seedmanagementv1alpha1.ManagedSeedSet, replicaRevisions map[string]string) (current, updated int32) {
+	for _, revision := range replicaRevisions {
+		switch revision {
+		case set.Status.CurrentRevision:
+			current++
+		case set.Status.UpdateRevision:
+			updated++
+		}
+	}
+	return current, updated
+}
@@ -22,6 +22,7 @@ import (
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	gardencorelisters "github.com/gardener/gardener/pkg/client/core/listers/core/v1beta1"
 	"github.com/gardener/gardener/pkg/controllermanager/apis/config"
+	"github.com/gardener/gardener/pkg/controllermanager/controller/project/stalenotify"
 	"github.com/gardener/gardener/pkg/operation/common"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 
@@ -49,6 +50,7 @@ func NewProjectStaleReconciler(
 	quotaLister gardencorelisters.QuotaLister,
 	namespaceLister kubecorev1listers.NamespaceLister,
 	secretLister kubecorev1listers.SecretLister,
+	notifiers []stalenotify.Notifier,
 ) reconcile.Reconciler {
 	return &projectStaleReconciler{
 		logger:              l,
@@ -61,6 +63,7 @@ func NewProjectStaleReconciler(
 		quotaLister:         quotaLister,
 		namespaceLister:     namespaceLister,
 		secretLister:        secretLister,
+		notifiers:           notifiers,
 	}
 }
 
@@ -75,6 +78,9 @@ type projectStaleReconciler struct {
 	quotaLister         gardencorelisters.QuotaLister
 	namespaceLister     kubecorev1listers.NamespaceLister
 	secretLister        kubecorev1listers.SecretLister
+	// notifiers dispatches stale-Project notifications (see ProjectControllerConfiguration.StaleNotifications);
+	// nil/empty disables notifications entirely, preserving the previous, silent behaviour.
+	notifiers []stalenotify.Notifier
 }
 
 func (r *projectStaleReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
@@ -112,6 +118,17 @@ func (r *projectStaleReconciler) reconcile(ctx context.Context, project *gardenc
 	projectLogger := newProjectLogger(project)
 	projectLogger.Infof("[STALE PROJECT RECONCILE]")
 
+	// Short-circuit projects that the configured StaleCheckSelector doesn't select at all; they are left untouched
+	// rather than marked 'not stale', since the selector declares them out of scope for this controller entirely.
+	selected, err := r.staleCheckSelectorMatches(project)
+	if err != nil {
+		return err
+	}
+	if !selected {
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Project is not selected by the configured StaleCheckSelector, skipping")
+		return nil
+	}
+
 	// Skip projects whose namespace is annotated with the skip-stale-check annotation.
 	namespace, err := r.namespaceLister.Get(*project.Spec.Namespace)
 	if err != nil {
@@ -128,10 +145,20 @@ func (r *projectStaleReconciler) reconcile(ctx context.Context, project *gardenc
 		return r.markProjectAsNotStale(ctx, r.gardenClient, project)
 	}
 
+	policy, err := r.effectiveStalePolicy(project)
+	if err != nil {
+		return err
+	}
+
+	if policy.pinned {
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Project is labelled with %s=pin, skipping the check and considering the project as 'not stale'", ProjectStalePolicyLabel)
+		return r.markProjectAsNotStale(ctx, r.gardenClient, project)
+	}
+
 	// Skip projects that are not older than the configured minimum lifetime in days. This allows having Projects for a
 	// certain period of time until they are checked whether they got stale.
-	if project.CreationTimestamp.UTC().Add(time.Hour * 24 * time.Duration(*r.config.MinimumLifetimeDays)).After(NowFunc().UTC()) {
-		projectLogger.Infof("[STALE PROJECT RECONCILE] Project is not older than the configured minimum %d days lifetime (%v), considering it 'not stale'", *r.config.MinimumLifetimeDays, project.CreationTimestamp.UTC())
+	if project.CreationTimestamp.UTC().Add(time.Hour * 24 * time.Duration(policy.minimumLifetimeDays)).After(NowFunc().UTC()) {
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Project is not older than the configured minimum %d days lifetime (%v), considering it 'not stale'", policy.minimumLifetimeDays, project.CreationTimestamp.UTC())
 		return r.markProjectAsNotStale(ctx, r.gardenClient, project)
 	}
 
@@ -152,25 +179,53 @@ func (r *projectStaleReconciler) reconcile(ctx context.Context, project *gardenc
 		}
 	}
 
+	if inUse, name, err := r.projectInUseDueToAdditionalResources(ctx, *project.Spec.Namespace); err != nil {
+		return err
+	} else if inUse {
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Project is being marked as 'not stale' because it is used by %s", name)
+		return r.markProjectAsNotStale(ctx, r.gardenClient, project)
+	}
+
 	projectLogger.Infof("[STALE PROJECT RECONCILE] Project is being marked as 'stale' because it is not being used by any resource")
-	if err := r.markProjectAsStale(ctx, r.gardenClient, project, NowFunc); err != nil {
+	if err := r.markProjectAsStale(ctx, r.gardenClient, project, policy, NowFunc); err != nil {
 		return err
 	}
+	metricProjectStaleMarkedTotal.Inc()
 
 	projectLogger.Infof("[STALE PROJECT RECONCILE] Project is stale since %s", *project.Status.StaleSinceTimestamp)
 	if project.Status.StaleAutoDeleteTimestamp != nil {
 		projectLogger.Infof("[STALE PROJECT RECONCILE] Project will be deleted at %s", *project.Status.StaleAutoDeleteTimestamp)
 	}
 
+	if err := r.dispatchPendingStaleNotifications(ctx, project); err != nil {
+		return err
+	}
+
 	if project.Status.StaleAutoDeleteTimestamp == nil || NowFunc().UTC().Before(project.Status.StaleAutoDeleteTimestamp.UTC()) {
 		return nil
 	}
 
-	projectLogger.Infof("[STALE PROJECT RECONCILE] Deleting Project now because it's auto-delete timestamp is expired")
-	if err := common.ConfirmDeletion(ctx, r.gardenClient, project); err != nil {
-		return err
+	switch r.config.StaleDeletionMode {
+	case staleDeletionModeDisabled, staleDeletionModeDryRun:
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Not deleting Project because StaleDeletionMode is %q", r.config.StaleDeletionMode)
+		return nil
+
+	case staleDeletionModeQuarantine:
+		return r.reconcileQuarantine(ctx, projectLogger, project)
+
+	default: // staleDeletionModeDelete, and "" for backwards compatibility with the original, only historically supported behaviour
+		projectLogger.Infof("[STALE PROJECT RECONCILE] Deleting Project now because it's auto-delete timestamp is expired")
+		if err := common.ConfirmDeletion(ctx, r.gardenClient, project); err != nil {
+			return err
+		}
+		if err := r.gardenClient.Delete(ctx, project); err != nil {
+			return err
+		}
+
+		metricProjectAutoDeletedTotal.Inc()
+		r.notifyDeleted(ctx, project)
+		return nil
 	}
-	return r.gardenClient.Delete(ctx, project)
 }
 
 func (r *projectStaleReconciler) projectInUseDueToShoots(namespace string) (bool, error) {
@@ -223,6 +278,38 @@ func (r *projectStaleReconciler) projectInUseDueToQuotas(namespace string) (bool
 	})
 }
 
+// projectInUseDueToAdditionalResources consults every checker registered via RegisterProjectInUseChecker, plus a
+// generic list-based check for every resource named in r.config.AdditionalInUseResources, returning the name to log
+// for the first one that reports the Project's namespace as in use.
+func (r *projectStaleReconciler) projectInUseDueToAdditionalResources(ctx context.Context, namespace string) (bool, string, error) {
+	projectInUseCheckersMu.Lock()
+	checkers := make([]registeredProjectInUseChecker, len(projectInUseCheckers))
+	copy(checkers, projectInUseCheckers)
+	projectInUseCheckersMu.Unlock()
+
+	for _, checker := range checkers {
+		inUse, err := checker.checkFunc(ctx, namespace)
+		if err != nil {
+			return false, "", err
+		}
+		if inUse {
+			return true, checker.name, nil
+		}
+	}
+
+	for _, gvr := range r.config.AdditionalInUseResources {
+		inUse, err := genericResourceInUseCheck(r.gardenClient, gvr)(ctx, namespace)
+		if err != nil {
+			return false, "", err
+		}
+		if inUse {
+			return true, gvr.Resource, nil
+		}
+	}
+
+	return false, "", nil
+}
+
 func (r *projectStaleReconciler) relevantSecretBindingsInUse(isSecretBindingRelevantFunc func(secretBinding *gardencorev1beta1.SecretBinding) bool) (bool, error) {
 	secretBindingList, err := r.secretBindingLister.List(labels.Everything())
 	if err != nil {
@@ -253,14 +340,14 @@ func (r *projectStaleReconciler) markProjectAsNotStale(ctx context.Context, clie
 	})
 }
 
-func (r *projectStaleReconciler) markProjectAsStale(ctx context.Context, client client.Client, project *gardencorev1beta1.Project, nowFunc func() metav1.Time) error {
+func (r *projectStaleReconciler) markProjectAsStale(ctx context.Context, client client.Client, project *gardencorev1beta1.Project, policy *projectStalePolicy, nowFunc func() metav1.Time) error {
 	return kutil.TryPatchStatus(ctx, retry.DefaultBackoff, client, project, func() error {
 		if project.Status.StaleSinceTimestamp == nil {
 			now := nowFunc()
 			project.Status.StaleSinceTimestamp = &now
 		}
 
-		if project.Status.StaleSinceTimestamp.UTC().Add(time.Hour * 24 * time.Duration(*r.config.StaleGracePeriodDays)).After(nowFunc().UTC()) {
+		if project.Status.StaleSinceTimestamp.UTC().Add(time.Hour * 24 * time.Duration(policy.staleGracePeriodDays)).After(nowFunc().UTC()) {
 			// We reset the potentially set auto-delete timestamp here to allow changing the StaleExpirationTimeDays
 			// configuration value and correctly applying the changes to all Projects that had already been assigned
 			// such a timestamp.
@@ -270,8 +357,8 @@ func (r *projectStaleReconciler) markProjectAsStale(ctx context.Context, client
 
 		// If the project got stale we compute an auto delete timestamp only if the configured stale grace period is
 		// exceeded. Note that this might update the potentially already set auto-delete timestamp in case the
-		// StaleExpirationTimeDays configuration value was changed.
-		autoDeleteTimestamp := metav1.Time{Time: project.Status.StaleSinceTimestamp.Add(time.Hour * 24 * time.Duration(*r.config.StaleExpirationTimeDays))}
+		// StaleExpirationTimeDays configuration value (or its per-Project override) was changed.
+		autoDeleteTimestamp := metav1.Time{Time: project.Status.StaleSinceTimestamp.Add(time.Hour * 24 * time.Duration(policy.staleExpirationDays))}
 
 		// Don't allow to shorten the auto-delete timestamp as end-users might depend on the configured time. It may
 		// only be extended.
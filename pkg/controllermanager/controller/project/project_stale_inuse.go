@@ -0,0 +1,87 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ProjectInUseCheckFunc reports whether resources exist in the given Project namespace that should keep the Project
+// from being considered stale.
+type ProjectInUseCheckFunc func(ctx context.Context, namespace string) (bool, error)
+
+type registeredProjectInUseChecker struct {
+	name      string
+	gvr       schema.GroupVersionResource
+	checkFunc ProjectInUseCheckFunc
+}
+
+var (
+	projectInUseCheckersMu sync.Mutex
+	projectInUseCheckers   []registeredProjectInUseChecker
+)
+
+// RegisterProjectInUseChecker registers an additional check that is consulted by the Project stale reconciler in
+// the same way it already consults Shoots, Plants, BackupEntries, Secrets and Quotas: if checkFunc returns true for
+// a Project's namespace, the Project is marked 'not stale', regardless of what the built-in checks say. This lets
+// operators (or other gardener components, e.g. seedmanagement) keep Projects alive because of custom resources
+// such as ManagedSeeds, ManagedSeedSets, or tenant-defined CRDs, without patching this package.
+//
+// name is used only for logging and must be unique; registering the same name again replaces the previous
+// registration. gvr identifies the resource the checker looks at and is likewise only used for logging, since
+// checkFunc is free to implement the actual check however it likes (e.g. via a lister, or a generic list as
+// genericResourceInUseCheck below does). RegisterProjectInUseChecker is meant to be called from an init function of
+// the package owning the custom resource, before the project controller is started.
+func RegisterProjectInUseChecker(name string, gvr schema.GroupVersionResource, checkFunc ProjectInUseCheckFunc) {
+	projectInUseCheckersMu.Lock()
+	defer projectInUseCheckersMu.Unlock()
+
+	for i, checker := range projectInUseCheckers {
+		if checker.name == name {
+			projectInUseCheckers[i] = registeredProjectInUseChecker{name, gvr, checkFunc}
+			return
+		}
+	}
+
+	projectInUseCheckers = append(projectInUseCheckers, registeredProjectInUseChecker{name, gvr, checkFunc})
+}
+
+// genericResourceInUseCheck returns a ProjectInUseCheckFunc which considers the Project in use if the given
+// namespaced resource has at least one object in the Project's namespace. It underlies
+// config.ProjectControllerConfiguration.AdditionalInUseResources, and doesn't need the resource's Go type to be
+// vendored, since it lists via the dynamic/unstructured client the same way AnyDeployedSNI does for istio's
+// VirtualServices.
+func genericResourceInUseCheck(c client.Client, gvr schema.GroupVersionResource) ProjectInUseCheckFunc {
+	return func(ctx context.Context, namespace string) (bool, error) {
+		gvk, err := c.RESTMapper().KindFor(gvr)
+		if err != nil {
+			return false, err
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := c.List(ctx, list, client.InNamespace(namespace), client.Limit(1)); err != nil {
+			return false, err
+		}
+
+		return len(list.Items) > 0, nil
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	metricProjectStaleMarkedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gardener",
+		Subsystem: "project",
+		Name:      "stale_marked_total",
+		Help:      "Number of reconciles in which a Project was found to be stale.",
+	})
+
+	metricProjectQuarantinedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gardener",
+		Subsystem: "project",
+		Name:      "quarantined_total",
+		Help:      "Number of Projects quarantined instead of being deleted, because StaleDeletionMode is Quarantine.",
+	})
+
+	metricProjectAutoDeletedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "gardener",
+		Subsystem: "project",
+		Name:      "auto_deleted_total",
+		Help:      "Number of stale Projects actually deleted once their auto-delete (or quarantine expiration) timestamp passed.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(metricProjectStaleMarkedTotal, metricProjectQuarantinedTotal, metricProjectAutoDeletedTotal)
+}
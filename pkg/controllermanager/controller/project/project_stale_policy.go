@@ -0,0 +1,128 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ProjectStalePolicyLabel lets a Project owner declare stale-check policy on the Project itself, overriding the
+// cluster-wide MinimumLifetimeDays/StaleGracePeriodDays/StaleExpirationTimeDays for that one Project. Its value is a
+// comma-separated list of directives:
+//   - "pin"                   never consider this Project stale, regardless of use. Equivalent in effect to
+//     common.ProjectSkipStaleCheck, but declared by the Project owner rather than the
+//     namespace administrator.
+//   - "track"                 use the cluster-wide defaults (same as omitting the label entirely).
+//   - "min-lifetime-days=<N>" overrides MinimumLifetimeDays for this Project.
+//   - "grace-period-days=<N>" overrides StaleGracePeriodDays for this Project.
+//   - "delete-after=<N>d"     overrides StaleExpirationTimeDays for this Project.
+const ProjectStalePolicyLabel = "project.gardener.cloud/stale-policy"
+
+// projectStalePolicy is the effective, possibly per-Project-overridden stale-check policy used for one reconcile.
+type projectStalePolicy struct {
+	pinned               bool
+	minimumLifetimeDays  int32
+	staleGracePeriodDays int32
+	staleExpirationDays  int32
+}
+
+// effectiveStalePolicy computes the policy to apply to project, starting from the cluster-wide configuration and
+// layering the per-Project override declared via ProjectStalePolicyLabel, if any, on top.
+func (r *projectStaleReconciler) effectiveStalePolicy(project *gardencorev1beta1.Project) (*projectStalePolicy, error) {
+	policy := &projectStalePolicy{
+		minimumLifetimeDays:  *r.config.MinimumLifetimeDays,
+		staleGracePeriodDays: *r.config.StaleGracePeriodDays,
+		staleExpirationDays:  *r.config.StaleExpirationTimeDays,
+	}
+
+	value, ok := project.Labels[ProjectStalePolicyLabel]
+	if !ok {
+		return policy, nil
+	}
+
+	for _, directive := range strings.Split(value, ",") {
+		directive = strings.TrimSpace(directive)
+
+		switch {
+		case directive == "", directive == "track":
+			// no override
+
+		case directive == "pin":
+			policy.pinned = true
+
+		case strings.HasPrefix(directive, "min-lifetime-days="):
+			days, err := strconv.Atoi(strings.TrimPrefix(directive, "min-lifetime-days="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s directive %q: %w", ProjectStalePolicyLabel, directive, err)
+			}
+			policy.minimumLifetimeDays = int32(days)
+
+		case strings.HasPrefix(directive, "grace-period-days="):
+			days, err := strconv.Atoi(strings.TrimPrefix(directive, "grace-period-days="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s directive %q: %w", ProjectStalePolicyLabel, directive, err)
+			}
+			policy.staleGracePeriodDays = int32(days)
+
+		case strings.HasPrefix(directive, "delete-after="):
+			days, err := parseDayCount(strings.TrimPrefix(directive, "delete-after="))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s directive %q: %w", ProjectStalePolicyLabel, directive, err)
+			}
+			policy.staleExpirationDays = days
+
+		default:
+			return nil, fmt.Errorf("unknown %s directive %q", ProjectStalePolicyLabel, directive)
+		}
+	}
+
+	return policy, nil
+}
+
+// parseDayCount parses a "<N>d" duration string (e.g. "30d") into a whole number of days.
+func parseDayCount(s string) (int32, error) {
+	if !strings.HasSuffix(s, "d") {
+		return 0, fmt.Errorf("expected a value in days, e.g. %q", "30d")
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(days), nil
+}
+
+// staleCheckSelectorMatches reports whether project matches r.config.StaleCheckSelector. A nil selector matches
+// every Project, preserving the previous, unconditional behaviour when the field is left unset.
+func (r *projectStaleReconciler) staleCheckSelectorMatches(project *gardencorev1beta1.Project) (bool, error) {
+	if r.config.StaleCheckSelector == nil {
+		return true, nil
+	}
+
+	selector, err := validation.LabelSelectorAsSelector(r.config.StaleCheckSelector)
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(project.Labels)), nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"context"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// This file assumes ProjectControllerConfiguration (whose defining file is absent from this checkout) carries two
+// new fields: `StaleDeletionMode string` (one of the staleDeletionMode* values below) and
+// `QuarantineExpirationDays *int32`, and that ProjectStatus carries a new `QuarantinedSince *metav1.Time` field.
+// StaleDeletionMode is deliberately kept a plain string here (rather than a dedicated named type) so this file does
+// not need to recreate the rest of the absent config package just to declare one enum.
+const (
+	// staleDeletionModeDisabled computes and records the auto-delete timestamp as before, but never acts on it.
+	staleDeletionModeDisabled = "Disabled"
+	// staleDeletionModeDryRun behaves like staleDeletionModeDisabled, but is spelled out explicitly so operators can
+	// tell "we haven't gotten around to configuring this" apart from "we deliberately never want to delete".
+	staleDeletionModeDryRun = "DryRun"
+	// staleDeletionModeQuarantine freezes a Project's namespace instead of deleting it outright; see
+	// reconcileQuarantine.
+	staleDeletionModeQuarantine = "Quarantine"
+	// staleDeletionModeDelete is the original, only historically supported behaviour: delete the Project once its
+	// auto-delete timestamp has passed. It is also the default for an empty/unset StaleDeletionMode, to preserve
+	// that existing behaviour.
+	staleDeletionModeDelete = "Delete"
+)
+
+const (
+	// quarantineNamespaceLabel marks a Project's namespace as quarantined once its Project has expired under
+	// staleDeletionModeQuarantine.
+	quarantineNamespaceLabel = "project.gardener.cloud/quarantined"
+	// quarantineShootCountQuotaKey is the object-count ResourceQuota key that blocks new Shoots in a quarantined
+	// namespace (Kubernetes' "count/<resource>.<group>" object-count-quota convention).
+	quarantineShootCountQuotaKey corev1.ResourceName = "count/shoots.core.gardener.cloud"
+)
+
+// reconcileQuarantine is the staleDeletionModeQuarantine branch of the expired-auto-delete-timestamp handling: it
+// quarantines the Project's namespace on first encounter, then only deletes the Project once
+// QuarantineExpirationDays have additionally elapsed since Status.QuarantinedSince.
+func (r *projectStaleReconciler) reconcileQuarantine(ctx context.Context, logger logrus.FieldLogger, project *gardencorev1beta1.Project) error {
+	if project.Status.QuarantinedSince == nil {
+		logger.Infof("[STALE PROJECT RECONCILE] Quarantining Project instead of deleting it")
+		if err := r.quarantineProject(ctx, project); err != nil {
+			return err
+		}
+		metricProjectQuarantinedTotal.Inc()
+		return nil
+	}
+
+	var quarantineExpirationDays int32
+	if r.config.QuarantineExpirationDays != nil {
+		quarantineExpirationDays = *r.config.QuarantineExpirationDays
+	}
+
+	quarantineExpiry := project.Status.QuarantinedSince.Add(time.Hour * 24 * time.Duration(quarantineExpirationDays))
+	if NowFunc().UTC().Before(quarantineExpiry) {
+		logger.Infof("[STALE PROJECT RECONCILE] Project is quarantined since %s, waiting for the quarantine period to expire at %s", *project.Status.QuarantinedSince, quarantineExpiry)
+		return nil
+	}
+
+	logger.Infof("[STALE PROJECT RECONCILE] Deleting quarantined Project now because its quarantine period has expired")
+	if err := common.ConfirmDeletion(ctx, r.gardenClient, project); err != nil {
+		return err
+	}
+	if err := r.gardenClient.Delete(ctx, project); err != nil {
+		return err
+	}
+
+	metricProjectAutoDeletedTotal.Inc()
+	r.notifyDeleted(ctx, project)
+	return nil
+}
+
+// quarantineProject labels the Project's namespace, blocks new Shoots in it via a deny-all NetworkPolicy and a
+// zero-Shoot ResourceQuota, and records Status.QuarantinedSince.
+func (r *projectStaleReconciler) quarantineProject(ctx context.Context, project *gardencorev1beta1.Project) error {
+	namespace := *project.Spec.Namespace
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.gardenClient, ns, func() error {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		ns.Labels[quarantineNamespaceLabel] = "true"
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	networkPolicy := r.emptyQuarantineNetworkPolicy(namespace)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.gardenClient, networkPolicy, func() error {
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	resourceQuota := r.emptyQuarantineResourceQuota(namespace)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.gardenClient, resourceQuota, func() error {
+		resourceQuota.Spec = corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{
+				quarantineShootCountQuotaKey: resource.MustParse("0"),
+			},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return kutil.TryPatchStatus(ctx, retry.DefaultBackoff, r.gardenClient, project, func() error {
+		now := NowFunc()
+		project.Status.QuarantinedSince = &now
+		return nil
+	})
+}
+
+func (r *projectStaleReconciler) emptyQuarantineNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "project-quarantine", Namespace: namespace}}
+}
+
+func (r *projectStaleReconciler) emptyQuarantineResourceQuota(namespace string) *corev1.ResourceQuota {
+	return &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: "project-quarantine", Namespace: namespace}}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stalenotify
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+// eventNotifier records a Kubernetes Event on the Project, reason-coded per Stage, using the controller-manager's
+// shared event recorder. It requires no additional infrastructure, so it is always safe to enable.
+type eventNotifier struct {
+	recorder record.EventRecorder
+}
+
+// NewEventNotifier returns a Notifier that records a Kubernetes Event on the Project for each stage.
+func NewEventNotifier(recorder record.EventRecorder) Notifier {
+	return &eventNotifier{recorder: recorder}
+}
+
+func (n *eventNotifier) Notify(_ context.Context, project *gardencorev1beta1.Project, stage Stage, payload *Payload) error {
+	n.recorder.Eventf(project, corev1.EventTypeNormal, string(stage), "Stale Project notification (%s): %s", stage, payload.ProjectName)
+	return nil
+}
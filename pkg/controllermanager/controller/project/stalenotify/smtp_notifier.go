@@ -0,0 +1,79 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stalenotify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// SMTPConfig configures outbound mail delivery for smtpNotifier.
+type SMTPConfig struct {
+	// Host is the "host:port" address of the SMTP relay.
+	Host string
+	// From is the sender address used for every notification mail.
+	From string
+	// Auth authenticates against Host, if the relay requires it. May be nil for an open/internal relay.
+	Auth smtp.Auth
+}
+
+// smtpNotifier emails the Project's owner and members (as configured on the Project's spec) at every stage. It is
+// intended for landscapes where Project owners aren't expected to watch Kubernetes Events or run a webhook
+// receiver.
+type smtpNotifier struct {
+	config SMTPConfig
+}
+
+// NewSMTPNotifier returns a Notifier that emails the Project's owner and members, from config.From, for every stage.
+func NewSMTPNotifier(config SMTPConfig) Notifier {
+	return &smtpNotifier{config: config}
+}
+
+func (n *smtpNotifier) Notify(_ context.Context, project *gardencorev1beta1.Project, stage Stage, payload *Payload) error {
+	recipients := recipientsFor(project)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("[gardener] Project %s: %s", payload.ProjectName, stage)
+	body := fmt.Sprintf("Project %s stale-check notification: %s\nStale since: %v\nScheduled deletion: %v\n",
+		payload.ProjectName, stage, payload.StaleSince, payload.AutoDelete)
+
+	message := []byte(fmt.Sprintf("Subject: %s\r\n\r\n%s", subject, body))
+
+	return smtp.SendMail(n.config.Host, n.config.Auth, n.config.From, recipients, message)
+}
+
+// recipientsFor collects the email addresses of the Project's owner and members. Project.Spec.Owner/Members are
+// assumed to carry an email-shaped Name for SubjectKind "User" entries, as is already the gardener convention
+// elsewhere for notifying humans about their Projects.
+func recipientsFor(project *gardencorev1beta1.Project) []string {
+	var recipients []string
+
+	if project.Spec.Owner != nil && project.Spec.Owner.Kind == "User" {
+		recipients = append(recipients, project.Spec.Owner.Name)
+	}
+
+	for _, member := range project.Spec.Members {
+		if member.Kind == "User" {
+			recipients = append(recipients, member.Name)
+		}
+	}
+
+	return recipients
+}
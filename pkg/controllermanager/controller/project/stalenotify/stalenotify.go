@@ -0,0 +1,70 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stalenotify turns stale-Project cleanup from a silent operation into an auditable workflow: it dispatches
+// configurable notifications at each stage of a Project's path towards auto-deletion (marked-stale, mid-grace, 7
+// days before deletion, 1 day before deletion, deletion-imminent, deleted), via one or more configured Notifiers.
+package stalenotify
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Stage identifies a point in a stale Project's lifecycle at which a notification is dispatched.
+type Stage string
+
+const (
+	// MarkedStale fires the first time a Project is marked stale.
+	MarkedStale Stage = "MarkedStale"
+	// MidGrace fires once the Project is halfway from its StaleSinceTimestamp to its StaleAutoDeleteTimestamp.
+	MidGrace Stage = "MidGrace"
+	// SevenDaysBeforeDeletion fires seven days before the Project's StaleAutoDeleteTimestamp.
+	SevenDaysBeforeDeletion Stage = "SevenDaysBeforeDeletion"
+	// OneDayBeforeDeletion fires one day before the Project's StaleAutoDeleteTimestamp.
+	OneDayBeforeDeletion Stage = "OneDayBeforeDeletion"
+	// DeletionImminent fires once the Project's StaleAutoDeleteTimestamp has passed, in the reconcile that then acts
+	// on the configured stale deletion mode (deleting or quarantining the Project, or leaving it as is).
+	DeletionImminent Stage = "DeletionImminent"
+	// Deleted fires after the Project has been successfully deleted.
+	Deleted Stage = "Deleted"
+)
+
+// Payload is the stable, JSON-serializable description of a stale Project sent to Notifiers.
+type Payload struct {
+	// ProjectName is the name of the affected Project.
+	ProjectName string `json:"projectName"`
+	// Owner is the email address or subject of the Project's owner, if known.
+	Owner string `json:"owner,omitempty"`
+	// StaleSince is when the Project was first marked stale.
+	StaleSince *metav1.Time `json:"staleSince,omitempty"`
+	// AutoDelete is when the Project is scheduled to be auto-deleted, if known.
+	AutoDelete *metav1.Time `json:"autoDelete,omitempty"`
+	// RemainingResources is a per-resource snapshot of the in-use checks, e.g. {"Shoots": 0, "Plants": 0}. The stale
+	// Project reconciler does not populate it, so it is omitted from every payload it sends.
+	RemainingResources map[string]int `json:"remainingResources,omitempty"`
+}
+
+// Notifier dispatches a stale-Project notification for a given Stage. Implementations exist per delivery channel
+// (Kubernetes Events, outbound HTTP webhooks, SMTP); the stale reconciler is agnostic to which ones are configured.
+type Notifier interface {
+	// Notify delivers a notification for the given stage. It must be idempotent-safe to call more than once for the
+	// same (project, stage) pair; the caller is responsible for only calling it once per stage using
+	// Project.Status.StaleNotifications, but a well-behaved Notifier should tolerate duplicates gracefully (e.g. a
+	// webhook notifier may simply re-POST).
+	Notify(ctx context.Context, project *gardencorev1beta1.Project, stage Stage, payload *Payload) error
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stalenotify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+)
+
+// webhookPayload is the JSON document POSTed to the configured webhook URL. It embeds Payload and adds the stage
+// and a fixed event name, so recipients can route on either without parsing the reason-coded Stage value.
+type webhookPayload struct {
+	Event string `json:"event"`
+	Stage Stage  `json:"stage"`
+	*Payload
+}
+
+// webhookNotifier delivers stale-Project notifications as an HTTP POST with a JSON body to a fixed URL.
+type webhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+// NewWebhookNotifier returns a Notifier that POSTs a JSON payload to url for every stage. httpClient may be nil, in
+// which case http.DefaultClient is used.
+func NewWebhookNotifier(httpClient *http.Client, url string) Notifier {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &webhookNotifier{client: httpClient, url: url}
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, _ *gardencorev1beta1.Project, stage Stage, payload *Payload) error {
+	body, err := json.Marshal(webhookPayload{Event: "project.stale", Stage: stage, Payload: payload})
+	if err != nil {
+		return fmt.Errorf("failed marshalling stale-project webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed building stale-project webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed delivering stale-project webhook to %s: %w", n.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("stale-project webhook %s responded with status %d", n.url, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/gardener/gardener/pkg/controllermanager/apis/config"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Event types for Seed lifecycle transitions. These follow the reverse-DNS CloudEvents taxonomy convention
+// ("<producer>.<subject>.<kind>.<version>") so that consumers can subscribe by prefix.
+const (
+	EventTypeSeedLifecycleReady           = "gardener.seed.lifecycle.ready.v1"
+	EventTypeSeedLifecycleNotReady        = "gardener.seed.lifecycle.notready.v1"
+	EventTypeSeedBackupBucketReady        = "gardener.seed.backupbucket.ready.v1"
+	EventTypeSeedBackupBucketNotReady     = "gardener.seed.backupbucket.notready.v1"
+	cloudEventsSpecVersion                = "1.0"
+	cloudEventsContentType                = "application/json"
+	cloudEventsSinkQueueName              = "Seed CloudEvents Sink"
+	cloudEventsMaxEnqueuedEvents      int = 1000
+)
+
+// CloudEvent is a CloudEvents 1.0 envelope (https://github.com/cloudevents/spec) for a Seed lifecycle transition.
+// Only the "structured JSON" encoding is supported; binary content mode (ce-* HTTP headers) is not implemented.
+type CloudEvent struct {
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	SpecVersion     string      `json:"specversion"`
+	Type            string      `json:"type"`
+	Subject         string      `json:"subject"`
+	Time            time.Time   `json:"time"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+// ConditionTransitionData is the JSON payload of a CloudEvent reporting a condition transition.
+type ConditionTransitionData struct {
+	PreviousStatus gardencorev1beta1.ConditionStatus `json:"previousStatus"`
+	Status         gardencorev1beta1.ConditionStatus `json:"status"`
+	Reason         string                            `json:"reason"`
+	Message        string                            `json:"message"`
+}
+
+// CloudEventsSink delivers CloudEvents to an external, operator-configured endpoint. It is optional: a Controller
+// constructed without one (nil sink) simply never emits.
+type CloudEventsSink interface {
+	// Send delivers event. Implementations are expected to be used from within the bounded retry queue
+	// cloudEventsQueue wraps them in, rather than retrying internally.
+	Send(ctx context.Context, event CloudEvent) error
+}
+
+// httpCloudEventsSink delivers CloudEvents over HTTP using the structured JSON content mode. It is the only
+// transport implemented so far; config.CloudEventsSink.Transport == "nats" is accepted by the configuration but not
+// yet wired up, since this repository does not vendor a NATS client.
+type httpCloudEventsSink struct {
+	url        string
+	authHeader string
+	client     *http.Client
+}
+
+// NewHTTPCloudEventsSink creates a CloudEventsSink that POSTs events as structured-mode CloudEvents JSON to cfg.URL.
+func NewHTTPCloudEventsSink(cfg config.CloudEventsSink) (CloudEventsSink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("cloud events sink URL must not be empty")
+	}
+
+	return &httpCloudEventsSink{
+		url:        cfg.URL,
+		authHeader: cfg.AuthHeader,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *httpCloudEventsSink) Send(ctx context.Context, event CloudEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal CloudEvent: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	if s.authHeader != "" {
+		req.Header.Set("Authorization", s.authHeader)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("cloud events sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// cloudEventsSinkMetrics are the Prometheus counters exposed alongside Controller.CollectMetrics.
+var cloudEventsSinkMetrics = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "gardener",
+		Subsystem: "seed_controller",
+		Name:      "cloud_events_total",
+		Help:      "Total number of Seed lifecycle CloudEvents by sink outcome.",
+	},
+	[]string{"outcome"}, // one of "delivered", "retried", "dropped"
+)
+
+func init() {
+	prometheus.MustRegister(cloudEventsSinkMetrics)
+}
+
+// cloudEventsQueue wraps a CloudEventsSink with a bounded in-memory retry queue and exponential backoff, so that a
+// temporarily unreachable sink delays delivery of that one event instead of blocking the caller of emit. The Seed,
+// Seed Lifecycle and Backup Bucket reconcilers do not call emit, so no CloudEvents are sent for their transitions.
+type cloudEventsQueue struct {
+	sink   CloudEventsSink
+	source string
+	queue  workqueue.RateLimitingInterface
+}
+
+// newCloudEventsQueue wraps sink in a bounded retry queue. source is used as the CloudEvents "source" attribute for
+// every event emitted through it (the garden cluster identity).
+func newCloudEventsQueue(sink CloudEventsSink, source string) *cloudEventsQueue {
+	q := &cloudEventsQueue{
+		sink:   sink,
+		source: source,
+		queue:  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), cloudEventsSinkQueueName),
+	}
+	go q.run()
+	return q
+}
+
+// emit enqueues a CloudEvent reporting a condition transition for subject (typically a Seed name). It never blocks
+// the caller: if the queue already holds cloudEventsMaxEnqueuedEvents items, the event is dropped and counted as
+// such, rather than applying backpressure to the reconcile loop that called it.
+func (q *cloudEventsQueue) emit(eventType, subject, reason, message string, previousStatus, status gardencorev1beta1.ConditionStatus) {
+	if q == nil {
+		return
+	}
+	if q.queue.Len() >= cloudEventsMaxEnqueuedEvents {
+		cloudEventsSinkMetrics.WithLabelValues("dropped").Inc()
+		return
+	}
+
+	q.queue.Add(CloudEvent{
+		ID:              uuid.NewString(),
+		Source:          q.source,
+		SpecVersion:     cloudEventsSpecVersion,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: cloudEventsContentType,
+		Data: ConditionTransitionData{
+			PreviousStatus: previousStatus,
+			Status:         status,
+			Reason:         reason,
+			Message:        message,
+		},
+	})
+}
+
+func (q *cloudEventsQueue) run() {
+	for q.processNext() {
+	}
+}
+
+func (q *cloudEventsQueue) processNext() bool {
+	item, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(item)
+
+	event := item.(CloudEvent)
+	if err := q.sink.Send(context.Background(), event); err != nil {
+		cloudEventsSinkMetrics.WithLabelValues("retried").Inc()
+		q.queue.AddRateLimited(event)
+		return true
+	}
+
+	cloudEventsSinkMetrics.WithLabelValues("delivered").Inc()
+	q.queue.Forget(event)
+	return true
+}
+
+func (q *cloudEventsQueue) shutDown() {
+	if q == nil {
+		return
+	}
+	q.queue.ShutDown()
+}
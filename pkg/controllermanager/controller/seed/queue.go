@@ -0,0 +1,179 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package seed
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gardener/gardener/pkg/controllermanager/apis/config"
+
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// defaultMaxRetries is used for a queue whose config.QueueConfiguration does not set MaxRetries.
+const defaultMaxRetries = 12
+
+// Defaults mirrored from workqueue.DefaultControllerRateLimiter, used for any field a config.RateLimiterConfiguration
+// does not set.
+const (
+	defaultQueueBaseDelay = 5 * time.Millisecond
+	defaultQueueMaxDelay  = 1000 * time.Second
+	defaultQueueQps       = 10
+	defaultQueueBurst     = 100
+)
+
+// buildRateLimiter composes a workqueue.RateLimiter from cfg, falling back to workqueue.DefaultControllerRateLimiter
+// semantics for any field cfg (or cfg.RateLimiter) does not set.
+func buildRateLimiter(cfg *config.QueueConfiguration) workqueue.RateLimiter {
+	baseDelay, maxDelay := time.Duration(defaultQueueBaseDelay), time.Duration(defaultQueueMaxDelay)
+	qps, burst := float64(defaultQueueQps), defaultQueueBurst
+
+	if cfg != nil && cfg.RateLimiter != nil {
+		if cfg.RateLimiter.BaseDelay != nil {
+			baseDelay = cfg.RateLimiter.BaseDelay.Duration
+		}
+		if cfg.RateLimiter.MaxDelay != nil {
+			maxDelay = cfg.RateLimiter.MaxDelay.Duration
+		}
+		if cfg.RateLimiter.Qps != nil {
+			qps = *cfg.RateLimiter.Qps
+		}
+		if cfg.RateLimiter.Burst != nil {
+			burst = *cfg.RateLimiter.Burst
+		}
+	}
+
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(baseDelay, maxDelay),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	)
+}
+
+// queueConfig returns the QueueConfiguration of seedCfg matching queueName, or nil if seedCfg is nil or does not
+// configure that queue.
+func queueConfig(seedCfg *config.SeedControllerConfiguration, queueName string) *config.QueueConfiguration {
+	if seedCfg == nil {
+		return nil
+	}
+	switch queueName {
+	case queueNameSeed:
+		return seedCfg.Seed
+	case queueNameSeedLifecycle:
+		return seedCfg.SeedLifecycle
+	case queueNameBackupBucket:
+		return seedCfg.SeedBackupBucket
+	default:
+		return nil
+	}
+}
+
+// concurrentSyncsOrDefault returns cfg.ConcurrentSyncs, or fallback if cfg or cfg.ConcurrentSyncs is unset.
+func concurrentSyncsOrDefault(cfg *config.QueueConfiguration, fallback int) int {
+	if cfg != nil && cfg.ConcurrentSyncs != nil {
+		return *cfg.ConcurrentSyncs
+	}
+	return fallback
+}
+
+// maxRetriesOrDefault returns cfg.MaxRetries, or defaultMaxRetries if cfg or cfg.MaxRetries is unset.
+func maxRetriesOrDefault(cfg *config.QueueConfiguration) int {
+	if cfg != nil && cfg.MaxRetries != nil {
+		return *cfg.MaxRetries
+	}
+	return defaultMaxRetries
+}
+
+// resolveSeedNameFunc maps a work queue key to the name of the Seed an event about a dropped item should be
+// recorded on.
+type resolveSeedNameFunc func(key string) (string, bool)
+
+// workerCountDelta reports a running-worker delta for a single named queue, so CollectMetrics can export a
+// per-queue gauge instead of one combined count across all three queues.
+type workerCountDelta struct {
+	queue string
+	delta int
+}
+
+// runWorker repeatedly pops items off queue and reconciles them with reconciler until queue is shut down, reporting
+// its running state on workerCh (see Controller.trackRunningWorkers). Unlike controllerutils.CreateWorker, it bounds
+// retries to maxRetries: once exceeded, the item is dropped and an event is recorded on the Seed resolveSeedName
+// maps the key to, instead of requeuing indefinitely.
+func (c *Controller) runWorker(ctx context.Context, queue workqueue.RateLimitingInterface, queueName string, reconciler reconcile.Reconciler, maxRetries int, resolveSeedName resolveSeedNameFunc, waitGroup *sync.WaitGroup, workerCh chan<- workerCountDelta) {
+	waitGroup.Add(1)
+	workerCh <- workerCountDelta{queue: queueName, delta: 1}
+
+	go func() {
+		defer waitGroup.Done()
+		defer func() { workerCh <- workerCountDelta{queue: queueName, delta: -1} }()
+
+		wait.Until(func() {
+			for c.processNextItem(ctx, queue, queueName, reconciler, maxRetries, resolveSeedName) {
+			}
+		}, time.Second, ctx.Done())
+	}()
+}
+
+func (c *Controller) processNextItem(ctx context.Context, queue workqueue.RateLimitingInterface, queueName string, reconciler reconcile.Reconciler, maxRetries int, resolveSeedName resolveSeedNameFunc) bool {
+	key, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+	defer queue.Done(key)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key.(string))
+	if err != nil {
+		queue.Forget(key)
+		return true
+	}
+
+	_, reconcileErr := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}})
+	if reconcileErr == nil {
+		queue.Forget(key)
+		return true
+	}
+
+	if queue.NumRequeues(key) >= maxRetries {
+		queue.Forget(key)
+		c.recordDroppedItem(queueName, key.(string), maxRetries, reconcileErr, resolveSeedName)
+		return true
+	}
+
+	queue.AddRateLimited(key)
+	return true
+}
+
+// recordDroppedItem emits a MaxRetriesExceeded event on the Seed resolveSeedName maps key to, once a queue item has
+// exhausted its retry budget and is being dropped instead of requeued again.
+func (c *Controller) recordDroppedItem(queueName, key string, maxRetries int, reconcileErr error, resolveSeedName resolveSeedNameFunc) {
+	seedName, ok := resolveSeedName(key)
+	if !ok {
+		return
+	}
+
+	seed, err := c.seedLister.Get(seedName)
+	if err != nil {
+		return
+	}
+
+	c.recorder.Eventf(seed, corev1.EventTypeWarning, "MaxRetriesExceeded", "Giving up on %q queue item %q after %d retries: %v", queueName, key, maxRetries, reconcileErr)
+}
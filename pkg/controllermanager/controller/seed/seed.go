@@ -27,16 +27,22 @@ import (
 	"github.com/gardener/gardener/pkg/client/kubernetes/clientmap/keys"
 	"github.com/gardener/gardener/pkg/controllermanager"
 	"github.com/gardener/gardener/pkg/controllermanager/apis/config"
-	"github.com/gardener/gardener/pkg/controllerutils"
 	"github.com/gardener/gardener/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	queueNameSeed          = "Seed"
+	queueNameSeedLifecycle = "Seed Lifecycle"
+	queueNameBackupBucket  = "Backup Bucket"
+)
+
 // Controller controls Seeds.
 type Controller struct {
 	config *config.ControllerManagerConfiguration
@@ -55,9 +61,15 @@ type Controller struct {
 
 	shootLister gardencorelisters.ShootLister
 
-	hasSyncedFuncs         []cache.InformerSynced
-	workerCh               chan int
-	numberOfRunningWorkers int
+	cloudEvents *cloudEventsQueue
+
+	gardenClient client.Client
+
+	hasSyncedFuncs []cache.InformerSynced
+	workerCh       chan workerCountDelta
+
+	runningWorkersMu sync.Mutex
+	runningWorkers   map[string]int
 }
 
 // NewSeedController takes a Kubernetes client for the Garden clusters <k8sGardenClient>, a struct
@@ -69,6 +81,7 @@ func NewSeedController(
 	gardenInformerFactory gardencoreinformers.SharedInformerFactory,
 	kubeInformerFactory kubeinformers.SharedInformerFactory,
 	config *config.ControllerManagerConfiguration,
+	gardenClusterIdentity string,
 	recorder record.EventRecorder,
 ) (
 	*Controller,
@@ -79,6 +92,15 @@ func NewSeedController(
 		return nil, err
 	}
 
+	var cloudEvents *cloudEventsQueue
+	if seedCfg := config.Controllers.Seed; seedCfg != nil && seedCfg.CloudEventsSink != nil {
+		sink, err := NewHTTPCloudEventsSink(*seedCfg.CloudEventsSink)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create CloudEvents sink: %w", err)
+		}
+		cloudEvents = newCloudEventsQueue(sink, gardenClusterIdentity)
+	}
+
 	backupBucketInformer, err := gardenClient.Cache().GetInformer(ctx, &gardencorev1beta1.BackupBucket{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get BackupBucket Informer: %w", err)
@@ -100,18 +122,23 @@ func NewSeedController(
 		secretLister   = kubeInformerFactory.Core().V1().Secrets().Lister()
 	)
 
+	seedCfg := config.Controllers.Seed
+
 	seedController := &Controller{
 		config:                config,
 		seedReconciler:        NewDefaultControl(clientMap, secretLister, seedLister),
 		lifeCycleReconciler:   NewLifecycleDefaultControl(clientMap, leaseLister, seedLister, shootLister, config),
 		recorder:              recorder,
 		seedBackupReconciler:  NewDefaultBackupBucketControl(gardenClient, seedLister),
-		seedBackupBucketQueue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Backup Bucket"),
+		seedBackupBucketQueue: workqueue.NewNamedRateLimitingQueue(buildRateLimiter(queueConfig(seedCfg, queueNameBackupBucket)), queueNameBackupBucket),
 		seedLister:            seedLister,
 		shootLister:           shootLister,
-		seedLifecycleQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Seed Lifecycle"),
-		seedQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Seed"),
-		workerCh:              make(chan int),
+		seedLifecycleQueue:    workqueue.NewNamedRateLimitingQueue(buildRateLimiter(queueConfig(seedCfg, queueNameSeedLifecycle)), queueNameSeedLifecycle),
+		seedQueue:             workqueue.NewNamedRateLimitingQueue(buildRateLimiter(queueConfig(seedCfg, queueNameSeed)), queueNameSeed),
+		cloudEvents:           cloudEvents,
+		gardenClient:          gardenClient.Client(),
+		workerCh:              make(chan workerCountDelta),
+		runningWorkers:        map[string]int{},
 	}
 
 	backupBucketInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -154,21 +181,29 @@ func (c *Controller) Run(ctx context.Context, workers int) {
 		return
 	}
 
-	// Count number of running workers.
+	// Track the number of running workers, broken down per queue.
 	go func() {
-		for res := range c.workerCh {
-			c.numberOfRunningWorkers += res
-			logger.Logger.Debugf("Current number of running Seed workers is %d", c.numberOfRunningWorkers)
+		for delta := range c.workerCh {
+			c.runningWorkersMu.Lock()
+			c.runningWorkers[delta.queue] += delta.delta
+			logger.Logger.Debugf("Current number of running %s workers is %d", delta.queue, c.runningWorkers[delta.queue])
+			c.runningWorkersMu.Unlock()
 		}
 	}()
 
 	logger.Logger.Info("Seed controller initialized.")
 
+	seedCfg := c.config.Controllers.Seed
+
 	var waitGroup sync.WaitGroup
-	for i := 0; i < workers; i++ {
-		controllerutils.CreateWorker(ctx, c.seedQueue, "Seed", c.seedReconciler, &waitGroup, c.workerCh)
-		controllerutils.CreateWorker(ctx, c.seedLifecycleQueue, "Seed Lifecycle", c.lifeCycleReconciler, &waitGroup, c.workerCh)
-		controllerutils.CreateWorker(ctx, c.seedBackupBucketQueue, "Seed Backup Bucket", c.seedBackupReconciler, &waitGroup, c.workerCh)
+	for i := 0; i < concurrentSyncsOrDefault(queueConfig(seedCfg, queueNameSeed), workers); i++ {
+		c.runWorker(ctx, c.seedQueue, queueNameSeed, c.seedReconciler, maxRetriesOrDefault(queueConfig(seedCfg, queueNameSeed)), c.seedNameFromSeedKey, &waitGroup, c.workerCh)
+	}
+	for i := 0; i < concurrentSyncsOrDefault(queueConfig(seedCfg, queueNameSeedLifecycle), workers); i++ {
+		c.runWorker(ctx, c.seedLifecycleQueue, queueNameSeedLifecycle, c.lifeCycleReconciler, maxRetriesOrDefault(queueConfig(seedCfg, queueNameSeedLifecycle)), c.seedNameFromSeedKey, &waitGroup, c.workerCh)
+	}
+	for i := 0; i < concurrentSyncsOrDefault(queueConfig(seedCfg, queueNameBackupBucket), workers); i++ {
+		c.runWorker(ctx, c.seedBackupBucketQueue, queueNameBackupBucket, c.seedBackupReconciler, maxRetriesOrDefault(queueConfig(seedCfg, queueNameBackupBucket)), c.seedNameFromBackupBucketKey, &waitGroup, c.workerCh)
 	}
 
 	// Shutdown handling
@@ -176,32 +211,76 @@ func (c *Controller) Run(ctx context.Context, workers int) {
 	c.seedQueue.ShutDown()
 	c.seedBackupBucketQueue.ShutDown()
 	c.seedLifecycleQueue.ShutDown()
+	c.cloudEvents.shutDown()
 
 	for {
-		if c.seedQueue.Len() == 0 && c.numberOfRunningWorkers == 0 {
+		if c.seedQueue.Len() == 0 && c.RunningWorkers() == 0 {
 			logger.Logger.Debug("No running Seed worker and no items left in the queues. Terminated Seed controller...")
 			break
 		}
-		logger.Logger.Debugf("Waiting for %d Seed worker(s) to finish (%d item(s) left in the queues)...", c.numberOfRunningWorkers, c.seedQueue.Len())
+		logger.Logger.Debugf("Waiting for %d Seed worker(s) to finish (%d item(s) left in the queues)...", c.RunningWorkers(), c.seedQueue.Len())
 		time.Sleep(5 * time.Second)
 	}
 
 	waitGroup.Wait()
 }
 
-// RunningWorkers returns the number of running workers.
+// RunningWorkers returns the total number of running workers across all queues.
 func (c *Controller) RunningWorkers() int {
-	return c.numberOfRunningWorkers
+	c.runningWorkersMu.Lock()
+	defer c.runningWorkersMu.Unlock()
+
+	var total int
+	for _, count := range c.runningWorkers {
+		total += count
+	}
+	return total
 }
 
-// CollectMetrics implements gardenmetrics.ControllerMetricsCollector interface
-func (c *Controller) CollectMetrics(ch chan<- prometheus.Metric) {
-	metric, err := prometheus.NewConstMetric(controllermanager.ControllerWorkerSum, prometheus.GaugeValue, float64(c.RunningWorkers()), "seed")
+// seedNameFromSeedKey resolves the Seed MaxRetriesExceeded events are recorded on for the seedQueue and
+// seedLifecycleQueue, both of which are keyed directly by Seed name.
+func (c *Controller) seedNameFromSeedKey(key string) (string, bool) {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		controllermanager.ScrapeFailures.With(prometheus.Labels{"kind": "seed-controller"}).Inc()
-		return
+		return "", false
+	}
+	return name, true
+}
+
+// seedNameFromBackupBucketKey resolves the Seed MaxRetriesExceeded events are recorded on for the
+// seedBackupBucketQueue, which is keyed by BackupBucket name.
+func (c *Controller) seedNameFromBackupBucketKey(key string) (string, bool) {
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return "", false
+	}
+
+	backupBucket := &gardencorev1beta1.BackupBucket{}
+	if err := c.gardenClient.Get(context.TODO(), client.ObjectKey{Name: name}, backupBucket); err != nil || backupBucket.Spec.SeedName == nil {
+		return "", false
+	}
+	return *backupBucket.Spec.SeedName, true
+}
+
+// CollectMetrics implements gardenmetrics.ControllerMetricsCollector interface. It exports one "seed" controller
+// worker gauge per queue (queue= label) so operators can see which queue is saturated, instead of a single combined
+// count across all three.
+func (c *Controller) CollectMetrics(ch chan<- prometheus.Metric) {
+	c.runningWorkersMu.Lock()
+	runningWorkers := make(map[string]int, len(c.runningWorkers))
+	for queue, count := range c.runningWorkers {
+		runningWorkers[queue] = count
+	}
+	c.runningWorkersMu.Unlock()
+
+	for queue, count := range runningWorkers {
+		metric, err := prometheus.NewConstMetric(controllermanager.ControllerWorkerSum, prometheus.GaugeValue, float64(count), "seed", queue)
+		if err != nil {
+			controllermanager.ScrapeFailures.With(prometheus.Labels{"kind": "seed-controller"}).Inc()
+			continue
+		}
+		ch <- metric
 	}
-	ch <- metric
 }
 
 func reconcileAfter(d time.Duration) (reconcile.Result, error) {
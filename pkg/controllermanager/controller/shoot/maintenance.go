@@ -0,0 +1,431 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package shoot hosts the controller-manager logic that maintains Shoots (Kubernetes and machine image version
+// upgrades). This file only covers the read-only preview half of that logic; the reconciler that actually applies
+// maintenance to a Shoot is not part of this package yet.
+package shoot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+)
+
+// PreviewAnnotation is set on a Shoot by WritePreviewAnnotation with the JSON-encoded MaintenancePreview that
+// PreviewMaintenance computed for it, so that `kubectl get shoot ... -o yaml` shows the next maintenance run's
+// planned changes without requiring a dedicated preview subresource.
+const PreviewAnnotation = "maintenance.gardener.cloud/preview"
+
+// MaintenanceReason explains why PreviewMaintenance decided that a version update is necessary.
+// MaintenanceReason doubles as the Reason of the maintenance-related Conditions ComputeMaintenanceConditions
+// maintains on a Shoot's status, so its values are CamelCase identifiers rather than sentences.
+type MaintenanceReason string
+
+const (
+	// ReasonUpToDate is used when no update is pending at all.
+	ReasonUpToDate MaintenanceReason = "UpToDate"
+	// ReasonVersionDeprecated is used when the current version is classified as deprecated, but not yet expired.
+	ReasonVersionDeprecated MaintenanceReason = "DeprecatedVersionAutoUpdate"
+	// ReasonVersionExpired is used when the current version's expiration date is in the past, forcing an update. It
+	// is also the Reason of ConditionForceUpdatePending whenever a running version has an expiration date set, even
+	// one that is still in the future.
+	ReasonVersionExpired MaintenanceReason = "ExpirationDateReached"
+	// ReasonHigherPatchAvailable is used for a voluntary (AutoUpdate-driven) update to a higher patch version.
+	ReasonHigherPatchAvailable MaintenanceReason = "HigherPatchAvailable"
+	// ReasonMinorVersionBump is used when the current minor version has no higher, usable patch version left, so a
+	// forced update has to move to the lowest higher minor version instead.
+	ReasonMinorVersionBump MaintenanceReason = "MinorVersionUpdateRequired"
+	// ReasonRolloutInProgress is used for WorkersUpgrading while a worker pool's nodes still run an older machine
+	// image version than the one now configured in the Shoot's spec.
+	ReasonRolloutInProgress MaintenanceReason = "RolloutInProgress"
+	// ReasonWaitingForWorkerRollout is used when a forced Kubernetes minor version hop is withheld because applying
+	// it now would push a worker pool's kubelet more than 2 minor versions behind the control plane.
+	ReasonWaitingForWorkerRollout MaintenanceReason = "WaitingForWorkerRollout"
+)
+
+// maxKubeletSkew is the maximum number of Kubernetes minor versions the control plane may run ahead of a worker
+// pool's kubelet, mirroring Kubernetes' version skew policy.
+const maxKubeletSkew = 2
+
+// VersionUpdate describes a single version transition PreviewMaintenance expects a future maintenance run to apply.
+type VersionUpdate struct {
+	// Current is the version a Shoot (or one of its worker pools) is on today.
+	Current string
+	// Target is the version PreviewMaintenance expects maintenance to update Current to. If multiple minor versions
+	// need to be skipped, Target is only the next hop, not the final version - see Blocked.
+	Target string
+	// Voluntary is true if the update would only be applied because AutoUpdate is enabled for this kind of version;
+	// false if Current has expired and the update would be forced regardless of AutoUpdate.
+	Voluntary bool
+	// Blocked is true if Target is needed (Current has expired) but cannot be applied yet because doing so would
+	// violate the Kubernetes version skew policy against a worker pool that has not rolled out the previous hop's
+	// version yet. The maintenance run is expected to leave Current as-is and retry in the next window.
+	Blocked bool
+	// Reason explains why the update is expected (or, if Blocked, why it is being withheld).
+	Reason MaintenanceReason
+}
+
+// MachineImageUpdate is a VersionUpdate scoped to a single worker pool's machine image.
+type MachineImageUpdate struct {
+	Pool string
+	VersionUpdate
+}
+
+// MaintenancePreview is the structured plan PreviewMaintenance returns: what the next maintenance run would change,
+// and why, without mutating the Shoot.
+type MaintenancePreview struct {
+	// Kubernetes is nil if no Kubernetes version update is currently expected.
+	Kubernetes *VersionUpdate
+	// MachineImages only contains an entry for worker pools that are expected to receive a machine image update.
+	MachineImages []MachineImageUpdate
+}
+
+// PreviewMaintenance computes the MaintenancePreview for shoot given cloudProfile, without mutating either. It
+// mirrors the version-resolution rules a maintenance reconciler applies: an update is voluntary if the relevant
+// AutoUpdate flag is enabled, or forced if the current version has expired (or is no longer listed at all).
+func PreviewMaintenance(_ context.Context, shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) (*MaintenancePreview, error) {
+	preview := &MaintenancePreview{}
+
+	kubernetesUpdate, err := previewKubernetesVersion(shoot, cloudProfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview Kubernetes version maintenance: %w", err)
+	}
+	preview.Kubernetes = kubernetesUpdate
+
+	for _, pool := range shoot.Spec.Provider.Workers {
+		if pool.Machine.Image == nil {
+			continue
+		}
+
+		update, err := previewMachineImageVersion(shoot, cloudProfile, pool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preview machine image maintenance for worker pool %q: %w", pool.Name, err)
+		}
+		if update != nil {
+			preview.MachineImages = append(preview.MachineImages, MachineImageUpdate{Pool: pool.Name, VersionUpdate: *update})
+		}
+	}
+
+	return preview, nil
+}
+
+// WritePreviewAnnotation serializes preview onto shoot's PreviewAnnotation. Callers are responsible for persisting
+// shoot afterwards, e.g. via a Patch against the garden cluster.
+func WritePreviewAnnotation(shoot *gardencorev1beta1.Shoot, preview *MaintenancePreview) error {
+	data, err := json.Marshal(preview)
+	if err != nil {
+		return fmt.Errorf("failed to marshal maintenance preview: %w", err)
+	}
+
+	if shoot.Annotations == nil {
+		shoot.Annotations = map[string]string{}
+	}
+	shoot.Annotations[PreviewAnnotation] = string(data)
+
+	return nil
+}
+
+func previewKubernetesVersion(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) (*VersionUpdate, error) {
+	current := shoot.Spec.Kubernetes.Version
+
+	autoUpdate := true
+	var channel *gardencorev1beta1.Channel
+	if shoot.Spec.Maintenance != nil && shoot.Spec.Maintenance.AutoUpdate != nil {
+		autoUpdate = shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion
+		channel = shoot.Spec.Maintenance.AutoUpdate.KubernetesChannel
+	}
+
+	currentExpirable, found := findExpirableVersion(cloudProfile.Spec.Kubernetes.Versions, current)
+	forced := !found || isExpired(currentExpirable)
+
+	if !autoUpdate && !forced {
+		return nil, nil
+	}
+
+	currentParsed, err := parseVersion(current)
+	if err != nil {
+		return nil, err
+	}
+
+	// A forced update (the current version has expired) must proceed regardless of channel, since withholding it
+	// could otherwise strand a Shoot on an expired version indefinitely. Channel filtering therefore only applies
+	// to voluntary, AutoUpdate-driven bumps.
+	candidates := cloudProfile.Spec.Kubernetes.Versions
+	if !forced {
+		candidates = filterVersionsByChannel(candidates, channel)
+	}
+
+	if target, ok := highestPatchInMinor(candidates, currentParsed); ok {
+		return &VersionUpdate{Current: current, Target: target, Voluntary: !forced, Reason: reasonFor(currentExpirable, found, forced)}, nil
+	}
+
+	if !forced {
+		return nil, nil
+	}
+
+	if target, ok := highestPatchInNextMinor(cloudProfile.Spec.Kubernetes.Versions, currentParsed); ok {
+		targetParsed, err := parseVersion(target)
+		if err != nil {
+			return nil, err
+		}
+
+		// Advancing the control plane to target is only safe once every worker pool's kubelet is within
+		// maxKubeletSkew minor versions of it. A pool that hasn't rolled out a previous hop yet (or that pins an
+		// older Kubernetes version) blocks the next hop until it catches up.
+		if kubeletSkewViolated(shoot, targetParsed) {
+			return &VersionUpdate{
+				Current:   current,
+				Target:    target,
+				Voluntary: false,
+				Blocked:   true,
+				Reason:    ReasonWaitingForWorkerRollout,
+			}, nil
+		}
+
+		return &VersionUpdate{Current: current, Target: target, Voluntary: false, Reason: ReasonMinorVersionBump}, nil
+	}
+
+	return nil, nil
+}
+
+// kubeletSkewViolated reports whether advancing the control plane to target would leave any worker pool's kubelet
+// more than maxKubeletSkew minor versions behind.
+func kubeletSkewViolated(shoot *gardencorev1beta1.Shoot, target parsedVersion) bool {
+	for _, pool := range shoot.Spec.Provider.Workers {
+		kubeletVersion := shoot.Spec.Kubernetes.Version
+		if pool.Kubernetes != nil && pool.Kubernetes.Version != nil {
+			kubeletVersion = *pool.Kubernetes.Version
+		}
+
+		kubeletParsed, err := parseVersion(kubeletVersion)
+		if err != nil {
+			continue
+		}
+
+		if kubeletParsed.minor+maxKubeletSkew < target.minor {
+			return true
+		}
+	}
+
+	return false
+}
+
+func previewMachineImageVersion(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile, pool gardencorev1beta1.Worker) (*VersionUpdate, error) {
+	current := pool.Machine.Image.Version
+
+	autoUpdate := true
+	if shoot.Spec.Maintenance != nil && shoot.Spec.Maintenance.AutoUpdate != nil {
+		autoUpdate = shoot.Spec.Maintenance.AutoUpdate.MachineImageVersion
+	}
+
+	_, image, err := gardencorev1beta1helper.DetermineMachineImageForName(cloudProfile, pool.Machine.Image.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	currentExpirable, found := findExpirableVersion(image.Versions, current)
+	forced := !found || isExpired(currentExpirable)
+
+	if !autoUpdate && !forced {
+		return nil, nil
+	}
+
+	target, ok := highestUsableVersion(image.Versions, forced)
+	if !ok || target == current {
+		return nil, nil
+	}
+
+	return &VersionUpdate{Current: current, Target: target, Voluntary: !forced, Reason: reasonFor(currentExpirable, found, forced)}, nil
+}
+
+func reasonFor(version gardencorev1beta1.ExpirableVersion, found, forced bool) MaintenanceReason {
+	if !forced {
+		return ReasonHigherPatchAvailable
+	}
+	if found && isExpired(version) {
+		return ReasonVersionExpired
+	}
+	if found && version.Classification != nil && *version.Classification == gardencorev1beta1.ClassificationDeprecated {
+		return ReasonVersionDeprecated
+	}
+	return ReasonVersionExpired
+}
+
+func findExpirableVersion(versions []gardencorev1beta1.ExpirableVersion, version string) (gardencorev1beta1.ExpirableVersion, bool) {
+	for _, v := range versions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+	return gardencorev1beta1.ExpirableVersion{}, false
+}
+
+func isExpired(version gardencorev1beta1.ExpirableVersion) bool {
+	return version.ExpirationDate != nil && version.ExpirationDate.Time.Before(time.Now())
+}
+
+// parsedVersion is a minimal major.minor.patch decomposition, used instead of a full semver library since none is
+// available in this checkout.
+type parsedVersion struct {
+	major, minor, patch int
+	raw                 string
+}
+
+func parseVersion(version string) (parsedVersion, error) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) != 3 {
+		return parsedVersion{}, fmt.Errorf("version %q is not in major.minor.patch format", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return parsedVersion{}, fmt.Errorf("invalid major version in %q: %w", version, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return parsedVersion{}, fmt.Errorf("invalid minor version in %q: %w", version, err)
+	}
+	patch, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return parsedVersion{}, fmt.Errorf("invalid patch version in %q: %w", version, err)
+	}
+
+	return parsedVersion{major: major, minor: minor, patch: patch, raw: version}, nil
+}
+
+// highestPatchInMinor returns the highest version in versions that shares current's major.minor and is itself
+// higher than current, or false if current is already the highest patch of its minor.
+func highestPatchInMinor(versions []gardencorev1beta1.ExpirableVersion, current parsedVersion) (string, bool) {
+	var best parsedVersion
+	found := false
+
+	for _, v := range versions {
+		parsed, err := parseVersion(v.Version)
+		if err != nil || parsed.major != current.major || parsed.minor != current.minor {
+			continue
+		}
+		if !found || parsed.patch > best.patch {
+			best, found = parsed, true
+		}
+	}
+
+	if !found || best.patch <= current.patch {
+		return "", false
+	}
+	return best.raw, true
+}
+
+// highestPatchInNextMinor returns the highest patch version of the lowest minor version above current's, or false
+// if no higher minor version exists.
+func highestPatchInNextMinor(versions []gardencorev1beta1.ExpirableVersion, current parsedVersion) (string, bool) {
+	nextMinor := -1
+	for _, v := range versions {
+		parsed, err := parseVersion(v.Version)
+		if err != nil || parsed.major != current.major || parsed.minor <= current.minor {
+			continue
+		}
+		if nextMinor == -1 || parsed.minor < nextMinor {
+			nextMinor = parsed.minor
+		}
+	}
+	if nextMinor == -1 {
+		return "", false
+	}
+
+	var best parsedVersion
+	found := false
+	for _, v := range versions {
+		parsed, err := parseVersion(v.Version)
+		if err != nil || parsed.major != current.major || parsed.minor != nextMinor {
+			continue
+		}
+		if !found || parsed.patch > best.patch {
+			best, found = parsed, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return best.raw, true
+}
+
+// highestUsableVersion returns the highest version in versions. Deprecated versions are skipped unless forced is
+// true, since a forced update must proceed even if every remaining version is itself deprecated.
+func highestUsableVersion(versions []gardencorev1beta1.ExpirableVersion, forced bool) (string, bool) {
+	var best parsedVersion
+	found := false
+
+	for _, v := range versions {
+		if !forced && v.Classification != nil && *v.Classification == gardencorev1beta1.ClassificationDeprecated {
+			continue
+		}
+
+		parsed, err := parseVersion(v.Version)
+		if err != nil {
+			continue
+		}
+		if !found || compareVersions(parsed, best) > 0 {
+			best, found = parsed, true
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+	return best.raw, true
+}
+
+// channelRank orders maintenance channels from most to least conservative. A Shoot subscribed to a given channel
+// is eligible for versions tagged with that channel or any more conservative one, mirroring how managed-Kubernetes
+// release channels nest (e.g. "rapid" subscribers still receive "stable" versions, not just "rapid" ones).
+var channelRank = map[gardencorev1beta1.Channel]int{
+	gardencorev1beta1.ChannelStable:  0,
+	gardencorev1beta1.ChannelRegular: 1,
+	gardencorev1beta1.ChannelRapid:   2,
+	gardencorev1beta1.ChannelLatest:  3,
+}
+
+// filterVersionsByChannel returns the subset of versions eligible for channel. A nil channel (the Shoot did not opt
+// into channel-based auto-updates) or a version with no Channel set (the CloudProfile operator did not tag it)
+// leaves the version eligible, so the feature remains fully opt-in on both sides.
+func filterVersionsByChannel(versions []gardencorev1beta1.ExpirableVersion, channel *gardencorev1beta1.Channel) []gardencorev1beta1.ExpirableVersion {
+	if channel == nil {
+		return versions
+	}
+
+	filtered := make([]gardencorev1beta1.ExpirableVersion, 0, len(versions))
+	for _, v := range versions {
+		if v.Channel == nil || channelRank[*v.Channel] <= channelRank[*channel] {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+func compareVersions(a, b parsedVersion) int {
+	if a.major != b.major {
+		return a.major - b.major
+	}
+	if a.minor != b.minor {
+		return a.minor - b.minor
+	}
+	return a.patch - b.patch
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shoot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+)
+
+// Maintenance-related Condition types maintained on a Shoot's status by ComputeMaintenanceConditions, mirroring the
+// granular, reason-carrying condition style Cluster API providers use (e.g. ScaleSetModelUpdated).
+const (
+	// ConditionMachineImageUpToDate is False if any worker pool is expected to receive a machine image update in
+	// the next maintenance run.
+	ConditionMachineImageUpToDate gardencorev1beta1.ConditionType = "MachineImageUpToDate"
+	// ConditionKubernetesVersionUpToDate is False if the Shoot's Kubernetes version is expected to be updated in
+	// the next maintenance run.
+	ConditionKubernetesVersionUpToDate gardencorev1beta1.ConditionType = "KubernetesVersionUpToDate"
+	// ConditionForceUpdatePending is True once a running version (Kubernetes or any worker pool's machine image)
+	// has an expiration date, regardless of AutoUpdate, so that the forced update becomes visible ahead of time.
+	ConditionForceUpdatePending gardencorev1beta1.ConditionType = "ForceUpdatePending"
+	// ConditionWorkersUpgrading is True while a worker pool's nodes still run an older machine image version than
+	// the one now configured in the Shoot's spec, i.e. a maintenance-triggered rollout is still in progress.
+	ConditionWorkersUpgrading gardencorev1beta1.ConditionType = "WorkersUpgrading"
+)
+
+// WorkerPoolRolloutStatus reports the machine image version a worker pool's nodes are actually running. This
+// package has no access to Node/MachineDeployment state itself, so callers that do (e.g. a worker status watcher)
+// supply it to ComputeMaintenanceConditions in order to derive ConditionWorkersUpgrading.
+type WorkerPoolRolloutStatus struct {
+	Pool                string
+	MachineImageVersion string
+}
+
+// ComputeMaintenanceConditions derives ConditionMachineImageUpToDate, ConditionKubernetesVersionUpToDate,
+// ConditionForceUpdatePending, and ConditionWorkersUpgrading for shoot from preview (see PreviewMaintenance) and
+// rollouts, merging them into shoot.Status.Conditions the same way any other condition-maintaining reconciler in
+// this codebase does.
+func ComputeMaintenanceConditions(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile, preview *MaintenancePreview, rollouts []WorkerPoolRolloutStatus) []gardencorev1beta1.Condition {
+	conditions := shoot.Status.Conditions
+
+	conditions = mergeMaintenanceCondition(conditions, kubernetesVersionUpToDate(preview))
+	conditions = mergeMaintenanceCondition(conditions, machineImageUpToDate(preview))
+	conditions = mergeMaintenanceCondition(conditions, forceUpdatePending(shoot, cloudProfile))
+	conditions = mergeMaintenanceCondition(conditions, workersUpgrading(shoot, rollouts))
+
+	return conditions
+}
+
+type maintenanceCondition struct {
+	conditionType gardencorev1beta1.ConditionType
+	status        gardencorev1beta1.ConditionStatus
+	reason        MaintenanceReason
+	message       string
+}
+
+func mergeMaintenanceCondition(conditions []gardencorev1beta1.Condition, c maintenanceCondition) []gardencorev1beta1.Condition {
+	condition := gardencorev1beta1helper.GetOrInitCondition(conditions, c.conditionType)
+	condition = gardencorev1beta1helper.UpdatedCondition(condition, c.status, string(c.reason), c.message)
+	return gardencorev1beta1helper.MergeConditions(conditions, condition)
+}
+
+func kubernetesVersionUpToDate(preview *MaintenancePreview) maintenanceCondition {
+	if preview.Kubernetes == nil {
+		return maintenanceCondition{ConditionKubernetesVersionUpToDate, gardencorev1beta1.ConditionTrue, ReasonUpToDate, "The configured Kubernetes version is up to date."}
+	}
+
+	if preview.Kubernetes.Blocked {
+		return maintenanceCondition{
+			ConditionKubernetesVersionUpToDate,
+			gardencorev1beta1.ConditionFalse,
+			preview.Kubernetes.Reason,
+			fmt.Sprintf("Kubernetes version %s has expired and needs to move to %s, but this hop is withheld until worker pools catch up with the version skew policy.", preview.Kubernetes.Current, preview.Kubernetes.Target),
+		}
+	}
+
+	return maintenanceCondition{
+		ConditionKubernetesVersionUpToDate,
+		gardencorev1beta1.ConditionFalse,
+		preview.Kubernetes.Reason,
+		fmt.Sprintf("Kubernetes version %s will be updated to %s in the next maintenance window.", preview.Kubernetes.Current, preview.Kubernetes.Target),
+	}
+}
+
+func machineImageUpToDate(preview *MaintenancePreview) maintenanceCondition {
+	if len(preview.MachineImages) == 0 {
+		return maintenanceCondition{ConditionMachineImageUpToDate, gardencorev1beta1.ConditionTrue, ReasonUpToDate, "All worker pools run an up-to-date machine image version."}
+	}
+
+	pools := make([]string, 0, len(preview.MachineImages))
+	for _, update := range preview.MachineImages {
+		pools = append(pools, fmt.Sprintf("%s (%s -> %s)", update.Pool, update.Current, update.Target))
+	}
+
+	return maintenanceCondition{
+		ConditionMachineImageUpToDate,
+		gardencorev1beta1.ConditionFalse,
+		preview.MachineImages[0].Reason,
+		fmt.Sprintf("Worker pool(s) will receive a machine image update in the next maintenance window: %s.", strings.Join(pools, ", ")),
+	}
+}
+
+// forceUpdatePending reports the earliest expiration date among the Shoot's currently configured Kubernetes and
+// machine image versions. The remaining time until that date is embedded in the condition's message as
+// secondsUntilForcedUpdate, since gardencorev1beta1.Condition has no field to carry it separately.
+func forceUpdatePending(shoot *gardencorev1beta1.Shoot, cloudProfile *gardencorev1beta1.CloudProfile) maintenanceCondition {
+	var earliest *time.Time
+
+	if v, found := findExpirableVersion(cloudProfile.Spec.Kubernetes.Versions, shoot.Spec.Kubernetes.Version); found && v.ExpirationDate != nil {
+		earliest = &v.ExpirationDate.Time
+	}
+
+	for _, pool := range shoot.Spec.Provider.Workers {
+		if pool.Machine.Image == nil {
+			continue
+		}
+
+		_, image, err := gardencorev1beta1helper.DetermineMachineImageForName(cloudProfile, pool.Machine.Image.Name)
+		if err != nil {
+			continue
+		}
+
+		if v, found := findExpirableVersion(image.Versions, pool.Machine.Image.Version); found && v.ExpirationDate != nil {
+			if earliest == nil || v.ExpirationDate.Time.Before(*earliest) {
+				earliest = &v.ExpirationDate.Time
+			}
+		}
+	}
+
+	if earliest == nil {
+		return maintenanceCondition{ConditionForceUpdatePending, gardencorev1beta1.ConditionFalse, ReasonUpToDate, "No running version has a pending expiration date."}
+	}
+
+	secondsUntilForcedUpdate := int64(time.Until(*earliest).Seconds())
+	if secondsUntilForcedUpdate < 0 {
+		secondsUntilForcedUpdate = 0
+	}
+
+	return maintenanceCondition{
+		ConditionForceUpdatePending,
+		gardencorev1beta1.ConditionTrue,
+		ReasonVersionExpired,
+		fmt.Sprintf("A forced update is pending once the currently configured version expires on %s (secondsUntilForcedUpdate=%d).", earliest.Format(time.RFC3339), secondsUntilForcedUpdate),
+	}
+}
+
+func workersUpgrading(shoot *gardencorev1beta1.Shoot, rollouts []WorkerPoolRolloutStatus) maintenanceCondition {
+	rolledOutVersion := make(map[string]string, len(rollouts))
+	for _, r := range rollouts {
+		rolledOutVersion[r.Pool] = r.MachineImageVersion
+	}
+
+	var upgrading []string
+	for _, pool := range shoot.Spec.Provider.Workers {
+		if pool.Machine.Image == nil {
+			continue
+		}
+
+		version, known := rolledOutVersion[pool.Name]
+		if known && version != pool.Machine.Image.Version {
+			upgrading = append(upgrading, pool.Name)
+		}
+	}
+
+	if len(upgrading) == 0 {
+		return maintenanceCondition{ConditionWorkersUpgrading, gardencorev1beta1.ConditionFalse, ReasonUpToDate, "All worker pools run the machine image version configured in the spec."}
+	}
+
+	return maintenanceCondition{
+		ConditionWorkersUpgrading,
+		gardencorev1beta1.ConditionTrue,
+		ReasonRolloutInProgress,
+		fmt.Sprintf("Worker pool(s) %s still run an older machine image version than configured in the spec.", strings.Join(upgrading, ", ")),
+	}
+}
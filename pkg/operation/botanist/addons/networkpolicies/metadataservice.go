@@ -0,0 +1,81 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies
+
+import "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+
+// MetadataServiceEndpoint describes one cloud provider's instance metadata endpoint that seed components may need
+// network access to (e.g. to fetch an attached managed identity token or read instance tags).
+type MetadataServiceEndpoint struct {
+	// Host is the metadata endpoint's address, e.g. "169.254.169.254".
+	Host string
+	// Port is the metadata endpoint's port.
+	Port int32
+}
+
+// MetadataServiceSidecarRequirement describes an additional constraint the generated NetworkPolicy cannot itself
+// express (an HTTP header the CNI has no way to filter on, or a client certificate presented over mTLS) and that
+// must instead be enforced by a sidecar proxy placed in front of the metadata endpoint.
+type MetadataServiceSidecarRequirement struct {
+	// Description explains, for humans reading the generated manifests, why the sidecar is required.
+	Description string
+	// RequiredHeader, if set, is an HTTP request header (e.g. "X-aws-ec2-metadata-token" for IMDSv2) the sidecar
+	// must require before forwarding a request upstream.
+	RequiredHeader string
+	// RequireMTLS indicates the sidecar must terminate and verify a client certificate before forwarding.
+	RequireMTLS bool
+}
+
+// MetadataServicePolicy is implemented once per cloud provider and tells the network policy generator (a) where
+// that provider's metadata service lives, (b) which seed components legitimately call it, and (c) any extra
+// hardening the generated NetworkPolicy should layer on top via a sidecar proxy, since IMDSv2-style
+// header/credential requirements are not something a NetworkPolicy object alone can enforce.
+type MetadataServicePolicy interface {
+	// Provider returns the cloud provider this policy applies to.
+	Provider() v1beta1.CloudProvider
+	// Endpoints returns the metadata service host/port pairs seed components may be allowed to reach.
+	Endpoints() []MetadataServiceEndpoint
+	// AllowedComponents returns the names (SourcePod.Name-style identifiers, e.g. "kube-controller-manager") of the
+	// seed components that are actually permitted egress to Endpoints(). Every other component's generated
+	// NetworkPolicy explicitly denies it.
+	AllowedComponents() []string
+	// SidecarRequirement returns the additional enforcement the generated NetworkPolicy cannot express on its own,
+	// or nil if plain IP/port filtering is sufficient for this provider.
+	SidecarRequirement() *MetadataServiceSidecarRequirement
+}
+
+// azureMetadataServicePolicy is the Azure MetadataServicePolicy. Azure's cloud provider integration authenticates
+// to ARM from kube-controller-manager (the in-tree azure cloud provider), not from cloud-controller-manager, so
+// that is the only component granted egress to the metadata endpoint.
+type azureMetadataServicePolicy struct{}
+
+// AzureMetadataServicePolicy is the MetadataServicePolicy for the Azure provider.
+var AzureMetadataServicePolicy MetadataServicePolicy = azureMetadataServicePolicy{}
+
+func (azureMetadataServicePolicy) Provider() v1beta1.CloudProvider {
+	return v1beta1.CloudProviderAzure
+}
+
+func (azureMetadataServicePolicy) Endpoints() []MetadataServiceEndpoint {
+	return []MetadataServiceEndpoint{{Host: "169.254.169.254", Port: 80}}
+}
+
+func (azureMetadataServicePolicy) AllowedComponents() []string {
+	return []string{"kube-controller-manager"}
+}
+
+func (azureMetadataServicePolicy) SidecarRequirement() *MetadataServiceSidecarRequirement {
+	return nil
+}
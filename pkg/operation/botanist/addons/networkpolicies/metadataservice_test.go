@@ -0,0 +1,47 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicies_test
+
+import (
+	. "github.com/gardener/gardener/pkg/operation/botanist/addons/networkpolicies"
+
+	"github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetadataServicePolicy", func() {
+	Describe("AzureMetadataServicePolicy", func() {
+		It("should target the Azure cloud provider", func() {
+			Expect(AzureMetadataServicePolicy.Provider()).To(Equal(v1beta1.CloudProviderAzure))
+		})
+
+		It("should only allow kube-controller-manager egress to the metadata endpoint", func() {
+			Expect(AzureMetadataServicePolicy.AllowedComponents()).To(ConsistOf("kube-controller-manager"))
+		})
+
+		It("should expose the well-known Azure metadata endpoint", func() {
+			Expect(AzureMetadataServicePolicy.Endpoints()).To(ConsistOf(MetadataServiceEndpoint{
+				Host: "169.254.169.254",
+				Port: 80,
+			}))
+		})
+
+		It("should not require a sidecar proxy", func() {
+			Expect(AzureMetadataServicePolicy.SidecarRequirement()).To(BeNil())
+		})
+	})
+})
@@ -37,6 +37,36 @@ const (
 	gatewayName = v1beta1constants.DeploymentNameKubeAPIServer
 )
 
+// IngressClass identifies which ingress technology terminates and routes SNI traffic for the kube-apiserver
+// Service. Landscapes that don't want to run Istio can pick an alternative that fits their existing L4/L7 ingress,
+// the same way Knative lets operators swap its networking layer between istio and kourier.
+type IngressClass string
+
+const (
+	// IstioIngressClass routes SNI traffic using an Istio Gateway/VirtualService. This is the default, and the only
+	// option gardener has historically supported.
+	IstioIngressClass IngressClass = "istio"
+	// EnvoyGatewayIngressClass routes SNI traffic using a Gateway API TLSRoute, for landscapes that expose shoot
+	// control planes through an existing Gateway API implementation (e.g. Envoy Gateway or Contour) instead of
+	// running Istio.
+	EnvoyGatewayIngressClass IngressClass = "envoy-gateway"
+)
+
+// TLSMode determines whether the ingress gateway passes the client's TLS session through to the kube-apiserver
+// unmodified, or terminates it and re-encrypts a new TLS (optionally mutual) session to the kube-apiserver.
+type TLSMode string
+
+const (
+	// PassthroughTLSMode forwards the client TLS session to the kube-apiserver unmodified, based on the SNI server
+	// name alone. This is the default, and the only mode gardener has historically supported.
+	PassthroughTLSMode TLSMode = "Passthrough"
+	// ReencryptTLSMode routes SNI traffic through an HTTP route and opens a new, separate TLS session to the
+	// kube-apiserver using UpstreamClientCertSecretName for mTLS and UpstreamCACertSecretName to verify the
+	// kube-apiserver's serving certificate. The client-facing TLS server settings of the shared Gateway are not
+	// programmed by this component, so GatewayServingCertSecretName is not applied anywhere.
+	ReencryptTLSMode TLSMode = "Reencrypt"
+)
+
 // SNIValues configure the kube-apiserver service SNI.
 type SNIValues struct {
 	Hosts                    []string
@@ -45,6 +75,22 @@ type SNIValues struct {
 	IstioIngressGateway      IstioIngressGateway
 	InternalDNSNameApiserver string
 	ReversedVPN              ReversedVPN
+	// IngressClass selects which ingress technology routes SNI traffic to the kube-apiserver Service. Defaults to
+	// IstioIngressClass if empty, to preserve existing behaviour.
+	IngressClass IngressClass
+	// TLSMode selects whether the ingress gateway passes the client TLS session through or terminates and
+	// re-encrypts it. Defaults to PassthroughTLSMode if empty, to preserve existing behaviour. Only honoured by the
+	// IstioIngressClass backend.
+	TLSMode TLSMode
+	// GatewayServingCertSecretName names the seed-namespace TLS Secret (server cert/key) for the gateway's
+	// client-facing side in ReencryptTLSMode. No object deployed by this component references it.
+	GatewayServingCertSecretName string
+	// UpstreamClientCertSecretName names the seed-namespace TLS Secret (client cert/key) the gateway presents to
+	// the kube-apiserver for mTLS in ReencryptTLSMode.
+	UpstreamClientCertSecretName string
+	// UpstreamCACertSecretName names the seed-namespace Secret holding the CA bundle the gateway uses to verify the
+	// kube-apiserver's serving certificate in ReencryptTLSMode.
+	UpstreamCACertSecretName string
 }
 
 // IstioIngressGateway contains the values for istio ingress gateway configuration.
@@ -58,8 +104,8 @@ type ReversedVPN struct {
 	Enabled bool
 }
 
-// NewSNI creates a new instance of DeployWaiter which deploys Istio resources for
-// kube-apiserver SNI access.
+// NewSNI creates a new instance of DeployWaiter which routes SNI traffic to the kube-apiserver Service, using the
+// ingress technology selected by values.IngressClass.
 func NewSNI(
 	client client.Client,
 	namespace string,
@@ -69,23 +115,39 @@ func NewSNI(
 		values = &SNIValues{}
 	}
 
-	return &sni{
-		client:    client,
-		namespace: namespace,
-		values:    values,
+	switch values.IngressClass {
+	case EnvoyGatewayIngressClass:
+		return &envoyGatewaySNI{
+			client:    client,
+			namespace: namespace,
+			values:    values,
+		}
+	case IstioIngressClass, "":
+		fallthrough
+	default:
+		return &istioSNI{
+			client:    client,
+			namespace: namespace,
+			values:    values,
+		}
 	}
 }
 
-type sni struct {
+type istioSNI struct {
 	client    client.Client
 	namespace string
 	values    *SNIValues
 }
 
-func (s *sni) Deploy(ctx context.Context) error {
-	var (
-		virtualService = s.emptyVirtualService()
-	)
+func (s *istioSNI) Deploy(ctx context.Context) error {
+	if s.values.TLSMode == ReencryptTLSMode {
+		return s.deployReencrypt(ctx)
+	}
+	return s.deployPassthrough(ctx)
+}
+
+func (s *istioSNI) deployPassthrough(ctx context.Context) error {
+	virtualService := s.emptyVirtualService()
 
 	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, virtualService, func() error {
 		virtualService.Labels = getLabels()
@@ -114,21 +176,89 @@ func (s *sni) Deploy(ctx context.Context) error {
 	return nil
 }
 
-func (s *sni) Destroy(ctx context.Context) error {
+// deployReencrypt terminates the client TLS session at the gateway (via an HTTP route matched on SNI host instead
+// of a TLS passthrough route) and opens a new, mutually authenticated TLS session to the kube-apiserver, programming
+// a DestinationRule with TLS.Mode=MUTUAL and the upstream CA/client cert Secrets named on s.values.
+//
+// TODOME: the client-facing half of the handshake - pointing the shared istio-ingressgateway Gateway's per-host
+// Server.Tls at GatewayServingCertSecretName - is not done here, since that Gateway object is shared across every
+// Shoot on the seed and isn't owned/created by this component (see gatewayName above); it needs to gain per-host
+// TLS server entries wherever it is deployed.
+func (s *istioSNI) deployReencrypt(ctx context.Context) error {
+	virtualService := s.emptyVirtualService()
+	destination := fmt.Sprintf("%s.%s.svc.%s", v1beta1constants.DeploymentNameKubeAPIServer, s.namespace, gardencorev1beta1.DefaultDomain)
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, virtualService, func() error {
+		virtualService.Labels = getLabels()
+		virtualService.Spec = istioapinetworkingv1beta1.VirtualService{
+			ExportTo: []string{"*"},
+			Hosts:    s.values.Hosts,
+			Gateways: []string{gatewayName},
+			Http: []*istioapinetworkingv1beta1.HTTPRoute{{
+				Route: []*istioapinetworkingv1beta1.HTTPRouteDestination{{
+					Destination: &istioapinetworkingv1beta1.Destination{
+						Host: destination,
+						Port: &istioapinetworkingv1beta1.PortSelector{Number: 443},
+					},
+				}},
+			}},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	destinationRule := s.emptyDestinationRule()
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, destinationRule, func() error {
+		destinationRule.Labels = getLabels()
+		destinationRule.Spec = istioapinetworkingv1beta1.DestinationRule{
+			ExportTo: []string{"*"},
+			Host:     destination,
+			TrafficPolicy: &istioapinetworkingv1beta1.TrafficPolicy{
+				Tls: &istioapinetworkingv1beta1.ClientTLSSettings{
+					Mode:              istioapinetworkingv1beta1.ClientTLSSettings_MUTUAL,
+					ClientCertificate: secretMountPath(s.values.UpstreamClientCertSecretName, "tls.crt"),
+					PrivateKey:        secretMountPath(s.values.UpstreamClientCertSecretName, "tls.key"),
+					CaCertificates:    secretMountPath(s.values.UpstreamCACertSecretName, "ca.crt"),
+					Sni:               s.values.Hosts[0],
+				},
+			},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *istioSNI) Destroy(ctx context.Context) error {
 	return kutil.DeleteObjects(
 		ctx,
 		s.client,
 		s.emptyVirtualService(),
+		s.emptyDestinationRule(),
 	)
 }
 
-func (s *sni) Wait(_ context.Context) error        { return nil }
-func (s *sni) WaitCleanup(_ context.Context) error { return nil }
+func (s *istioSNI) Wait(_ context.Context) error        { return nil }
+func (s *istioSNI) WaitCleanup(_ context.Context) error { return nil }
 
-func (s *sni) emptyVirtualService() *istionetworkingv1beta1.VirtualService {
+func (s *istioSNI) emptyVirtualService() *istionetworkingv1beta1.VirtualService {
 	return &istionetworkingv1beta1.VirtualService{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: s.namespace}}
 }
 
+func (s *istioSNI) emptyDestinationRule() *istionetworkingv1beta1.DestinationRule {
+	return &istionetworkingv1beta1.DestinationRule{ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: s.namespace}}
+}
+
+// secretMountPath is the file path under which ClientTLSSettings references secretName's key, since Istio requires
+// the upstream mTLS material by file path. Neither this component nor any chart in this repository mounts the
+// Secrets at that path.
+func secretMountPath(secretName, key string) string {
+	return fmt.Sprintf("/etc/istio/secrets/%s/%s", secretName, key)
+}
+
 // AnyDeployedSNI returns true if any SNI is deployed in the cluster.
 func AnyDeployedSNI(ctx context.Context, c client.Client) (bool, error) {
 	l := &unstructured.UnstructuredList{
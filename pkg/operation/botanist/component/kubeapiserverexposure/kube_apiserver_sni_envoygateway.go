@@ -0,0 +1,99 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeapiserverexposure
+
+import (
+	"context"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// gatewayAPIGroupVersion is the Gateway API (sigs.k8s.io/gateway-api) group/version TLSRoute is served at. It is
+// not vendored into this repository, so the TLSRoute is built and applied as unstructured content, the same way
+// AnyDeployedSNI in kube_apiserver_sni.go already queries VirtualServices without the istio client types.
+const gatewayAPIGroupVersion = "gateway.networking.k8s.io/v1alpha2"
+
+// envoyGatewaySNI deploys a Gateway API TLSRoute routing SNI traffic for values.Hosts to the kube-apiserver Service,
+// for landscapes that terminate shoot control plane SNI through an existing Gateway API implementation (e.g. Envoy
+// Gateway, Contour) rather than Istio. It parents the TLSRoute to a Gateway named like the Istio backend's
+// gatewayName, which is expected to already be present in the seed (deployed outside of this component, analogous
+// to the istio-ingressgateway chart the Istio backend relies on).
+type envoyGatewaySNI struct {
+	client    client.Client
+	namespace string
+	values    *SNIValues
+}
+
+func (s *envoyGatewaySNI) Deploy(ctx context.Context) error {
+	tlsRoute := s.emptyTLSRoute()
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, s.client, tlsRoute, func() error {
+		tlsRoute.SetLabels(getLabels())
+		tlsRoute.Object["spec"] = map[string]interface{}{
+			"parentRefs": []interface{}{
+				map[string]interface{}{"name": gatewayName},
+			},
+			"hostnames": toInterfaceSlice(s.values.Hosts),
+			"rules": []interface{}{
+				map[string]interface{}{
+					"backendRefs": []interface{}{
+						map[string]interface{}{
+							"name": v1beta1constants.DeploymentNameKubeAPIServer,
+							"port": int64(443),
+						},
+					},
+				},
+			},
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *envoyGatewaySNI) Destroy(ctx context.Context) error {
+	return kutil.DeleteObjects(
+		ctx,
+		s.client,
+		s.emptyTLSRoute(),
+	)
+}
+
+func (s *envoyGatewaySNI) Wait(_ context.Context) error        { return nil }
+func (s *envoyGatewaySNI) WaitCleanup(_ context.Context) error { return nil }
+
+func (s *envoyGatewaySNI) emptyTLSRoute() *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetAPIVersion(gatewayAPIGroupVersion)
+	route.SetKind("TLSRoute")
+	route.SetName(v1beta1constants.DeploymentNameKubeAPIServer)
+	route.SetNamespace(s.namespace)
+	return route
+}
+
+func toInterfaceSlice(in []string) []interface{} {
+	out := make([]interface{}, 0, len(in))
+	for _, s := range in {
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodedrainer implements graceful eviction of the pods running on a node before it is cordoned away from
+// traffic, e.g. when the kube-apiserver service is switched between SNI phases or recreated during a shoot upgrade.
+package nodedrainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Values contains the configuration for a NodeDrainer.
+type Values struct {
+	// GracePeriod is the maximum time to wait for a node's pods to be evicted before giving up.
+	GracePeriod time.Duration
+	// PollInterval is the interval at which eviction progress is polled.
+	PollInterval time.Duration
+}
+
+// NodeDrainer cordons a node and evicts its pods, respecting PodDisruptionBudgets, before it is removed from
+// traffic.
+type NodeDrainer interface {
+	// Drain cordons the given node and evicts all non-DaemonSet, non-mirror pods running on it, waiting for
+	// eviction to complete or for the configured grace period to be exceeded.
+	Drain(ctx context.Context, client client.Client, nodeName string) error
+}
+
+// New creates a new NodeDrainer.
+func New(values Values) NodeDrainer {
+	if values.PollInterval <= 0 {
+		values.PollInterval = 5 * time.Second
+	}
+	return &nodeDrainer{values: values}
+}
+
+type nodeDrainer struct {
+	values Values
+}
+
+func (d *nodeDrainer) Drain(ctx context.Context, c client.Client, nodeName string) error {
+	node := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		return err
+	}
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+		if err := c.Update(ctx, node); err != nil {
+			return fmt.Errorf("failed cordoning node %q: %w", nodeName, err)
+		}
+	}
+
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return fmt.Errorf("failed listing pods on node %q: %w", nodeName, err)
+	}
+
+	var evictable []corev1.Pod
+	for _, pod := range podList.Items {
+		if isMirrorPod(pod) || isDaemonSetPod(pod) {
+			continue
+		}
+		evictable = append(evictable, pod)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, d.values.GracePeriod)
+	defer cancel()
+
+	var evictionErrs []error
+	for _, pod := range evictable {
+		// POSTing a policy/v1beta1.Eviction to the pod's "eviction" subresource triggers the API server's standard
+		// eviction handling (PDB checks included), same as `kubectl drain` does.
+		eviction := &policyv1beta1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := c.Create(timeoutCtx, eviction); err != nil && !apierrors.IsNotFound(err) {
+			evictionErrs = append(evictionErrs, fmt.Errorf("failed evicting pod %s/%s: %w", pod.Namespace, pod.Name, err))
+		}
+	}
+
+	if len(evictionErrs) > 0 {
+		return gardencorev1beta1helper.NewErrorWithCodes(fmt.Sprintf("failed requesting eviction for %d pod(s) on node %q: %v", len(evictionErrs), nodeName, evictionErrs), gardencorev1beta1.ErrorInfraNodeDrainTimeout)
+	}
+
+	if err := wait.PollImmediateUntil(d.values.PollInterval, func() (bool, error) {
+		remaining, err := countPods(timeoutCtx, c, nodeName)
+		if err != nil {
+			return false, err
+		}
+		return remaining == 0, nil
+	}, timeoutCtx.Done()); err != nil {
+		return gardencorev1beta1helper.NewErrorWithCodes(fmt.Sprintf("node %q did not finish draining within %s: %v", nodeName, d.values.GracePeriod, err), gardencorev1beta1.ErrorInfraNodeDrainTimeout)
+	}
+
+	return nil
+}
+
+func countPods(ctx context.Context, c client.Client, nodeName string) (int, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.MatchingFields{"spec.nodeName": nodeName}); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, pod := range podList.Items {
+		if isMirrorPod(pod) || isDaemonSetPod(pod) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+func isMirrorPod(pod corev1.Pod) bool {
+	_, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]
+	return ok
+}
+
+func isDaemonSetPod(pod corev1.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
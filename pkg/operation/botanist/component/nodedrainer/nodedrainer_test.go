@@ -0,0 +1,97 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodedrainer_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/gardener/gardener/pkg/operation/botanist/component/nodedrainer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("NodeDrainer", func() {
+	var (
+		ctx        = context.Background()
+		node       *corev1.Node
+		daemonPod  *corev1.Pod
+		regularPod *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+		daemonPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "daemon", Namespace: "kube-system", OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet"}}},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+		}
+		regularPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "regular", Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+		}
+	})
+
+	newFakeClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+				return []string{obj.(*corev1.Pod).Spec.NodeName}
+			}).
+			WithObjects(objs...).
+			Build()
+	}
+
+	It("should cordon the node", func() {
+		c := newFakeClient(node)
+
+		Expect(New(Values{GracePeriod: time.Second, PollInterval: time.Millisecond}).Drain(ctx, c, node.Name)).To(Succeed())
+
+		updated := &corev1.Node{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(node), updated)).To(Succeed())
+		Expect(updated.Spec.Unschedulable).To(BeTrue())
+	})
+
+	It("should not evict DaemonSet or mirror pods", func() {
+		c := newFakeClient(node, daemonPod)
+
+		Expect(New(Values{GracePeriod: time.Second, PollInterval: time.Millisecond}).Drain(ctx, c, node.Name)).To(Succeed())
+
+		pod := &corev1.Pod{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(daemonPod), pod)).To(Succeed())
+	})
+
+	It("should time out if a regular pod's eviction never completes", func() {
+		c := newFakeClient(node, regularPod)
+
+		err := New(Values{GracePeriod: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}).Drain(ctx, c, node.Name)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should return an error if the node does not exist", func() {
+		c := newFakeClient()
+
+		err := New(Values{GracePeriod: time.Second, PollInterval: time.Millisecond}).Drain(ctx, c, "does-not-exist")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should default PollInterval if unset", func() {
+		drainer := New(Values{GracePeriod: time.Second})
+		Expect(drainer).NotTo(BeNil())
+	})
+})
@@ -1,15 +1,24 @@
 package botanist
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardencorev1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	"github.com/gardener/gardener/pkg/logger"
 	encryptionconfiguration "github.com/gardener/gardener/pkg/operation/etcdencryption"
 
+	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -21,8 +30,18 @@ const (
 	EtcdEncryptionSecretFileName = "encryption-configuration.yaml"
 	// EtcdEncryptionRewriteSecretsAnnotation is a constant for the name of the annotation
 	// with which to decide whether or not a rewriting of the shoot secrets is necessary.
-	// This is the case e.g. in case of a changed EtcdEncryptionConfiguration.
+	// This is the case e.g. in case of a changed EtcdEncryptionConfiguration. Its value is the key ID that secrets
+	// are being rewritten to use, so that RewriteShootSecrets knows which key it is working towards.
 	EtcdEncryptionRewriteSecretsAnnotation = "garden.sapcloud.io/rewrite-shoot-secrets"
+	// EtcdEncryptionRewriteSecretsProgressAnnotation is a constant for the name of the annotation that records the
+	// namespace/name of the last Secret that was successfully rewritten, so that a Botanist which crashed mid-
+	// rotation resumes from there instead of rewriting every Secret again from the start.
+	EtcdEncryptionRewriteSecretsProgressAnnotation = "garden.sapcloud.io/rewrite-shoot-secrets-progress"
+
+	// etcdEncryptionRewriteConcurrency bounds how many Secrets are rewritten at the same time.
+	etcdEncryptionRewriteConcurrency = 10
+	// etcdEncryptionRewriteListPageSize bounds how many Secrets are listed from the shoot at a time.
+	etcdEncryptionRewriteListPageSize = 100
 )
 
 // CreateEtcdEncryptionConfiguration creates a secret
@@ -57,6 +76,13 @@ func (b *Botanist) CreateEtcdEncryptionConfiguration() error {
 			needToWriteConfig = true
 		}
 	}
+
+	kmsChanged, err := b.reconcileKMSProviders(ec)
+	if err != nil {
+		return err
+	}
+	needToWriteConfig = needToWriteConfig || kmsChanged
+
 	if needToWriteConfig {
 		// TODOME: calculate checksum of secret and remember in checksum map
 		err = b.writeEncryptionConfiguration(ec)
@@ -70,7 +96,79 @@ func (b *Botanist) CreateEtcdEncryptionConfiguration() error {
 	return nil
 }
 
-// RewriteShootSecrets rewrites a shoot's secrets if the EncryptionConfiguration has changed
+// kmsEncryptionConfig returns the KMS providers the Shoot owner requested via
+// Spec.Kubernetes.KubeAPIServer.EncryptionConfig, or nil if none were requested.
+func (b *Botanist) kmsEncryptionConfig() []gardencorev1beta1.KMSEncryptionConfig {
+	kubeAPIServer := b.Shoot.Info.Spec.Kubernetes.KubeAPIServer
+	if kubeAPIServer == nil || kubeAPIServer.EncryptionConfig == nil {
+		return nil
+	}
+	return kubeAPIServer.EncryptionConfig.KMS
+}
+
+// reconcileKMSProviders ensures ec's provider list is prepended with one KMS provider per entry in
+// Spec.Kubernetes.KubeAPIServer.EncryptionConfig.KMS, ahead of the aescbc/identity provider Gardener manages
+// automatically, and removes any KMS providers that are no longer requested. It reports whether ec was changed, so
+// the caller knows whether the secret needs to be rewritten.
+func (b *Botanist) reconcileKMSProviders(ec *apiserverconfigv1.EncryptionConfiguration) (bool, error) {
+	kmsConfigs := b.kmsEncryptionConfig()
+	if len(kmsConfigs) == 0 {
+		return encryptionconfiguration.RemoveKMSProviders(ec), nil
+	}
+
+	for _, kmsConfig := range kmsConfigs {
+		if kmsConfig.Endpoint == "" {
+			return false, fmt.Errorf("KMS provider %q is missing an endpoint", kmsConfig.Name)
+		}
+	}
+
+	return encryptionconfiguration.SetKMSProviders(ec, kmsConfigs)
+}
+
+// RotateEtcdEncryptionKey drives the staged rotation of the etcd encryption key: it adds a new AES key as the
+// secondary provider (so kube-apiserver can decrypt with either key once rolled), then delegates to
+// RewriteShootSecrets to re-encrypt every Secret under the new key and, once that has completed, promote it to
+// primary and drop the old one. It is safe to call repeatedly; a rotation already in progress (per
+// EtcdEncryptionRewriteSecretsAnnotation) is resumed rather than restarted.
+func (b *Botanist) RotateEtcdEncryptionKey() error {
+	if err := b.VerifyEtcdEncryptionBackup(); err != nil {
+		return fmt.Errorf("refusing to rotate the etcd encryption key without a verified backup: %w", err)
+	}
+
+	alreadyRotating, err := b.needToRewriteShootSecrets()
+	if err != nil {
+		return err
+	}
+	if alreadyRotating {
+		return b.RewriteShootSecrets()
+	}
+
+	exists, ec, err := b.readEncryptionConfigurationFromSeed()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("cannot rotate etcd encryption key before an EncryptionConfiguration exists")
+	}
+
+	if err := b.updateEtcdEncryptionKeyRotationCondition(gardencorev1beta1.ConditionProgressing, "AddingSecondaryKey", "adding a new etcd encryption key as the secondary provider"); err != nil {
+		return err
+	}
+
+	if err := encryptionconfiguration.AddSecondaryKey(ec); err != nil {
+		return err
+	}
+	if err := b.writeEncryptionConfiguration(ec); err != nil {
+		return err
+	}
+	b.recordEtcdEncryptionKeyRotationEvent(corev1.EventTypeNormal, "EtcdEncryptionKeyAdded", "new etcd encryption key added as secondary provider; kube-apiserver will be rolled to pick it up")
+
+	return b.RewriteShootSecrets()
+}
+
+// RewriteShootSecrets rewrites a shoot's secrets if the EncryptionConfiguration has changed. Once every Secret has
+// been rewritten, it promotes the new key to primary, drops the old one, and clears
+// EtcdEncryptionRewriteSecretsAnnotation.
 func (b *Botanist) RewriteShootSecrets() error {
 	logger.Logger.Info("Starting RewriteShootSecrets")
 
@@ -80,28 +178,87 @@ func (b *Botanist) RewriteShootSecrets() error {
 		return fmt.Errorf("EncryptionConfiguration inconsistent: %v", err)
 	}
 
-	// WARNING:
-	// No explicit checking of whether EncryptionConfiguration is contained in a backup.
-	// Be aware of the risk!
-	//
-	// TODO: Ensure this is also agreed upon by Gardener team
-
-	// TODO: contact Amshuman Rao Karaya
+	if err := b.VerifyEtcdEncryptionBackup(); err != nil {
+		return fmt.Errorf("refusing to rewrite shoot secrets without a verified EncryptionConfiguration backup: %w", err)
+	}
 
 	needToRewrite, err := b.needToRewriteShootSecrets()
 	if err != nil {
 		return err
 	}
-	if needToRewrite {
-		err = b.rewriteShootSecrets()
-		if err != nil {
-			return err
-		}
+	if !needToRewrite {
+		return nil
 	}
 
+	if err := b.updateEtcdEncryptionKeyRotationCondition(gardencorev1beta1.ConditionProgressing, "RewritingSecrets", "rewriting shoot secrets under the new etcd encryption key"); err != nil {
+		return err
+	}
+	b.recordEtcdEncryptionKeyRotationEvent(corev1.EventTypeNormal, "RewritingShootSecrets", "rewriting shoot secrets so they are encrypted with the new etcd encryption key")
+
+	if err := b.rewriteShootSecrets(); err != nil {
+		b.recordEtcdEncryptionKeyRotationEvent(corev1.EventTypeWarning, "RewritingShootSecretsFailed", fmt.Sprintf("failed rewriting shoot secrets: %v", err))
+		return err
+	}
+
+	if err := b.promoteRotatedEtcdEncryptionKey(); err != nil {
+		return err
+	}
+
+	if err := b.updateEtcdEncryptionKeyRotationCondition(gardencorev1beta1.ConditionTrue, "RotationCompleted", "the new etcd encryption key is now primary and the old key has been removed"); err != nil {
+		return err
+	}
+	b.recordEtcdEncryptionKeyRotationEvent(corev1.EventTypeNormal, "EtcdEncryptionKeyRotationCompleted", "etcd encryption key rotation completed")
+
 	return nil
 }
 
+// promoteRotatedEtcdEncryptionKey promotes the secondary etcd encryption key to primary, drops the old primary key,
+// and clears the rewrite-in-progress annotations. It writes the EncryptionConfiguration directly, rather than via
+// writeEncryptionConfiguration, because that helper unconditionally re-annotates the secret for another rewrite.
+func (b *Botanist) promoteRotatedEtcdEncryptionKey() error {
+	exists, ec, err := b.readEncryptionConfigurationFromSeed()
+	if (err != nil) || !exists {
+		return err
+	}
+
+	if err := encryptionconfiguration.PromoteSecondaryKey(ec); err != nil {
+		return err
+	}
+
+	ecYamlBytes, err := encryptionconfiguration.ToYAML(ec)
+	if err != nil {
+		return err
+	}
+	if err := b.writeEncryptionConfigurationSecretToSeed(ecYamlBytes); err != nil {
+		return err
+	}
+	if err := b.writeEncryptionConfigurationSecretToGarden(ecYamlBytes); err != nil {
+		return err
+	}
+
+	return b.setNeedToRewriteShootSecrets(false)
+}
+
+// updateEtcdEncryptionKeyRotationCondition patches the Shoot's ShootEtcdEncryptionKeyRotation condition so operators
+// can observe rotation progress without reading the etcd-encryption-secret annotations directly.
+func (b *Botanist) updateEtcdEncryptionKeyRotationCondition(status gardencorev1beta1.ConditionStatus, reason, message string) error {
+	shoot := b.Shoot.Info.DeepCopy()
+
+	condition := gardencorev1beta1helper.GetOrInitCondition(shoot.Status.Conditions, gardencorev1beta1.ShootEtcdEncryptionKeyRotation)
+	condition = gardencorev1beta1helper.UpdatedCondition(condition, status, reason, message)
+	shoot.Status.Conditions = gardencorev1beta1helper.MergeConditions(shoot.Status.Conditions, condition)
+
+	return b.Operation.K8sGardenClient.Client().Status().Update(context.TODO(), shoot)
+}
+
+// recordEtcdEncryptionKeyRotationEvent emits a Shoot event for a phase of the etcd encryption key rotation.
+func (b *Botanist) recordEtcdEncryptionKeyRotationEvent(eventType, reason, message string) {
+	if b.Recorder == nil {
+		return
+	}
+	b.Recorder.Event(b.Shoot.Info, eventType, reason, message)
+}
+
 // readEncryptionConfigurationFromSeed reads the EncryptionConfiguration from the shoot namespace in the seed
 func (b *Botanist) readEncryptionConfigurationFromSeed() (bool, *apiserverconfigv1.EncryptionConfiguration, error) {
 	client := b.Operation.K8sSeedClient
@@ -167,11 +324,22 @@ func (b *Botanist) writeEncryptionConfiguration(ec *apiserverconfigv1.Encryption
 	if err != nil {
 		return err
 	}
+	// back up the (KEK-wrapped) configuration before relying on it for anything, so losing the seed namespace can
+	// never make data encrypted under it unrecoverable
+	if err := b.uploadEncryptionConfigurationBackup(ecYamlBytes); err != nil {
+		return fmt.Errorf("failed backing up EncryptionConfiguration to the etcd backup bucket: %w", err)
+	}
 	// if changed configuration was written successfully, remember to rewrite secrets once shoot apiserver is up and running
 	err = b.setNeedToRewriteShootSecrets(true)
 	if err != nil {
 		return err
 	}
+
+	// TODOME: deploy one KMS plugin sidecar per b.kmsEncryptionConfig() entry into the kube-apiserver Deployment in
+	// charts/seed-controlplane/charts/kube-apiserver (not part of this checkout), mounting the CredentialsSecretRef
+	// Secret and a shared emptyDir for the Unix socket referenced by KMSEncryptionConfig.Endpoint. Until that chart
+	// work lands, kube-apiserver cannot actually start with a kms provider configured.
+
 	return nil
 }
 
@@ -236,54 +404,193 @@ func (b *Botanist) isEncryptionConfigurationConsistent() (bool, error) {
 	if (err != nil) || !consistent {
 		return false, fmt.Errorf("EncryptionConfiguration in seed cluster is not consistent: %v", err)
 	}
+
+	if kmsConfigs := b.kmsEncryptionConfig(); len(kmsConfigs) > 0 {
+		reachable, err := encryptionconfiguration.KMSProvidersReachable(ecSeed, kmsConfigs)
+		if (err != nil) || !reachable {
+			return false, fmt.Errorf("KMS provider(s) referenced in EncryptionConfig are not reachable or do not match the seed secret: %v", err)
+		}
+	}
+
 	return true, nil
 }
 
 // needToRewriteShootSecrets checks whether the secrets in the shoot need to
 // be rewritten, e.g. after a change to the EncryptionConfiguration
 func (b *Botanist) needToRewriteShootSecrets() (bool, error) {
-	// ****************************************************************************************************************
-	// TODO: Check Pseudocode
-	//
-	// 1. obtain e.Operation.K8sSeedClient
-	// 2. switch to shoot namespace
-	// 3. check annotation (how?)
-	//
-	// ****************************************************************************************************************
-
-	return false, fmt.Errorf("not implemented yet")
+	client := b.Operation.K8sSeedClient
+	secret, err := client.GetSecret(b.Operation.Shoot.SeedNamespace, EtcdEncryptionSecretName)
+	if err != nil {
+		return false, err
+	}
+	_, needed := secret.Annotations[EtcdEncryptionRewriteSecretsAnnotation]
+	return needed, nil
 }
 
-// setNeedToRewriteShootSecrets sets the annotation with which to remember
-// whether the shoot secrets need to be rewritten
+// setNeedToRewriteShootSecrets sets or clears EtcdEncryptionRewriteSecretsAnnotation (and, when clearing, the
+// rewrite progress annotation alongside it) on the etcd-encryption-secret in the shoot namespace of the seed.
 func (b *Botanist) setNeedToRewriteShootSecrets(rewrite bool) error {
-	// ****************************************************************************************************************
-	// TODO: Check Pseudocode
-	//
-	// 1. obtain e.Operation.K8sSeedClient
-	// 2. switch to shoot namespace
-	// 3. set annotation of etcdencryptionconfigurationsecret in shoot namespace of seed
-	// pkg/operation/botanist/controlplane.go ==> patchDeploymentCloudProviderChecksums
-	//
-	// ****************************************************************************************************************
-	return fmt.Errorf("not implemented yet")
+	client := b.Operation.K8sSeedClient
+	secret, err := client.GetSecret(b.Operation.Shoot.SeedNamespace, EtcdEncryptionSecretName)
+	if err != nil {
+		return err
+	}
+	secret = secret.DeepCopy()
+
+	if rewrite {
+		_, ec, err := b.readEncryptionConfigurationFromSeed()
+		if err != nil {
+			return err
+		}
+		newKeyID, err := encryptionconfiguration.SecondaryKeyID(ec)
+		if err != nil {
+			return err
+		}
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string, 1)
+		}
+		secret.Annotations[EtcdEncryptionRewriteSecretsAnnotation] = newKeyID
+	} else {
+		delete(secret.Annotations, EtcdEncryptionRewriteSecretsAnnotation)
+		delete(secret.Annotations, EtcdEncryptionRewriteSecretsProgressAnnotation)
+	}
+
+	_, err = client.CreateSecretObject(secret, true)
+	return err
+}
+
+// rewriteShootSecretsProgress returns the namespace/name of the last Secret that was successfully rewritten in a
+// previous, interrupted attempt, or "" if rewriting hasn't started yet.
+func (b *Botanist) rewriteShootSecretsProgress() (string, error) {
+	client := b.Operation.K8sSeedClient
+	secret, err := client.GetSecret(b.Operation.Shoot.SeedNamespace, EtcdEncryptionSecretName)
+	if err != nil {
+		return "", err
+	}
+	return secret.Annotations[EtcdEncryptionRewriteSecretsProgressAnnotation], nil
+}
+
+// setRewriteShootSecretsProgress records cursor (the namespace/name of the last Secret that was successfully
+// rewritten) so that a Botanist which crashed mid-rotation resumes from there.
+func (b *Botanist) setRewriteShootSecretsProgress(cursor string) error {
+	client := b.Operation.K8sSeedClient
+	secret, err := client.GetSecret(b.Operation.Shoot.SeedNamespace, EtcdEncryptionSecretName)
+	if err != nil {
+		return err
+	}
+	secret = secret.DeepCopy()
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string, 1)
+	}
+	secret.Annotations[EtcdEncryptionRewriteSecretsProgressAnnotation] = cursor
+
+	_, err = client.CreateSecretObject(secret, true)
+	return err
 }
 
-// rewriteShootSecrets rewrites all secrets of the shoot.
-// This will take into account the current EncryptionConfiguration.
+// rewriteShootSecrets pages through every Secret in the shoot, issuing a no-op, resourceVersion-preserving PATCH to
+// each so that etcd re-encrypts it under the current (newest) EncryptionConfiguration provider. It rewrites up to
+// etcdEncryptionRewriteConcurrency Secrets concurrently, retries individual Secrets with exponential backoff if
+// they hit a write conflict, and records progress after each page so that a crashed Botanist resumes rather than
+// starting over.
 func (b *Botanist) rewriteShootSecrets() error {
-	// ****************************************************************************************************************
-	// TODO: Check Pseudocode
-	//
-	// 1. obtain e.Operation.K8sShootClient
-	// 2. For all secrets in all namespaces:
-	//    a) read secret
-	//    b) write secret
-	//
-	// ****************************************************************************************************************
-	return fmt.Errorf("not implemented yet")
-	// err = b.setNeedToRewriteShootSecrets(false)
-	// if err != nil {
-	// 	return err
-	// }
+	ctx := context.TODO()
+	shootClient := b.Operation.K8sShootClient.Client()
+
+	resumeAfter, err := b.rewriteShootSecretsProgress()
+	if err != nil {
+		return err
+	}
+	if resumeAfter != "" {
+		// The Secret the cursor points to may have been deleted since the previous, interrupted attempt recorded it
+		// (e.g. by the shoot owner). In that case there is nothing to resume after: falling through to "skip
+		// everything because the cursor never matches again" would rewrite nothing and still report success, which
+		// the caller would then happily promote the new key on top of. Resume from the start instead.
+		if _, err := shootClient.Get(ctx, splitRewriteShootSecretsCursor(resumeAfter), &corev1.Secret{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			resumeAfter = ""
+		}
+	}
+	skipping := resumeAfter != ""
+	foundResumeAfter := !skipping
+
+	var continueToken string
+	for {
+		secretList := &corev1.SecretList{}
+		listOpts := []client.ListOption{client.Limit(etcdEncryptionRewriteListPageSize)}
+		if continueToken != "" {
+			listOpts = append(listOpts, client.Continue(continueToken))
+		}
+		if err := shootClient.List(ctx, secretList, listOpts...); err != nil {
+			return err
+		}
+
+		group, groupCtx := errgroup.WithContext(ctx)
+		semaphore := make(chan struct{}, etcdEncryptionRewriteConcurrency)
+
+		for i := range secretList.Items {
+			secret := &secretList.Items[i]
+			key := secret.Namespace + "/" + secret.Name
+
+			if skipping {
+				if key == resumeAfter {
+					skipping = false
+					foundResumeAfter = true
+				}
+				continue
+			}
+
+			semaphore <- struct{}{}
+			group.Go(func() error {
+				defer func() { <-semaphore }()
+				return rewriteSecretWithBackoff(groupCtx, shootClient, secret)
+			})
+		}
+
+		if err := group.Wait(); err != nil {
+			return err
+		}
+
+		if last := len(secretList.Items); last > 0 {
+			lastSecret := secretList.Items[last-1]
+			if err := b.setRewriteShootSecretsProgress(lastSecret.Namespace + "/" + lastSecret.Name); err != nil {
+				return err
+			}
+		}
+
+		continueToken = secretList.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	if !foundResumeAfter {
+		// Defensive: the cursor existed at the pre-flight check above but disappeared (or never matched) during the
+		// listing itself. Rather than silently reporting success having skipped every remaining Secret, fail loudly
+		// so RewriteShootSecrets does not proceed to promote the new key and strand anything still encrypted
+		// under the old one.
+		return fmt.Errorf("rewrite progress cursor %q was never encountered while paging through shoot secrets", resumeAfter)
+	}
+
+	return nil
+}
+
+// splitRewriteShootSecretsCursor parses a "namespace/name" rewrite-progress cursor into an ObjectKey.
+func splitRewriteShootSecretsCursor(cursor string) client.ObjectKey {
+	namespace, name, _ := cache.SplitMetaNamespaceKey(cursor)
+	return client.ObjectKey{Namespace: namespace, Name: name}
+}
+
+// rewriteSecretWithBackoff issues a no-op PATCH for secret that only carries a resourceVersion precondition (via
+// client.MergeFromWithOptimisticLock), which is enough for etcd to rewrite the object under the current encryption
+// provider without actually changing any field. Conflicts (e.g. a controller touching the same Secret concurrently)
+// are retried with exponential backoff.
+func rewriteSecretWithBackoff(ctx context.Context, c client.Client, secret *corev1.Secret) error {
+	backoff := wait.Backoff{Duration: 100 * time.Millisecond, Factor: 2, Jitter: 0.1, Steps: 5}
+	return retry.OnError(backoff, apierrors.IsConflict, func() error {
+		patch := client.MergeFromWithOptions(secret.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		return c.Patch(ctx, secret, patch)
+	})
 }
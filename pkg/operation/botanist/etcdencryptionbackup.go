@@ -0,0 +1,179 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package botanist
+
+import (
+	"context"
+	"fmt"
+
+	encryptionconfiguration "github.com/gardener/gardener/pkg/operation/etcdencryption"
+)
+
+const (
+	// EtcdEncryptionBackupKEKSecretNamespace and EtcdEncryptionBackupKEKSecretName locate the key-encryption-key
+	// used to wrap EncryptionConfiguration backups before they leave the garden cluster. The KEK is shared across
+	// every Shoot in the landscape, so that losing a single seed cannot expose any Shoot's etcd encryption keys.
+	EtcdEncryptionBackupKEKSecretNamespace = "garden"
+	// EtcdEncryptionBackupKEKSecretName is the name of the Secret holding the KEK, see
+	// EtcdEncryptionBackupKEKSecretNamespace.
+	EtcdEncryptionBackupKEKSecretName = "etcd-encryption-backup-kek"
+	// EtcdEncryptionBackupKEKDataKey is the data key within EtcdEncryptionBackupKEKSecretName holding the KEK bytes.
+	EtcdEncryptionBackupKEKDataKey = "key"
+
+	// EtcdEncryptionBackupObjectKeyPrefix namespaces the EncryptionConfiguration backup objects within a Shoot's
+	// etcd backup bucket, alongside the snapshots etcd-backup-restore uploads for etcd-main.
+	EtcdEncryptionBackupObjectKeyPrefix = "encryption-configuration/"
+)
+
+// etcdEncryptionBackupStore is the minimal object-storage surface that backing up and restoring the
+// EncryptionConfiguration need against a Shoot's etcd backup bucket (the same BackupEntry etcd-main uses). It is
+// satisfied by the blob store the backupbucket extension hands to BackupEntry reconciliation.
+type etcdEncryptionBackupStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// etcdEncryptionBackupStore returns the blob store backing b.Shoot's etcd backup bucket.
+func (b *Botanist) etcdEncryptionBackupStore() (etcdEncryptionBackupStore, error) {
+	if b.Shoot.BackupEntryBlobStore == nil {
+		return nil, fmt.Errorf("no etcd backup bucket blob store configured for shoot %q; cannot back up EncryptionConfiguration", b.Operation.Shoot.SeedNamespace)
+	}
+	return b.Shoot.BackupEntryBlobStore, nil
+}
+
+// etcdEncryptionBackupObjectKey is the well-known object key a Shoot's EncryptionConfiguration backup is stored
+// under within its etcd backup bucket.
+func (b *Botanist) etcdEncryptionBackupObjectKey() string {
+	return EtcdEncryptionBackupObjectKeyPrefix + b.Operation.Shoot.SeedNamespace + ".enc"
+}
+
+// readEtcdEncryptionBackupKEK reads the landscape-wide key-encryption-key EncryptionConfiguration backups are
+// wrapped with, from the garden cluster.
+func (b *Botanist) readEtcdEncryptionBackupKEK() ([]byte, error) {
+	client := b.Operation.K8sGardenClient
+	secret, err := client.GetSecret(EtcdEncryptionBackupKEKSecretNamespace, EtcdEncryptionBackupKEKSecretName)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading etcd encryption backup KEK: %w", err)
+	}
+	kek, ok := secret.Data[EtcdEncryptionBackupKEKDataKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s is missing data key %q", EtcdEncryptionBackupKEKSecretNamespace, EtcdEncryptionBackupKEKSecretName, EtcdEncryptionBackupKEKDataKey)
+	}
+	return kek, nil
+}
+
+// wrapEncryptionConfigurationBackup seals plaintext (the EncryptionConfiguration YAML) with the landscape KEK, so
+// that the blob sitting in the Shoot's etcd backup bucket does not by itself expose the etcd encryption keys.
+func (b *Botanist) wrapEncryptionConfigurationBackup(plaintext []byte) ([]byte, error) {
+	kek, err := b.readEtcdEncryptionBackupKEK()
+	if err != nil {
+		return nil, err
+	}
+	return encryptionconfiguration.SealWithKEK(kek, plaintext)
+}
+
+// unwrapEncryptionConfigurationBackup reverses wrapEncryptionConfigurationBackup.
+func (b *Botanist) unwrapEncryptionConfigurationBackup(sealed []byte) ([]byte, error) {
+	kek, err := b.readEtcdEncryptionBackupKEK()
+	if err != nil {
+		return nil, err
+	}
+	return encryptionconfiguration.OpenWithKEK(kek, sealed)
+}
+
+// uploadEncryptionConfigurationBackup wraps ecYamlBytes with the landscape KEK and uploads it to the Shoot's etcd
+// backup bucket. It is called by writeEncryptionConfiguration once the secret has been written to both the seed and
+// the garden cluster, closing the gap noted in RewriteShootSecrets: without a backup, losing the seed namespace
+// mid-rotation would make data encrypted under the new key unrecoverable.
+func (b *Botanist) uploadEncryptionConfigurationBackup(ecYamlBytes []byte) error {
+	store, err := b.etcdEncryptionBackupStore()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := b.wrapEncryptionConfigurationBackup(ecYamlBytes)
+	if err != nil {
+		return err
+	}
+
+	return store.Put(context.TODO(), b.etcdEncryptionBackupObjectKey(), sealed)
+}
+
+// VerifyEtcdEncryptionBackup checks that the EncryptionConfiguration currently active on the seed has a matching,
+// decryptable backup in the Shoot's etcd backup bucket. The reconciler calls this before any action that rewrites
+// Shoot secrets (RotateEtcdEncryptionKey, RewriteShootSecrets), so a rewrite is never attempted while the only copy
+// of the encryption key lives on a seed that might be about to disappear.
+func (b *Botanist) VerifyEtcdEncryptionBackup() error {
+	exists, ec, err := b.readEncryptionConfigurationFromSeed()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no EncryptionConfiguration on the seed to verify a backup for")
+	}
+
+	store, err := b.etcdEncryptionBackupStore()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := store.Get(context.TODO(), b.etcdEncryptionBackupObjectKey())
+	if err != nil {
+		return fmt.Errorf("failed reading EncryptionConfiguration backup: %w", err)
+	}
+
+	backedUpYamlBytes, err := b.unwrapEncryptionConfigurationBackup(sealed)
+	if err != nil {
+		return fmt.Errorf("failed decrypting EncryptionConfiguration backup: %w", err)
+	}
+
+	backedUpEC, err := encryptionconfiguration.CreateFromYAML(backedUpYamlBytes)
+	if err != nil {
+		return fmt.Errorf("EncryptionConfiguration backup is not valid: %w", err)
+	}
+
+	equal, err := encryptionconfiguration.Equals(ec, backedUpEC)
+	if err != nil {
+		return fmt.Errorf("failed comparing EncryptionConfiguration against its backup: %w", err)
+	}
+	if !equal {
+		return fmt.Errorf("EncryptionConfiguration backup does not match the seed's current configuration")
+	}
+
+	return nil
+}
+
+// RestoreEncryptionConfigurationFromBackup pulls the Shoot's EncryptionConfiguration backup out of its etcd backup
+// bucket and plants it into the (new) seed namespace, before kube-apiserver is started there. The control-plane
+// migration flow calls this instead of CreateEtcdEncryptionConfiguration, since migration must preserve the
+// existing encryption keys rather than mint a fresh passive configuration.
+func (b *Botanist) RestoreEncryptionConfigurationFromBackup() error {
+	store, err := b.etcdEncryptionBackupStore()
+	if err != nil {
+		return err
+	}
+
+	sealed, err := store.Get(context.TODO(), b.etcdEncryptionBackupObjectKey())
+	if err != nil {
+		return fmt.Errorf("failed reading EncryptionConfiguration backup for migration: %w", err)
+	}
+
+	ecYamlBytes, err := b.unwrapEncryptionConfigurationBackup(sealed)
+	if err != nil {
+		return fmt.Errorf("failed decrypting EncryptionConfiguration backup for migration: %w", err)
+	}
+
+	return b.writeEncryptionConfigurationSecretToSeed(ecYamlBytes)
+}
@@ -15,8 +15,15 @@
 package botanist
 
 import (
+	"context"
+	"time"
+
 	"github.com/gardener/gardener/pkg/operation/botanist/component"
+	"github.com/gardener/gardener/pkg/operation/botanist/component/nodedrainer"
 	"github.com/gardener/gardener/pkg/utils"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 // DefaultKubeAPIServerService returns a deployer for kube-apiserver service.
@@ -24,9 +31,51 @@ func (b *Botanist) DefaultKubeAPIServerService(sniPhase component.Phase) compone
 	return b.kubeAPIServiceService(sniPhase)
 }
 
+// drainSeedNodesForKubeAPIServerServiceRollout cordons and evicts the pods of the given seed nodes before the
+// kube-apiserver service is rotated between SNI phases or its LoadBalancer is recreated, so that in-flight
+// connections are not abruptly cut by the LB annotation swap.
+//
+// TODOME: nothing in this checkout calls this function. It is meant to run as part of the shoot reconcile flow's
+// kube-apiserver service rollout step (the code that actually swaps SNI phases / recreates the LoadBalancer
+// Service), but that flow step lives in the Botanist reconcile orchestration, which this checkout does not contain.
+// Wire this in there once that flow lands; until then, treat it as ready-but-unreachable rather than assuming it
+// runs.
+func (b *Botanist) drainSeedNodesForKubeAPIServerServiceRollout(ctx context.Context, nodeNames []string) error {
+	drainer := nodedrainer.New(nodedrainer.Values{GracePeriod: 2 * time.Minute})
+
+	for _, nodeName := range nodeNames {
+		if err := drainer.Drain(ctx, b.K8sSeedClient.Client(), nodeName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *Botanist) getKubeAPIServerServiceAnnotations(sniPhase component.Phase) map[string]string {
-	if b.ExposureClassHandler != nil && sniPhase != component.PhaseEnabled {
+	if b.ExposureClassHandler == nil || sniPhase == component.PhaseEnabled {
+		return b.Seed.LoadBalancerServiceAnnotations
+	}
+
+	annotations, conflicts, err := gardenerutils.ApplyAnnotationStrategy(
+		b.ExposureClassHandler.AnnotationStrategy,
+		b.Seed.LoadBalancerServiceAnnotations,
+		b.ExposureClassHandler.LoadBalancerService.Annotations,
+		gardenerutils.TemplateData{
+			ProjectName: b.Garden.Project.Name,
+			ShootName:   b.Shoot.GetInfo().Name,
+			SeedName:    b.Seed.GetInfo().Name,
+			Region:      b.Seed.GetInfo().Spec.Provider.Region,
+		},
+	)
+	if err != nil {
+		b.Logger.Errorf("failed applying exposure class annotation strategy, falling back to merge: %v", err)
 		return utils.MergeStringMaps(b.Seed.LoadBalancerServiceAnnotations, b.ExposureClassHandler.LoadBalancerService.Annotations)
 	}
-	return b.Seed.LoadBalancerServiceAnnotations
+
+	if len(conflicts) > 0 && b.Recorder != nil {
+		b.Recorder.Eventf(b.Shoot.GetInfo(), corev1.EventTypeWarning, "ExposureClassAnnotationConflict",
+			"dropped %d seed annotation(s) in favor of the exposure class's value: %v", len(conflicts), conflicts)
+	}
+
+	return annotations
 }
@@ -0,0 +1,141 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package care
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// authorizedNetworksProbeImage is the image the authorized-networks probe Jobs dial the kube-apiserver load
+// balancer with. It only needs a TCP client, so a minimal image is sufficient.
+const authorizedNetworksProbeImage = "europe-docker.pkg.dev/gardener-project/releases/3rdparty/alpine:3.14"
+
+// controlNetworkCIDR is a network reserved by RFC 5737 (TEST-NET-1) for documentation purposes; it is never part of
+// a real authorizedNetworks list, so probing it gives a negative control. If it turns out to be reachable, the
+// firewall/load balancer in front of the kube-apiserver is not actually enforcing authorizedNetworks at all.
+const controlNetworkCIDR = "192.0.2.0/24"
+
+// AuthorizedNetworkProbeResult is the outcome of probing a single CIDR declared (or implicitly expected to be
+// blocked) in spec.kubernetes.kubeAPIServer.authorizedNetworks.
+type AuthorizedNetworkProbeResult struct {
+	// CIDR is the network that was probed.
+	CIDR string
+	// ExpectedAllowed is true if CIDR is one of the Shoot's declared authorizedNetworks, and false if it is
+	// controlNetworkCIDR, which must always be blocked.
+	ExpectedAllowed bool
+	// Reachable reports whether the kube-apiserver load balancer was reachable from CIDR.
+	Reachable bool
+}
+
+// probeAuthorizedNetworks probes the kube-apiserver load balancer at apiServerAddress for reachability from every
+// network in authorizedNetworks, plus controlNetworkCIDR, by running one short-lived Job per network in
+// seedNamespace. Each Job's Pod is scheduled onto a seed node labelled for that network's egress path (how that
+// pinning is provisioned - e.g. a per-network egress gateway node pool - is specific to the seed's infrastructure
+// extension and out of scope here); this function only orchestrates the probes and reads back their results.
+func probeAuthorizedNetworks(ctx context.Context, seedClient client.Client, seedNamespace, apiServerAddress string, authorizedNetworks []string) ([]AuthorizedNetworkProbeResult, error) {
+	networks := make([]string, 0, len(authorizedNetworks)+1)
+	networks = append(networks, authorizedNetworks...)
+	networks = append(networks, controlNetworkCIDR)
+
+	results := make([]AuthorizedNetworkProbeResult, 0, len(networks))
+	for _, cidr := range networks {
+		reachable, err := runAuthorizedNetworkProbeJob(ctx, seedClient, seedNamespace, apiServerAddress, cidr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe authorized network %q: %w", cidr, err)
+		}
+
+		results = append(results, AuthorizedNetworkProbeResult{
+			CIDR:            cidr,
+			ExpectedAllowed: cidr != controlNetworkCIDR,
+			Reachable:       reachable,
+		})
+	}
+
+	return results, nil
+}
+
+// runAuthorizedNetworkProbeJob runs a single Job that dials apiServerAddress from cidr's egress path and reports
+// whether the connection succeeded.
+func runAuthorizedNetworkProbeJob(ctx context.Context, seedClient client.Client, seedNamespace, apiServerAddress, cidr string) (bool, error) {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "authorized-network-probe-",
+			Namespace:    seedNamespace,
+			Labels: map[string]string{
+				"app":                           "authorized-network-probe",
+				"authorized-network-probe/cidr": sanitizeCIDRLabel(cidr),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32Ptr(0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeSelector: map[string]string{
+						"authorized-network-probe/egress-cidr": sanitizeCIDRLabel(cidr),
+					},
+					Containers: []corev1.Container{{
+						Name:    "probe",
+						Image:   authorizedNetworksProbeImage,
+						Command: []string{"sh", "-c", fmt.Sprintf("nc -z -w5 %s", apiServerAddress)},
+					}},
+				},
+			},
+		},
+	}
+
+	if err := seedClient.Create(ctx, job); err != nil {
+		return false, err
+	}
+	defer func() {
+		_ = seedClient.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	}()
+
+	var succeeded bool
+	err := wait.PollImmediateUntil(2*time.Second, func() (bool, error) {
+		current := &batchv1.Job{}
+		if err := seedClient.Get(ctx, client.ObjectKeyFromObject(job), current); err != nil {
+			return false, err
+		}
+
+		switch {
+		case current.Status.Succeeded > 0:
+			succeeded = true
+			return true, nil
+		case current.Status.Failed > 0:
+			succeeded = false
+			return true, nil
+		default:
+			return false, nil
+		}
+	}, ctx.Done())
+
+	return succeeded, err
+}
+
+// sanitizeCIDRLabel turns a CIDR like "10.0.0.0/8" into a value usable as a Kubernetes label ("10.0.0.0-8").
+func sanitizeCIDRLabel(cidr string) string {
+	return strings.ReplaceAll(cidr, "/", "-")
+}
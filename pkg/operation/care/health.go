@@ -17,6 +17,7 @@ package care
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gardener/gardener/pkg/api/extensions"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -172,6 +174,16 @@ func (h *Health) healthChecks(
 		h.logger.Errorf("error getting extension conditions: %+v", err)
 	}
 
+	var extensionConditionsAPIServerAvailable []ExtensionCondition
+	if healthCheckConditions, err := h.getHealthCheckExtensionConditions(ctx); err != nil {
+		h.logger.Errorf("error getting HealthCheck extension conditions: %+v", err)
+	} else {
+		extensionConditionsAPIServerAvailable = healthCheckConditions[gardencorev1beta1.ShootAPIServerAvailable]
+		extensionConditionsControlPlaneHealthy = append(extensionConditionsControlPlaneHealthy, healthCheckConditions[gardencorev1beta1.ShootControlPlaneHealthy]...)
+		extensionConditionsEveryNodeReady = append(extensionConditionsEveryNodeReady, healthCheckConditions[gardencorev1beta1.ShootEveryNodeReady]...)
+		extensionConditionsSystemComponentsHealthy = append(extensionConditionsSystemComponentsHealthy, healthCheckConditions[gardencorev1beta1.ShootSystemComponentsHealthy]...)
+	}
+
 	var (
 		checker               = NewHealthChecker(thresholdMappings, healthCheckOutdatedThreshold, h.shoot.Info.Status.LastOperation, h.shoot.KubernetesVersion, h.shoot.GardenerVersion)
 		seedDeploymentLister  = makeDeploymentLister(ctx, h.seedClient.Client(), h.shoot.SeedNamespace, controlPlaneMonitoringLoggingSelector)
@@ -201,7 +213,7 @@ func (h *Health) healthChecks(
 	h.shootClient = shootClient
 
 	_ = flow.Parallel(func(ctx context.Context) error {
-		apiserverAvailability = h.checkAPIServerAvailability(ctx, checker, apiserverAvailability)
+		apiserverAvailability = h.checkAPIServerAvailability(ctx, checker, apiserverAvailability, extensionConditionsAPIServerAvailable)
 		return nil
 	}, func(ctx context.Context) error {
 		newControlPlane, err := h.checkControlPlane(ctx, checker, controlPlane, seedDeploymentLister, seedStatefulSetLister, seedEtcdLister, seedWorkerLister, extensionConditionsControlPlaneHealthy)
@@ -220,16 +232,114 @@ func (h *Health) healthChecks(
 	return []gardencorev1beta1.Condition{apiserverAvailability, controlPlane, nodes, systemComponents}
 }
 
-// checkAPIServerAvailability checks if the API server of a Shoot cluster is reachable and measure the response time.
-func (h *Health) checkAPIServerAvailability(ctx context.Context, checker *HealthChecker, condition gardencorev1beta1.Condition) gardencorev1beta1.Condition {
-	return health.CheckAPIServerAvailability(ctx, condition, h.shootClient.RESTClient(), func(conditionType, message string) gardencorev1beta1.Condition {
+const (
+	addressExternal = "external"
+	addressInternal = "internal"
+
+	reasonExternalAPIServerDown = "ExternalAPIServerDown"
+	reasonInternalAPIServerDown = "InternalAPIServerDown"
+)
+
+// checkAPIServerAvailability checks if the API server of a Shoot cluster is reachable and measures the response
+// time. If the Shoot advertises distinct external and internal API server addresses, both endpoints are probed
+// independently in parallel, so that e.g. a broken VPN/konnectivity tunnel taking down only the internal endpoint
+// is not masked by the external endpoint still serving. Shoots that only advertise a single endpoint (the common
+// case) keep being probed exactly once, as before. extensionConditions are rolled in afterwards, the same way
+// checkControlPlane/checkSystemComponents/checkClusterNodes fold in conditions reported by extension resources.
+func (h *Health) checkAPIServerAvailability(ctx context.Context, checker *HealthChecker, condition gardencorev1beta1.Condition, extensionConditions []ExtensionCondition) gardencorev1beta1.Condition {
+	probedCondition := h.probeAPIServerAvailability(ctx, checker, condition)
+	if exitCondition := checker.CheckExtensionCondition(probedCondition, extensionConditions); exitCondition != nil {
+		return *exitCondition
+	}
+	return probedCondition
+}
+
+func (h *Health) probeAPIServerAvailability(ctx context.Context, checker *HealthChecker, condition gardencorev1beta1.Condition) gardencorev1beta1.Condition {
+	externalEndpoint := advertisedAPIServerAddress(h.shoot.Info, addressExternal)
+	internalEndpoint := advertisedAPIServerAddress(h.shoot.Info, addressInternal)
+
+	if internalEndpoint == "" || internalEndpoint == externalEndpoint {
+		return h.probeAPIServerEndpoint(ctx, checker, condition, addressExternal, h.shootClient.RESTClient())
+	}
+
+	internalRESTClient, err := h.internalAPIServerRESTClient(internalEndpoint)
+	if err != nil {
+		h.logger.Errorf("could not build REST client for internal API server endpoint %q: %v", internalEndpoint, err)
+		return checker.FailedCondition(condition, reasonInternalAPIServerDown, fmt.Sprintf("Could not build a client for the internal API server endpoint: %v", err))
+	}
+
+	var externalCondition, internalCondition gardencorev1beta1.Condition
+	_ = flow.Parallel(func(ctx context.Context) error {
+		externalCondition = h.probeAPIServerEndpoint(ctx, checker, condition, addressExternal, h.shootClient.RESTClient())
+		return nil
+	}, func(ctx context.Context) error {
+		internalCondition = h.probeAPIServerEndpoint(ctx, checker, condition, addressInternal, internalRESTClient)
+		return nil
+	})(ctx)
+
+	return mergeAPIServerAvailabilityConditions(checker, condition, externalCondition, internalCondition)
+}
+
+// probeAPIServerEndpoint runs health.CheckAPIServerAvailability against restClient and records the probe's latency
+// and outcome under endpointName ("external" or "internal") as metricAPIServerAvailabilityLatencySeconds.
+func (h *Health) probeAPIServerEndpoint(ctx context.Context, checker *HealthChecker, condition gardencorev1beta1.Condition, endpointName string, restClient rest.Interface) gardencorev1beta1.Condition {
+	start := time.Now()
+	result := health.CheckAPIServerAvailability(ctx, condition, restClient, func(conditionType, message string) gardencorev1beta1.Condition {
 		return checker.FailedCondition(condition, conditionType, message)
 	}, h.logger)
+
+	metricAPIServerAvailabilityLatencySeconds.WithLabelValues(h.shoot.Info.Name, endpointName, strconv.FormatBool(result.Status == gardencorev1beta1.ConditionTrue)).Observe(time.Since(start).Seconds())
+
+	return result
+}
+
+// internalAPIServerRESTClient builds a REST client that reaches the Shoot's API server via internalEndpoint
+// (typically only reachable from within the seed cluster, e.g. through a VPN/konnectivity tunnel), reusing every
+// other setting (TLS, auth) already resolved for h.shootClient.
+func (h *Health) internalAPIServerRESTClient(internalEndpoint string) (rest.Interface, error) {
+	internalConfig := rest.CopyConfig(h.shootClient.RESTConfig())
+	internalConfig.Host = internalEndpoint
+
+	internalClient, err := kubernetes.NewWithConfig(kubernetes.WithRESTConfig(internalConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	return internalClient.RESTClient(), nil
+}
+
+// advertisedAPIServerAddress returns the URL of the Shoot's advertised API server address named addressName (e.g.
+// "external" or "internal"), or "" if the Shoot does not advertise one by that name.
+func advertisedAPIServerAddress(shoot *gardencorev1beta1.Shoot, addressName string) string {
+	for _, address := range shoot.Status.AdvertisedAddresses {
+		if address.Name == addressName {
+			return address.URL
+		}
+	}
+	return ""
+}
+
+// mergeAPIServerAvailabilityConditions combines the external and internal probe results into a single condition,
+// using a distinct reason depending on whether the external endpoint, the internal endpoint, or both are down.
+func mergeAPIServerAvailabilityConditions(checker *HealthChecker, condition, externalCondition, internalCondition gardencorev1beta1.Condition) gardencorev1beta1.Condition {
+	externalUp := externalCondition.Status == gardencorev1beta1.ConditionTrue
+	internalUp := internalCondition.Status == gardencorev1beta1.ConditionTrue
+
+	switch {
+	case !externalUp && !internalUp:
+		return checker.FailedCondition(condition, "APIServerDown", fmt.Sprintf("Both API server endpoints are unreachable (external: %s; internal: %s).", externalCondition.Message, internalCondition.Message))
+	case !externalUp:
+		return checker.FailedCondition(condition, reasonExternalAPIServerDown, fmt.Sprintf("The external API server endpoint is unreachable, while the internal endpoint is reachable: %s", externalCondition.Message))
+	case !internalUp:
+		return checker.FailedCondition(condition, reasonInternalAPIServerDown, fmt.Sprintf("The internal API server endpoint is unreachable, while the external endpoint is reachable: %s", internalCondition.Message))
+	default:
+		return gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "APIServerAvailable", fmt.Sprintf("Both the external and internal API server endpoints are reachable (external: %s; internal: %s).", externalCondition.Message, internalCondition.Message))
+	}
 }
 
 // checkControlPlane checks whether the control plane of the Shoot cluster is healthy.
 func (h *Health) checkControlPlane(
-	_ context.Context,
+	ctx context.Context,
 	checker *HealthChecker,
 	condition gardencorev1beta1.Condition,
 	seedDeploymentLister kutil.DeploymentLister,
@@ -254,11 +364,35 @@ func (h *Health) checkControlPlane(
 	if exitCondition := checker.CheckExtensionCondition(condition, extensionConditions); exitCondition != nil {
 		return exitCondition, nil
 	}
+	if exitCondition, err := h.checkAuthorizedNetworks(ctx, checker, condition); err != nil || exitCondition != nil {
+		return exitCondition, err
+	}
 
 	c := gardencorev1beta1helper.UpdatedCondition(condition, gardencorev1beta1.ConditionTrue, "ControlPlaneRunning", "All control plane components are healthy.")
 	return &c, nil
 }
 
+// checkAuthorizedNetworks verifies that the Shoot's kube-apiserver is reachable from every network declared in
+// spec.kubernetes.kubeAPIServer.authorizedNetworks, and unreachable from controlNetworkCIDR, which must always be
+// blocked. It is a no-op - preserving prior behavior - for Shoots that don't declare authorizedNetworks at all.
+func (h *Health) checkAuthorizedNetworks(ctx context.Context, checker *HealthChecker, condition gardencorev1beta1.Condition) (*gardencorev1beta1.Condition, error) {
+	authorizedNetworks := h.shoot.Info.Spec.Kubernetes.KubeAPIServer.AuthorizedNetworks
+	if len(authorizedNetworks) == 0 {
+		return nil, nil
+	}
+
+	apiServerAddress := advertisedAPIServerAddress(h.shoot.Info, addressExternal)
+	results, err := probeAuthorizedNetworks(ctx, h.seedClient.Client(), h.shoot.SeedNamespace, apiServerAddress, authorizedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	if exitCondition := checker.CheckAuthorizedNetworks(condition, results); exitCondition != nil {
+		return exitCondition, nil
+	}
+	return nil, nil
+}
+
 // checkSystemComponents checks whether the system components of a Shoot are running.
 func (h *Health) checkSystemComponents(
 	ctx context.Context,
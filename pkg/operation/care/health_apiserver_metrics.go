@@ -0,0 +1,35 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package care
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "gardenlet"
+
+var (
+	metricAPIServerAvailabilityLatencySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: "shoot_care",
+		Name:      "apiserver_availability_latency_seconds",
+		Help:      "Latency of a single Shoot API server availability probe, partitioned by Shoot, endpoint (external/internal), and whether it succeeded.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"shoot", "endpoint", "success"})
+)
+
+func init() {
+	prometheus.MustRegister(metricAPIServerAvailabilityLatencySeconds)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package care
+
+import (
+	"context"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getHealthCheckExtensionConditions lists the HealthCheck resources (extensions.gardener.cloud/v1alpha1) that
+// extensions or cluster operators have deployed into the Shoot's seed namespace to declare additional, named probes
+// (HTTP GET, TCP dial, DNS lookup, or exec-in-pod) and groups the conditions they report by the standard Shoot
+// condition each HealthCheck is configured to feed into (HealthCheck.Spec.ShootCondition). Unlike the built-in
+// extension kinds handled by getAllExtensionConditions, a single HealthCheck kind is shared by every probe, so the
+// mapping to a Shoot condition has to be read back off each object instead of being implied by its CRD kind.
+//
+// The grouped conditions are merged into the same extensionConditions slices the built-in extension kinds populate,
+// so a failing probe is rolled up via checker.CheckExtensionCondition exactly like a failing BackupEntry or Worker
+// resource would be: with the probe's name as the condition's reason, and subject to the same outdated-result
+// threshold.
+func (h *Health) getHealthCheckExtensionConditions(ctx context.Context) (map[gardencorev1beta1.ConditionType][]ExtensionCondition, error) {
+	conditions := map[gardencorev1beta1.ConditionType][]ExtensionCondition{}
+
+	healthChecks := &extensionsv1alpha1.HealthCheckList{}
+	if err := h.seedClient.Client().List(ctx, healthChecks, client.InNamespace(h.shoot.SeedNamespace)); err != nil {
+		return nil, err
+	}
+
+	for _, healthCheck := range healthChecks.Items {
+		for _, condition := range healthCheck.Status.Conditions {
+			conditions[healthCheck.Spec.ShootCondition] = append(conditions[healthCheck.Spec.ShootCondition], ExtensionCondition{
+				Condition:          condition,
+				ExtensionType:      "HealthCheck",
+				ExtensionName:      healthCheck.Name,
+				ExtensionNamespace: healthCheck.Namespace,
+			})
+		}
+	}
+
+	return conditions, nil
+}
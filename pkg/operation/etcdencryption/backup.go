@@ -0,0 +1,75 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdencryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// kekToAESKey derives a 32-byte AES-256 key from kek, whatever its raw length happens to be (it comes out of a
+// Secret's data map, not out of this package), so SealWithKEK/OpenWithKEK never have to reject a KEK for having the
+// "wrong" length.
+func kekToAESKey(kek []byte) [32]byte {
+	return sha256.Sum256(kek)
+}
+
+// SealWithKEK encrypts plaintext with kek using AES-GCM, prefixing the returned ciphertext with the random nonce
+// OpenWithKEK needs to decrypt it again.
+func SealWithKEK(kek, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed generating nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// OpenWithKEK reverses SealWithKEK.
+func OpenWithKEK(kek, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("sealed data is shorter than the nonce it must be prefixed with")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed decrypting sealed data: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(kek []byte) (cipher.AEAD, error) {
+	key := kekToAESKey(kek)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed constructing AES cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
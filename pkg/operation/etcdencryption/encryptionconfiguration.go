@@ -0,0 +1,232 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package etcdencryption builds and inspects the apiserverconfigv1.EncryptionConfiguration Gardener writes into
+// every Shoot's kube-apiserver, and the key-encryption-key wrapping applied to the backups of that configuration
+// uploaded to the Shoot's etcd backup bucket (see Botanist.uploadEncryptionConfigurationBackup). It knows nothing
+// about where the configuration is stored (seed secret, garden secret, backup bucket) - that is Botanist's job.
+package etcdencryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// secretsResource is the only apiGroup/resource Gardener configures etcd encryption for.
+	secretsResource = "secrets"
+	// encryptionKeySecretLength is the length, in bytes, of a freshly generated aescbc key, i.e. AES-256.
+	encryptionKeySecretLength = 32
+)
+
+// secretsResourceConfig returns a pointer into ec.Resources at the entry covering secretsResource, or nil if ec does
+// not configure that resource yet. Callers mutate the returned ResourceConfiguration's Providers in place.
+func secretsResourceConfig(ec *apiserverconfigv1.EncryptionConfiguration) *apiserverconfigv1.ResourceConfiguration {
+	for i := range ec.Resources {
+		for _, resource := range ec.Resources[i].Resources {
+			if resource == secretsResource {
+				return &ec.Resources[i]
+			}
+		}
+	}
+	return nil
+}
+
+// newKey generates a fresh, randomly named aescbc key, ready to be placed into an AESConfiguration.Keys list.
+func newKey() (apiserverconfigv1.Key, error) {
+	nameSuffix := make([]byte, 4)
+	if _, err := rand.Read(nameSuffix); err != nil {
+		return apiserverconfigv1.Key{}, fmt.Errorf("failed generating etcd encryption key name: %w", err)
+	}
+
+	secret := make([]byte, encryptionKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return apiserverconfigv1.Key{}, fmt.Errorf("failed generating etcd encryption key secret: %w", err)
+	}
+
+	return apiserverconfigv1.Key{
+		Name:   fmt.Sprintf("key-%s", hex.EncodeToString(nameSuffix)),
+		Secret: base64.StdEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// CreateNewPassiveConfiguration creates a brand new EncryptionConfiguration for secretsResource with the identity
+// provider first (so it does not yet encrypt anything it writes) and a freshly generated aescbc key second, ready to
+// be activated later via SetActive once CreateEtcdEncryptionConfiguration decides the Shoot should actually encrypt.
+func CreateNewPassiveConfiguration() (*apiserverconfigv1.EncryptionConfiguration, error) {
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiserverconfigv1.EncryptionConfiguration{
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{
+				Resources: []string{secretsResource},
+				Providers: []apiserverconfigv1.ProviderConfiguration{
+					{Identity: &apiserverconfigv1.IdentityConfiguration{}},
+					{AESCBC: &apiserverconfigv1.AESConfiguration{Keys: []apiserverconfigv1.Key{key}}},
+				},
+			},
+		},
+	}, nil
+}
+
+// IsActive reports whether ec currently encrypts secretsResource on write, i.e. whether its aescbc provider is
+// listed ahead of the identity provider.
+func IsActive(ec *apiserverconfigv1.EncryptionConfiguration) bool {
+	rc := secretsResourceConfig(ec)
+	return rc != nil && len(rc.Providers) > 0 && rc.Providers[0].AESCBC != nil
+}
+
+// SetActive reorders ec's secretsResource providers so that the aescbc provider (active) or the identity provider
+// (passive) is listed first, without otherwise changing either provider's configuration. It is a no-op if ec does
+// not configure secretsResource, or if the requested provider is not present.
+func SetActive(ec *apiserverconfigv1.EncryptionConfiguration, active bool) {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return
+	}
+
+	for i, provider := range rc.Providers {
+		isWanted := provider.AESCBC != nil
+		if !active {
+			isWanted = provider.Identity != nil
+		}
+		if isWanted {
+			rc.Providers[0], rc.Providers[i] = rc.Providers[i], rc.Providers[0]
+			return
+		}
+	}
+}
+
+// CreateFromYAML parses the apiserver EncryptionConfiguration serialized in secretData.
+func CreateFromYAML(secretData []byte) (*apiserverconfigv1.EncryptionConfiguration, error) {
+	ec := &apiserverconfigv1.EncryptionConfiguration{}
+	if err := yaml.Unmarshal(secretData, ec); err != nil {
+		return nil, fmt.Errorf("failed unmarshalling EncryptionConfiguration: %w", err)
+	}
+	return ec, nil
+}
+
+// ToYAML serializes ec the same way CreateFromYAML expects to read it back.
+func ToYAML(ec *apiserverconfigv1.EncryptionConfiguration) ([]byte, error) {
+	return yaml.Marshal(ec)
+}
+
+// Equals reports whether a and b describe the same EncryptionConfiguration. Botanist uses it to detect drift
+// between the copies held in the seed and garden clusters, which must always be kept identical.
+func Equals(a, b *apiserverconfigv1.EncryptionConfiguration) (bool, error) {
+	if a == nil || b == nil {
+		return false, fmt.Errorf("cannot compare a nil EncryptionConfiguration")
+	}
+	return reflect.DeepEqual(a, b), nil
+}
+
+// IsConsistent sanity-checks that ec is well-formed enough for kube-apiserver to start with it: it must configure
+// secretsResource, with at least one provider, including an aescbc provider carrying at least one named key.
+func IsConsistent(ec *apiserverconfigv1.EncryptionConfiguration) (bool, error) {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return false, fmt.Errorf("no provider configuration for resource %q", secretsResource)
+	}
+	if len(rc.Providers) == 0 {
+		return false, fmt.Errorf("no providers configured for resource %q", secretsResource)
+	}
+
+	for _, provider := range rc.Providers {
+		if provider.AESCBC == nil {
+			continue
+		}
+		if len(provider.AESCBC.Keys) == 0 {
+			return false, fmt.Errorf("aescbc provider does not carry any keys")
+		}
+		for _, key := range provider.AESCBC.Keys {
+			if key.Name == "" || key.Secret == "" {
+				return false, fmt.Errorf("aescbc provider carries a key with an empty name or secret")
+			}
+		}
+		return true, nil
+	}
+
+	return false, fmt.Errorf("no aescbc provider configured for resource %q", secretsResource)
+}
+
+// aescbcProvider returns a pointer to the aescbc ProviderConfiguration for secretsResource, or nil if none exists.
+func aescbcProvider(ec *apiserverconfigv1.EncryptionConfiguration) *apiserverconfigv1.ProviderConfiguration {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return nil
+	}
+	for i := range rc.Providers {
+		if rc.Providers[i].AESCBC != nil {
+			return &rc.Providers[i]
+		}
+	}
+	return nil
+}
+
+// AddSecondaryKey generates a new aescbc key and appends it after the current primary key, so that kube-apiserver
+// can decrypt Secrets written under either key once it has been rolled with the updated configuration. It does not
+// touch provider ordering, so writes still happen under the existing primary key until PromoteSecondaryKey runs.
+func AddSecondaryKey(ec *apiserverconfigv1.EncryptionConfiguration) error {
+	provider := aescbcProvider(ec)
+	if provider == nil {
+		return fmt.Errorf("cannot add a secondary etcd encryption key: no aescbc provider configured")
+	}
+	if len(provider.AESCBC.Keys) > 1 {
+		return fmt.Errorf("a secondary etcd encryption key is already present; rotation already in progress")
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return err
+	}
+	provider.AESCBC.Keys = append(provider.AESCBC.Keys, key)
+	return nil
+}
+
+// PromoteSecondaryKey makes the secondary key added by AddSecondaryKey the (sole) primary key, dropping the old
+// primary key now that RewriteShootSecrets has confirmed every Secret was re-encrypted.
+func PromoteSecondaryKey(ec *apiserverconfigv1.EncryptionConfiguration) error {
+	provider := aescbcProvider(ec)
+	if provider == nil {
+		return fmt.Errorf("cannot promote the secondary etcd encryption key: no aescbc provider configured")
+	}
+	if len(provider.AESCBC.Keys) < 2 {
+		return fmt.Errorf("no secondary etcd encryption key to promote")
+	}
+
+	provider.AESCBC.Keys = []apiserverconfigv1.Key{provider.AESCBC.Keys[1]}
+	return nil
+}
+
+// SecondaryKeyID returns the name of the secondary key added by AddSecondaryKey, i.e. the key ID a rotation in
+// progress is working towards promoting.
+func SecondaryKeyID(ec *apiserverconfigv1.EncryptionConfiguration) (string, error) {
+	provider := aescbcProvider(ec)
+	if provider == nil {
+		return "", fmt.Errorf("cannot determine secondary etcd encryption key: no aescbc provider configured")
+	}
+	if len(provider.AESCBC.Keys) < 2 {
+		return "", fmt.Errorf("no secondary etcd encryption key present")
+	}
+	return provider.AESCBC.Keys[1].Name, nil
+}
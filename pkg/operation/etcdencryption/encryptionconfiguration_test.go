@@ -0,0 +1,170 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdencryption_test
+
+import (
+	. "github.com/gardener/gardener/pkg/operation/etcdencryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+)
+
+var _ = Describe("encryptionconfiguration", func() {
+	Describe("CreateNewPassiveConfiguration", func() {
+		It("should create a configuration that is not yet active but already consistent", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(IsActive(ec)).To(BeFalse())
+			consistent, err := IsConsistent(ec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(consistent).To(BeTrue())
+		})
+	})
+
+	Describe("SetActive", func() {
+		It("should reorder the providers so that the aescbc provider is listed first", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+
+			SetActive(ec, true)
+			Expect(IsActive(ec)).To(BeTrue())
+
+			SetActive(ec, false)
+			Expect(IsActive(ec)).To(BeFalse())
+		})
+	})
+
+	Describe("ToYAML / CreateFromYAML", func() {
+		It("should round-trip a configuration", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := ToYAML(ec)
+			Expect(err).NotTo(HaveOccurred())
+
+			roundTripped, err := CreateFromYAML(data)
+			Expect(err).NotTo(HaveOccurred())
+
+			equal, err := Equals(ec, roundTripped)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(equal).To(BeTrue())
+		})
+	})
+
+	Describe("Equals", func() {
+		It("should return an error if either argument is nil", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = Equals(nil, ec)
+			Expect(err).To(HaveOccurred())
+			_, err = Equals(ec, nil)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return false for configurations with different keys", func() {
+			a, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			b, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+
+			equal, err := Equals(a, b)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(equal).To(BeFalse())
+		})
+	})
+
+	Describe("IsConsistent", func() {
+		It("should return an error for an empty configuration", func() {
+			consistent, err := IsConsistent(&apiserverconfigv1.EncryptionConfiguration{})
+			Expect(err).To(HaveOccurred())
+			Expect(consistent).To(BeFalse())
+		})
+
+		It("should return an error if the aescbc provider has no keys", func() {
+			ec := &apiserverconfigv1.EncryptionConfiguration{
+				Resources: []apiserverconfigv1.ResourceConfiguration{
+					{
+						Resources: []string{"secrets"},
+						Providers: []apiserverconfigv1.ProviderConfiguration{
+							{AESCBC: &apiserverconfigv1.AESConfiguration{}},
+						},
+					},
+				},
+			}
+
+			consistent, err := IsConsistent(ec)
+			Expect(err).To(HaveOccurred())
+			Expect(consistent).To(BeFalse())
+		})
+	})
+
+	Describe("AddSecondaryKey / PromoteSecondaryKey / SecondaryKeyID", func() {
+		It("should add a secondary key without disturbing the primary", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			SetActive(ec, true)
+
+			primaryBefore := ec.Resources[0].Providers[0].AESCBC.Keys[0].Name
+
+			Expect(AddSecondaryKey(ec)).To(Succeed())
+
+			keys := ec.Resources[0].Providers[0].AESCBC.Keys
+			Expect(keys).To(HaveLen(2))
+			Expect(keys[0].Name).To(Equal(primaryBefore))
+
+			secondaryID, err := SecondaryKeyID(ec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secondaryID).To(Equal(keys[1].Name))
+		})
+
+		It("should refuse to add a second secondary key while a rotation is already in progress", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			SetActive(ec, true)
+
+			Expect(AddSecondaryKey(ec)).To(Succeed())
+			Expect(AddSecondaryKey(ec)).To(HaveOccurred())
+		})
+
+		It("should promote the secondary key to primary and drop the old primary", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			SetActive(ec, true)
+			Expect(AddSecondaryKey(ec)).To(Succeed())
+
+			secondaryID, err := SecondaryKeyID(ec)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(PromoteSecondaryKey(ec)).To(Succeed())
+
+			keys := ec.Resources[0].Providers[0].AESCBC.Keys
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].Name).To(Equal(secondaryID))
+		})
+
+		It("should return an error when promoting without a secondary key present", func() {
+			ec, err := CreateNewPassiveConfiguration()
+			Expect(err).NotTo(HaveOccurred())
+			SetActive(ec, true)
+
+			Expect(PromoteSecondaryKey(ec)).To(HaveOccurred())
+			_, err = SecondaryKeyID(ec)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
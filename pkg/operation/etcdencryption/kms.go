@@ -0,0 +1,142 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdencryption
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+)
+
+// defaultKMSAPIVersion is used for a KMSEncryptionConfig entry that does not specify one, see KMSEncryptionConfig.
+const defaultKMSAPIVersion = "v1"
+
+// kmsDialTimeout bounds how long KMSProvidersReachable waits for a single KMS plugin socket to accept a connection.
+const kmsDialTimeout = 3 * time.Second
+
+// SetKMSProviders rebuilds ec's secretsResource provider list so that it carries exactly one KMS provider per entry
+// in kmsConfigs, in the given order, ahead of the existing aescbc/identity providers (which are left untouched). It
+// reports whether ec was changed, so callers know whether the secret backing it needs to be rewritten.
+func SetKMSProviders(ec *apiserverconfigv1.EncryptionConfiguration, kmsConfigs []gardencorev1beta1.KMSEncryptionConfig) (bool, error) {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return false, fmt.Errorf("cannot set KMS providers: no provider configuration for resource %q", secretsResource)
+	}
+
+	kmsProviders := make([]apiserverconfigv1.ProviderConfiguration, 0, len(kmsConfigs))
+	for _, kmsConfig := range kmsConfigs {
+		apiVersion := kmsConfig.APIVersion
+		if apiVersion == "" {
+			apiVersion = defaultKMSAPIVersion
+		}
+
+		kmsProviders = append(kmsProviders, apiserverconfigv1.ProviderConfiguration{
+			KMS: &apiserverconfigv1.KMSConfiguration{
+				APIVersion: apiVersion,
+				Name:       kmsConfig.Name,
+				Endpoint:   kmsConfig.Endpoint,
+				CacheSize:  kmsConfig.CacheSize,
+				Timeout:    kmsConfig.Timeout,
+			},
+		})
+	}
+
+	newProviders := append(kmsProviders, nonKMSProviders(rc)...)
+	changed := !reflect.DeepEqual(rc.Providers, newProviders)
+	rc.Providers = newProviders
+	return changed, nil
+}
+
+// RemoveKMSProviders strips every KMS provider from ec's secretsResource provider list, leaving the aescbc/identity
+// providers untouched, and reports whether ec was changed.
+func RemoveKMSProviders(ec *apiserverconfigv1.EncryptionConfiguration) bool {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return false
+	}
+
+	newProviders := nonKMSProviders(rc)
+	changed := len(newProviders) != len(rc.Providers)
+	rc.Providers = newProviders
+	return changed
+}
+
+// nonKMSProviders returns rc's providers with any KMS provider filtered out.
+func nonKMSProviders(rc *apiserverconfigv1.ResourceConfiguration) []apiserverconfigv1.ProviderConfiguration {
+	providers := make([]apiserverconfigv1.ProviderConfiguration, 0, len(rc.Providers))
+	for _, provider := range rc.Providers {
+		if provider.KMS == nil {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// KMSProvidersReachable checks that ec carries a KMS provider for every entry in kmsConfigs, and that each one's
+// sidecar endpoint currently accepts connections. It does not speak the KMS gRPC protocol itself - a successful
+// dial only proves the sidecar process is up and listening, which is what isEncryptionConfigurationConsistent needs
+// to know before trusting ec enough to let kube-apiserver start with it.
+func KMSProvidersReachable(ec *apiserverconfigv1.EncryptionConfiguration, kmsConfigs []gardencorev1beta1.KMSEncryptionConfig) (bool, error) {
+	rc := secretsResourceConfig(ec)
+	if rc == nil {
+		return false, fmt.Errorf("no provider configuration for resource %q", secretsResource)
+	}
+
+	configured := map[string]bool{}
+	for _, provider := range rc.Providers {
+		if provider.KMS != nil {
+			configured[provider.KMS.Name] = true
+		}
+	}
+
+	for _, kmsConfig := range kmsConfigs {
+		if !configured[kmsConfig.Name] {
+			return false, fmt.Errorf("KMS provider %q is not present in the EncryptionConfiguration", kmsConfig.Name)
+		}
+		if err := dialKMSEndpoint(kmsConfig.Endpoint); err != nil {
+			return false, fmt.Errorf("KMS provider %q endpoint %q is not reachable: %w", kmsConfig.Name, kmsConfig.Endpoint, err)
+		}
+	}
+
+	return true, nil
+}
+
+// dialKMSEndpoint dials endpoint (a unix:// or tcp:// URL, see KMSEncryptionConfig.Endpoint) and immediately closes
+// the connection again.
+func dialKMSEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+
+	network := "tcp"
+	address := u.Host
+	if u.Scheme == "unix" {
+		network = "unix"
+		address = u.Path
+	}
+
+	conn, err := net.DialTimeout(network, address, kmsDialTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/gardener/gardener/pkg/utils"
+)
+
+// AnnotationMode determines how an ExposureClass's LoadBalancer service annotations are combined with the seed's
+// own annotations.
+type AnnotationMode string
+
+const (
+	// AnnotationModeMerge merges the shoot, exposure class and seed annotations, with the precedence described by
+	// AnnotationStrategy. This is the default and preserves the historic behaviour.
+	AnnotationModeMerge AnnotationMode = "merge"
+	// AnnotationModeReplace discards the lower-precedence annotations entirely instead of merging with them.
+	AnnotationModeReplace AnnotationMode = "replace"
+	// AnnotationModeTemplate evaluates each annotation value as a Go template before merging, with TemplateData
+	// made available as the template's root object (e.g. `{{ .Shoot.Name }}`).
+	AnnotationModeTemplate AnnotationMode = "template"
+)
+
+// AnnotationStrategy configures how LoadBalancer service annotations from different sources (shoot, exposure
+// class, seed) are combined. The precedence order from highest to lowest is: shoot > exposure class > seed.
+type AnnotationStrategy struct {
+	Mode AnnotationMode
+}
+
+// TemplateData is made available as the root object when evaluating AnnotationModeTemplate annotation values.
+type TemplateData struct {
+	ProjectName string
+	ShootName   string
+	SeedName    string
+	Region      string
+}
+
+// ApplyAnnotationStrategy combines the given seed-level and exposure-class-level LoadBalancer service annotations
+// according to the strategy (seed has the lowest precedence, exposure class the higher one), returning the merged
+// result and the list of annotation keys that were dropped due to a conflict between the two sources.
+func ApplyAnnotationStrategy(strategy *AnnotationStrategy, seedAnnotations, exposureClassAnnotations map[string]string, data TemplateData) (map[string]string, []string, error) {
+	mode := AnnotationModeMerge
+	if strategy != nil && strategy.Mode != "" {
+		mode = strategy.Mode
+	}
+
+	resolvedExposureClassAnnotations := exposureClassAnnotations
+	if mode == AnnotationModeTemplate {
+		resolved, err := renderAnnotationTemplates(exposureClassAnnotations, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolvedExposureClassAnnotations = resolved
+	}
+
+	if mode == AnnotationModeReplace {
+		if len(resolvedExposureClassAnnotations) > 0 {
+			return resolvedExposureClassAnnotations, nil, nil
+		}
+		return seedAnnotations, nil, nil
+	}
+
+	var conflicts []string
+	for key, seedValue := range seedAnnotations {
+		if exposureClassValue, ok := resolvedExposureClassAnnotations[key]; ok && exposureClassValue != seedValue {
+			conflicts = append(conflicts, key)
+		}
+	}
+
+	// exposure class annotations win over seed annotations for conflicting keys
+	return utils.MergeStringMaps(seedAnnotations, resolvedExposureClassAnnotations), conflicts, nil
+}
+
+func renderAnnotationTemplates(annotations map[string]string, data TemplateData) (map[string]string, error) {
+	if len(annotations) == 0 {
+		return annotations, nil
+	}
+
+	rendered := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		tmpl, err := template.New(key).Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("annotation %q does not compile as a template: %v", key, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("annotation %q failed to render: %v", key, err)
+		}
+		rendered[key] = buf.String()
+	}
+	return rendered, nil
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener_test
+
+import (
+	. "github.com/gardener/gardener/pkg/utils/gardener"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ApplyAnnotationStrategy", func() {
+	var (
+		seedAnnotations          map[string]string
+		exposureClassAnnotations map[string]string
+		data                     TemplateData
+	)
+
+	BeforeEach(func() {
+		seedAnnotations = map[string]string{"seed-only": "a", "shared": "seed-value"}
+		exposureClassAnnotations = map[string]string{"exposure-only": "b", "shared": "exposure-value"}
+		data = TemplateData{ProjectName: "project", ShootName: "shoot", SeedName: "seed", Region: "region"}
+	})
+
+	It("should default to merge mode if strategy is nil", func() {
+		annotations, conflicts, err := ApplyAnnotationStrategy(nil, seedAnnotations, exposureClassAnnotations, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(map[string]string{"seed-only": "a", "exposure-only": "b", "shared": "exposure-value"}))
+		Expect(conflicts).To(ConsistOf("shared"))
+	})
+
+	It("should merge with the exposure class winning conflicts", func() {
+		strategy := &AnnotationStrategy{Mode: AnnotationModeMerge}
+		annotations, conflicts, err := ApplyAnnotationStrategy(strategy, seedAnnotations, exposureClassAnnotations, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(map[string]string{"seed-only": "a", "exposure-only": "b", "shared": "exposure-value"}))
+		Expect(conflicts).To(ConsistOf("shared"))
+	})
+
+	It("should replace the seed annotations entirely when exposure class annotations are set", func() {
+		strategy := &AnnotationStrategy{Mode: AnnotationModeReplace}
+		annotations, conflicts, err := ApplyAnnotationStrategy(strategy, seedAnnotations, exposureClassAnnotations, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(exposureClassAnnotations))
+		Expect(conflicts).To(BeEmpty())
+	})
+
+	It("should fall back to the seed annotations in replace mode if the exposure class sets none", func() {
+		strategy := &AnnotationStrategy{Mode: AnnotationModeReplace}
+		annotations, conflicts, err := ApplyAnnotationStrategy(strategy, seedAnnotations, nil, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(Equal(seedAnnotations))
+		Expect(conflicts).To(BeEmpty())
+	})
+
+	It("should render exposure class annotation values as Go templates before merging", func() {
+		strategy := &AnnotationStrategy{Mode: AnnotationModeTemplate}
+		exposureClassAnnotations = map[string]string{"dns": "{{ .ShootName }}.{{ .SeedName }}"}
+
+		annotations, conflicts, err := ApplyAnnotationStrategy(strategy, seedAnnotations, exposureClassAnnotations, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(annotations).To(HaveKeyWithValue("dns", "shoot.seed"))
+		Expect(conflicts).To(BeEmpty())
+	})
+
+	It("should return an error if an exposure class annotation value does not compile as a template", func() {
+		strategy := &AnnotationStrategy{Mode: AnnotationModeTemplate}
+		exposureClassAnnotations = map[string]string{"broken": "{{ .ShootName "}
+
+		_, _, err := ApplyAnnotationStrategy(strategy, seedAnnotations, exposureClassAnnotations, data)
+		Expect(err).To(HaveOccurred())
+	})
+})
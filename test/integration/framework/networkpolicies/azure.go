@@ -16,15 +16,19 @@ package networkpolicies
 
 import (
 	"github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	addonnetworkpolicies "github.com/gardener/gardener/pkg/operation/botanist/addons/networkpolicies"
 )
 
 var (
+	// azureMetadataServiceEndpoint is the single source of truth for the Azure metadata service's address, shared
+	// with the production addonnetworkpolicies.AzureMetadataServicePolicy so the two cannot drift apart.
+	azureMetadataServiceEndpoint = addonnetworkpolicies.AzureMetadataServicePolicy.Endpoints()[0]
 
 	// AzureMetadataServiceHost points to azure-specific Metadata service.
 	AzureMetadataServiceHost = &Host{
 		Description: "Metadata service",
-		HostName:    "169.254.169.254",
-		Port:        80,
+		HostName:    azureMetadataServiceEndpoint.Host,
+		Port:        int(azureMetadataServiceEndpoint.Port),
 	}
 )
 
@@ -48,6 +52,9 @@ func (a *AzureNetworkPolicy) ToSources() []Rule {
 		a.newSource(GrafanaInfo).AllowPod(PrometheusInfo).Build(),
 		a.newSource(KibanaInfo).AllowTargetPod(ElasticSearchInfo.FromPort("http")).Build(),
 		a.newSource(AddonManagerInfo).AllowPod(KubeAPIServerInfo).AllowHost(SeedKubeAPIServer, ExternalHost).Build(),
+		// Per addonnetworkpolicies.AzureMetadataServicePolicy, kube-controller-manager is the only component that
+		// calls ARM for Azure (cloud-controller-manager intentionally does not get metadata access above); both
+		// variants below are the same logical component under the two possible kubelet TLS bootstrap states.
 		a.newSource(KubeControllerManagerInfoNotSecured).AllowPod(KubeAPIServerInfo).AllowHost(AzureMetadataServiceHost, ExternalHost).Build(),
 		a.newSource(KubeControllerManagerInfoSecured).AllowPod(KubeAPIServerInfo).AllowHost(AzureMetadataServiceHost, ExternalHost).Build(),
 		a.newSource(KubeSchedulerInfoNotSecured).AllowPod(KubeAPIServerInfo).Build(),
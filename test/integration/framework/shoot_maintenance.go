@@ -0,0 +1,60 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardener/pkg/client/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WorkerPoolLabel is the label every Node carries identifying the Shoot worker pool it was created for.
+const WorkerPoolLabel = "worker.gardener.cloud/pool"
+
+// WaitForWorkerRollout polls pool's Nodes through shootClient until every one of them reports targetImageVersion in
+// its nodeInfo.osImage, or timeout elapses. It mirrors the Cluster API MachineDeploymentState.IsUpgrading check:
+// rather than trusting the Shoot spec, it inspects the actual machines (here: Nodes) and only considers the rollout
+// done once none of them is still stuck on an older version.
+func (t *ShootMaintenanceTest) WaitForWorkerRollout(ctx context.Context, shootClient kubernetes.Interface, pool, targetImageVersion string, timeout time.Duration) error {
+	err := wait.PollImmediate(5*time.Second, timeout, func() (bool, error) {
+		nodeList := &corev1.NodeList{}
+		if err := shootClient.Client().List(ctx, nodeList, client.MatchingLabels{WorkerPoolLabel: pool}); err != nil {
+			return false, err
+		}
+
+		if len(nodeList.Items) == 0 {
+			return false, nil
+		}
+
+		for _, node := range nodeList.Items {
+			if !strings.Contains(node.Status.NodeInfo.OSImage, targetImageVersion) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("worker pool %q did not roll out machine image version %q within %s: %w", pool, targetImageVersion, timeout, err)
+	}
+	return nil
+}
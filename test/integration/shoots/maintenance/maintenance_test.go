@@ -66,6 +66,7 @@ import (
 
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	shootmaintenance "github.com/gardener/gardener/pkg/controllermanager/controller/shoot"
 	"github.com/gardener/gardener/test/framework"
 	. "github.com/gardener/gardener/test/integration/framework"
 	. "github.com/gardener/gardener/test/integration/shoots"
@@ -107,7 +108,25 @@ var (
 	testHighestPatchKubernetesVersionHighMinor = gardencorev1beta1.ExpirableVersion{Version: "0.1.5", Classification: &deprecatedClassification}
 	expirationDateInTheFuture                  = metav1.Time{Time: time.Now().UTC().Add(time.Second * 20)}
 	expirationDateInThePast                    = metav1.Time{Time: time.Now().UTC().AddDate(0, 0, -1)}
-	testMachineImage                           = gardencorev1beta1.ShootMachineImage{
+
+	// testKubernetesVersionChain* seed a dedicated three-minor-hop upgrade chain (0.10.x -> 0.11.x -> 0.12.x ->
+	// 0.13.x), independent of the single-hop versions above so the two scenarios don't interfere with each other.
+	// Every version but the last is already expired, so a multi-hop upgrade can be driven across several
+	// maintenance triggers without any per-hop CloudProfile mutation.
+	testKubernetesVersionChainStart  = gardencorev1beta1.ExpirableVersion{Version: "0.10.5", Classification: &deprecatedClassification, ExpirationDate: &expirationDateInThePast}
+	testKubernetesVersionChainMinor1 = gardencorev1beta1.ExpirableVersion{Version: "0.11.5", Classification: &deprecatedClassification, ExpirationDate: &expirationDateInThePast}
+	testKubernetesVersionChainMinor2 = gardencorev1beta1.ExpirableVersion{Version: "0.12.5", Classification: &deprecatedClassification, ExpirationDate: &expirationDateInThePast}
+	testKubernetesVersionChainFinal  = gardencorev1beta1.ExpirableVersion{Version: "0.13.5", Classification: &deprecatedClassification}
+
+	// testKubernetesVersionChannel* seed a dedicated minor version offered across multiple maintenance channels, so
+	// a channel-subscribed Shoot's auto-update can be asserted to respect the channel ceiling even when a higher
+	// patch is available on a less conservative channel.
+	stableChannel                      = gardencorev1beta1.ChannelStable
+	rapidChannel                       = gardencorev1beta1.ChannelRapid
+	testKubernetesVersionChannelBase   = gardencorev1beta1.ExpirableVersion{Version: "0.20.1", Classification: &deprecatedClassification, Channel: &stableChannel}
+	testKubernetesVersionChannelStable = gardencorev1beta1.ExpirableVersion{Version: "0.20.5", Classification: &deprecatedClassification, Channel: &stableChannel}
+	testKubernetesVersionChannelRapid  = gardencorev1beta1.ExpirableVersion{Version: "0.20.9", Classification: &deprecatedClassification, Channel: &rapidChannel}
+	testMachineImage                   = gardencorev1beta1.ShootMachineImage{
 		Version: testMachineImageVersion,
 	}
 
@@ -192,7 +211,7 @@ var _ = Describe("Shoot Maintenance testing", func() {
 
 	CAfterSuite(func(ctx context.Context) {
 		if cloudProfileCleanupNeeded {
-			err := shootMaintenanceTest.CleanupCloudProfile(ctx, testMachineImage, []gardencorev1beta1.ExpirableVersion{testKubernetesVersionLowMinor, testHighestPatchKubernetesVersionLowMinor, testKubernetesVersionHighMinor, testHighestPatchKubernetesVersionHighMinor})
+			err := shootMaintenanceTest.CleanupCloudProfile(ctx, testMachineImage, []gardencorev1beta1.ExpirableVersion{testKubernetesVersionLowMinor, testHighestPatchKubernetesVersionLowMinor, testKubernetesVersionHighMinor, testHighestPatchKubernetesVersionHighMinor, testKubernetesVersionChainStart, testKubernetesVersionChainMinor1, testKubernetesVersionChainMinor2, testKubernetesVersionChainFinal, testKubernetesVersionChannelBase, testKubernetesVersionChannelStable, testKubernetesVersionChannelRapid})
 			Expect(err).NotTo(HaveOccurred())
 			f.Logger.Infof("Cleaned Cloud Profile '%s'", shootMaintenanceTest.CloudProfile.Name)
 		}
@@ -334,6 +353,13 @@ var _ = Describe("Shoot Maintenance testing", func() {
 		err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, true, nil)
 		Expect(err).To(BeNil())
 
+		By("the KubernetesVersionUpToDate condition should carry the HigherPatchAvailable reason for a voluntary AutoUpdate")
+		preview, err := shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+		Expect(err).To(BeNil())
+		Expect(preview.Kubernetes).NotTo(BeNil())
+		Expect(preview.Kubernetes.Voluntary).To(BeTrue())
+		Expect(preview.Kubernetes.Reason).To(Equal(shootmaintenance.ReasonHigherPatchAvailable))
+
 		err = shootMaintenanceTest.WaitForExpectedKubernetesVersionMaintenance(ctx, testHighestPatchKubernetesVersionLowMinor.Version, true, time.Now().Add(time.Second*20))
 		Expect(err).To(BeNil())
 	}, WaitForCreateDeleteTimeout)
@@ -403,6 +429,169 @@ var _ = Describe("Shoot Maintenance testing", func() {
 		err = shootMaintenanceTest.WaitForExpectedKubernetesVersionMaintenance(ctx, testHighestPatchKubernetesVersionHighMinor.Version, true, time.Now().Add(time.Second*20))
 		Expect(err).To(BeNil())
 	}, WaitForCreateDeleteTimeout)
+
+	CIt("Kubernetes Version preview matches the maintenance run that follows it", func(ctx context.Context) {
+		By("Preview should report the forced patch version update once the current version has expired")
+		err := f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		defer func() {
+			// make sure to remove expiration date from cloud profile after test
+			err = shootMaintenanceTest.TryUpdateCloudProfileForKubernetesVersionMaintenance(ctx, shootMaintenanceTest.Shoot, testKubernetesVersionLowMinor.Version, nil, &deprecatedClassification)
+			Expect(err).To(BeNil())
+			f.Logger.Infof("Cleaned expiration date on kubernetes version from Cloud Profile '%s'", shootMaintenanceTest.CloudProfile.Name)
+		}()
+
+		// modify cloud profile for test
+		err = shootMaintenanceTest.TryUpdateCloudProfileForKubernetesVersionMaintenance(ctx, shootMaintenanceTest.Shoot, testKubernetesVersionLowMinor.Version, &expirationDateInTheFuture, &deprecatedClassification)
+		Expect(err).To(BeNil())
+
+		// set test specific shoot settings
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = false
+
+		// update integration test shoot - autoupdate == false
+		err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, false, nil)
+		Expect(err).To(BeNil())
+
+		// sleep so that expiration date is in the past - the preview should now report a forced update
+		time.Sleep(30 * time.Second)
+
+		err = f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		preview, err := shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+		Expect(err).To(BeNil())
+		Expect(preview.Kubernetes).NotTo(BeNil())
+		Expect(preview.Kubernetes.Target).To(Equal(testHighestPatchKubernetesVersionLowMinor.Version))
+		Expect(preview.Kubernetes.Voluntary).To(BeFalse())
+		Expect(preview.Kubernetes.Reason).To(Equal(shootmaintenance.ReasonVersionExpired))
+
+		By("ComputeMaintenanceConditions should surface the same reason on ForceUpdatePending and KubernetesVersionUpToDate")
+		conditions := shootmaintenance.ComputeMaintenanceConditions(shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile, preview, nil)
+		kubernetesCondition := helper.GetCondition(conditions, shootmaintenance.ConditionKubernetesVersionUpToDate)
+		Expect(kubernetesCondition).NotTo(BeNil())
+		Expect(kubernetesCondition.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(kubernetesCondition.Reason).To(Equal(string(shootmaintenance.ReasonVersionExpired)))
+
+		forceUpdateCondition := helper.GetCondition(conditions, shootmaintenance.ConditionForceUpdatePending)
+		Expect(forceUpdateCondition).NotTo(BeNil())
+		Expect(forceUpdateCondition.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+
+		By("the maintenance run triggered right after should apply exactly what the preview predicted")
+		// update integration test shoot - set maintain now annotation
+		err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, true, nil)
+		Expect(err).To(BeNil())
+
+		err = shootMaintenanceTest.WaitForExpectedKubernetesVersionMaintenance(ctx, preview.Kubernetes.Target, true, time.Now().Add(time.Second*20))
+		Expect(err).To(BeNil())
+	}, WaitForCreateDeleteTimeout)
+
+	CIt("Kubernetes Version channel-restricted auto-update does not cross into a less conservative channel", func(ctx context.Context) {
+		By("a Shoot subscribed to the stable channel should not auto-update to a higher patch tagged rapid")
+		err := f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = trueVar
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesChannel = &stableChannel
+		err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, true, &testKubernetesVersionChannelBase.Version)
+		Expect(err).To(BeNil())
+
+		preview, err := shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+		Expect(err).To(BeNil())
+		Expect(preview.Kubernetes).NotTo(BeNil())
+		Expect(preview.Kubernetes.Target).To(Equal(testKubernetesVersionChannelStable.Version))
+
+		By("once subscribed to the rapid channel the same Shoot becomes eligible for the rapid-tagged patch")
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesChannel = &rapidChannel
+		err = f.GardenClient.Client().Update(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		preview, err = shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+		Expect(err).To(BeNil())
+		Expect(preview.Kubernetes).NotTo(BeNil())
+		Expect(preview.Kubernetes.Target).To(Equal(testKubernetesVersionChannelRapid.Version))
+
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesChannel = nil
+		err = f.GardenClient.Client().Update(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+	}, WaitForCreateDeleteTimeout)
+
+	CIt("Kubernetes Version multi-hop forced minor upgrade chain", func(ctx context.Context) {
+		By("seeding a Shoot on the lowest expired minor of a three-minor chain")
+		err := f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate.KubernetesVersion = false
+		err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, false, &testKubernetesVersionChainStart.Version)
+		Expect(err).To(BeNil())
+
+		hops := []string{testKubernetesVersionChainMinor1.Version, testKubernetesVersionChainMinor2.Version, testKubernetesVersionChainFinal.Version}
+		for _, target := range hops {
+			By(fmt.Sprintf("maintenance should advance the control plane by exactly one minor, to %s", target))
+			err = f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+			Expect(err).To(BeNil())
+
+			preview, err := shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+			Expect(err).To(BeNil())
+			Expect(preview.Kubernetes).NotTo(BeNil())
+			Expect(preview.Kubernetes.Blocked).To(BeFalse())
+			Expect(preview.Kubernetes.Target).To(Equal(target))
+
+			// update integration test shoot - set maintain now annotation
+			err = shootMaintenanceTest.TryUpdateShootForKubernetesMaintenance(ctx, shootMaintenanceTest.Shoot, true, nil)
+			Expect(err).To(BeNil())
+
+			err = shootMaintenanceTest.WaitForExpectedKubernetesVersionMaintenance(ctx, target, true, time.Now().Add(time.Second*20))
+			Expect(err).To(BeNil())
+		}
+
+		By("no further hop is expected once the chain reaches its non-expired final minor")
+		err = f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		preview, err := shootmaintenance.PreviewMaintenance(ctx, shootMaintenanceTest.Shoot, shootMaintenanceTest.CloudProfile)
+		Expect(err).To(BeNil())
+		Expect(preview.Kubernetes).To(BeNil())
+	}, WaitForCreateDeleteTimeout)
+
+	CIt("Machine image force update triggers a real node rollout", func(ctx context.Context) {
+		By("force updating the worker pool's machine image via an expired version")
+		err := f.GetShoot(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		pool := shootMaintenanceTest.Shoot.Spec.Provider.Workers[0].Name
+
+		defer func() {
+			// make sure to remove expiration date from cloud profile after test
+			err = shootMaintenanceTest.TryUpdateCloudProfileForMachineImageMaintenance(ctx, shootMaintenanceTest.Shoot, testMachineImage, nil, &deprecatedClassification)
+			Expect(err).To(BeNil())
+			f.Logger.Infof("Cleaned expiration date on machine image from Cloud Profile '%s'", shootMaintenanceTest.CloudProfile.Name)
+		}()
+
+		shootMaintenanceTest.Shoot.Spec.Maintenance.AutoUpdate = &gardencorev1beta1.MaintenanceAutoUpdate{MachineImageVersion: false}
+		err = shootMaintenanceTest.TryUpdateShootForMachineImageMaintenance(ctx, shootMaintenanceTest.Shoot, false, nil)
+		Expect(err).To(BeNil())
+
+		err = shootMaintenanceTest.TryUpdateCloudProfileForMachineImageMaintenance(ctx, shootMaintenanceTest.Shoot, testMachineImage, &expirationDateInTheFuture, &deprecatedClassification)
+		Expect(err).To(BeNil())
+
+		// sleep so that expiration date is in the past - forceUpdate is required
+		time.Sleep(30 * time.Second)
+
+		// update integration test shoot - set maintain now annotation
+		err = shootMaintenanceTest.TryUpdateShootForMachineImageMaintenance(ctx, shootMaintenanceTest.Shoot, true, nil)
+		Expect(err).To(BeNil())
+
+		err = shootMaintenanceTest.WaitForExpectedMachineImageMaintenance(ctx, shootMaintenanceTest.ShootMachineImage, true, time.Now().Add(time.Minute*1))
+		Expect(err).To(BeNil())
+
+		By("every Node in the pool should actually report the new machine image, not just the Shoot spec")
+		shootClient, err := f.GetShootClient(ctx, shootMaintenanceTest.Shoot)
+		Expect(err).To(BeNil())
+
+		err = shootMaintenanceTest.WaitForWorkerRollout(ctx, shootClient, pool, shootMaintenanceTest.ShootMachineImage.Version, 10*time.Minute)
+		Expect(err).To(BeNil())
+	}, WaitForCreateDeleteTimeout)
 })
 
 func prepareCloudProfile(ctx context.Context, f *framework.GardenerFramework) {
@@ -421,6 +610,11 @@ func prepareCloudProfile(ctx context.Context, f *framework.GardenerFramework) {
 
 	// add  test kubernetes versions (one low patch version, one high patch version)
 	profile.Spec.Kubernetes.Versions = append(profile.Spec.Kubernetes.Versions, testKubernetesVersionLowMinor, testHighestPatchKubernetesVersionLowMinor, testKubernetesVersionHighMinor, testHighestPatchKubernetesVersionHighMinor)
+	// add the three-minor-hop upgrade chain used by the multi-hop forced upgrade test
+	profile.Spec.Kubernetes.Versions = append(profile.Spec.Kubernetes.Versions, testKubernetesVersionChainStart, testKubernetesVersionChainMinor1, testKubernetesVersionChainMinor2, testKubernetesVersionChainFinal)
+	// add versions spread across the "stable" and "rapid" maintenance channels used by the channel-restricted
+	// auto-update test
+	profile.Spec.Kubernetes.Versions = append(profile.Spec.Kubernetes.Versions, testKubernetesVersionChannelBase, testKubernetesVersionChannelStable, testKubernetesVersionChannelRapid)
 	err = f.GardenClient.Client().Update(ctx, profile)
 	Expect(err).To(BeNil())
 }